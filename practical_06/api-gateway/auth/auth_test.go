@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/douglasswm/student-cafe-protos/principal"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testKey is an Ed25519 signing key published under an ID
+type testKey struct {
+	id      string
+	private ed25519.PrivateKey
+}
+
+func newTestKey(t *testing.T, id string) testKey {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return testKey{id: id, private: private}
+}
+
+// jwk returns the public half of the key as the User Service publishes it
+func (k testKey) jwk() *userv1.JSONWebKey {
+	return &userv1.JSONWebKey{
+		Kty: "OKP",
+		Kid: k.id,
+		Use: "sig",
+		Alg: "EdDSA",
+		Crv: "Ed25519",
+		X:   base64.RawURLEncoding.EncodeToString(k.private.Public().(ed25519.PublicKey)),
+	}
+}
+
+// sign issues an access token like the User Service does
+func (k testKey) sign(t *testing.T, subject, role string, expiresAt time.Time) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    DefaultIssuer,
+			Audience:  jwt.ClaimStrings{DefaultIssuer},
+			Subject:   subject,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Role: role,
+	})
+	token.Header["kid"] = k.id
+	signed, err := token.SignedString(k.private)
+	require.NoError(t, err)
+	return signed
+}
+
+// testVerifier returns a verifier whose keys come from *published, and the
+// number of times they were fetched
+func testVerifier(published *[]*userv1.JSONWebKey, now *time.Time) (*Verifier, *int) {
+	fetches := 0
+	v := NewVerifier(func(ctx context.Context) ([]*userv1.JSONWebKey, error) {
+		fetches++
+		return *published, nil
+	})
+	v.clock = func() time.Time { return *now }
+	return v, &fetches
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	key := newTestKey(t, "key-1")
+	published := []*userv1.JSONWebKey{key.jwk()}
+	v, fetches := testVerifier(&published, &now)
+	ctx := context.Background()
+
+	t.Run("valid", func(t *testing.T) {
+		p, err := v.Verify(ctx, key.sign(t, "42", principal.RoleOwner, now.Add(time.Minute)))
+		require.NoError(t, err)
+		assert.Equal(t, principal.Principal{UserID: 42, Role: principal.RoleOwner}, p)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		_, err := v.Verify(ctx, key.sign(t, "42", principal.RoleStudent, now.Add(-time.Second)))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("signed by an unknown key", func(t *testing.T) {
+		_, err := v.Verify(ctx, newTestKey(t, "key-1").sign(t, "42", principal.RoleOwner, now.Add(time.Minute)))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("bad subject", func(t *testing.T) {
+		_, err := v.Verify(ctx, key.sign(t, "alice", principal.RoleStudent, now.Add(time.Minute)))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("keys are cached", func(t *testing.T) {
+		assert.Equal(t, 1, *fetches)
+	})
+
+	t.Run("new keys are fetched, at most every minKeyFetchInterval", func(t *testing.T) {
+		rotated := newTestKey(t, "key-2")
+		published = []*userv1.JSONWebKey{rotated.jwk(), key.jwk()}
+		token := rotated.sign(t, "7", principal.RoleStudent, now.Add(time.Hour))
+
+		_, err := v.Verify(ctx, token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+		assert.Equal(t, 1, *fetches)
+
+		now = now.Add(minKeyFetchInterval)
+		p, err := v.Verify(ctx, token)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(7), p.UserID)
+		assert.Equal(t, 2, *fetches)
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	now := time.Now()
+	key := newTestKey(t, "key-1")
+	published := []*userv1.JSONWebKey{key.jwk()}
+	v, _ := testVerifier(&published, &now)
+
+	var caller principal.Principal
+	var authenticated bool
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		caller, authenticated = FromContext(r.Context())
+	})
+	public := v.Authenticate(handler)
+	protected := v.Authenticate(RequireAuth(handler))
+
+	serve := func(h http.Handler, authorization string) *httptest.ResponseRecorder {
+		caller, authenticated = principal.Principal{}, false
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	valid := "Bearer " + key.sign(t, "42", principal.RoleStudent, now.Add(time.Minute))
+
+	t.Run("anonymous public route", func(t *testing.T) {
+		rec := serve(public, "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.False(t, authenticated)
+	})
+
+	t.Run("anonymous protected route", func(t *testing.T) {
+		rec := serve(protected, "")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("authenticated", func(t *testing.T) {
+		rec := serve(protected, valid)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, authenticated)
+		assert.Equal(t, principal.Principal{UserID: 42, Role: principal.RoleStudent}, caller)
+	})
+
+	t.Run("invalid token is rejected even on public routes", func(t *testing.T) {
+		rec := serve(public, "Bearer not.a.token")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Header().Get("WWW-Authenticate"), "invalid_token")
+		assert.False(t, authenticated)
+	})
+
+	t.Run("other schemes are rejected", func(t *testing.T) {
+		rec := serve(protected, "Basic YWxpY2U6c2VjcmV0")
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
\ No newline at end of file
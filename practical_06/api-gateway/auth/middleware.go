@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/douglasswm/student-cafe-protos/principal"
+)
+
+// contextKey is the request context key of the authenticated caller
+type contextKey struct{}
+
+// NewContext returns ctx carrying the authenticated caller
+func NewContext(ctx context.Context, p principal.Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the authenticated caller of a request, if any
+func FromContext(ctx context.Context) (principal.Principal, bool) {
+	p, ok := ctx.Value(contextKey{}).(principal.Principal)
+	return p, ok
+}
+
+// Authenticate verifies the bearer token of requests that send one and makes
+// its caller available with FromContext. Requests with an invalid token are
+// rejected; requests without one continue anonymously.
+func (v *Verifier) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		scheme, token, _ := strings.Cut(header, " ")
+		if !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			unauthorized(w, `Bearer error="invalid_request"`, "authorization header must be a bearer token")
+			return
+		}
+
+		p, err := v.Verify(r.Context(), strings.TrimSpace(token))
+		if err != nil {
+			log.Printf("Rejected access token: %v", err)
+			unauthorized(w, `Bearer error="invalid_token"`, "invalid or expired access token")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), p)))
+	})
+}
+
+// RequireAuth rejects requests that Authenticate did not find a caller for
+func RequireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := FromContext(r.Context()); !ok {
+			unauthorized(w, "Bearer", "authentication required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// unauthorized writes a 401 response with a WWW-Authenticate challenge
+func unauthorized(w http.ResponseWriter, challenge, message string) {
+	w.Header().Set("WWW-Authenticate", challenge)
+	http.Error(w, message, http.StatusUnauthorized)
+}
\ No newline at end of file
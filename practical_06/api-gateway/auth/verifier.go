@@ -0,0 +1,201 @@
+// Package auth authenticates API callers by their bearer access tokens. Tokens
+// are verified offline against the User Service's signing keys, which are
+// fetched once and cached.
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/douglasswm/student-cafe-protos/principal"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// DefaultIssuer is the default "iss" and "aud" access tokens must carry
+	DefaultIssuer = "student-cafe"
+
+	// keyRefreshInterval is how often the signing keys are fetched again, so
+	// keys added while rotating are picked up
+	keyRefreshInterval = 5 * time.Minute
+
+	// minKeyFetchInterval limits how often an unknown key ID may trigger a fetch
+	minKeyFetchInterval = 30 * time.Second
+)
+
+// ErrInvalidToken is returned for tokens that are malformed, expired, or not
+// signed by a known key
+var ErrInvalidToken = errors.New("invalid token")
+
+// KeySource fetches the public keys access tokens are signed with
+type KeySource func(ctx context.Context) ([]*userv1.JSONWebKey, error)
+
+// UserServiceKeys fetches the signing keys from the User Service
+func UserServiceKeys(client userv1.UserServiceClient) KeySource {
+	return func(ctx context.Context) ([]*userv1.JSONWebKey, error) {
+		resp, err := client.GetJWKS(ctx, &userv1.GetJWKSRequest{})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Keys, nil
+	}
+}
+
+// publicKey is a verification key and the algorithm it is used with
+type publicKey struct {
+	algorithm string
+	key       crypto.PublicKey
+}
+
+// Verifier verifies access tokens
+type Verifier struct {
+	Issuer   string // Required "iss"
+	Audience string // Required "aud"
+
+	source    KeySource
+	clock     func() time.Time // Overridden in tests
+	mu        sync.Mutex
+	keys      map[string]publicKey // By key ID
+	fetchedAt time.Time
+}
+
+// NewVerifier creates a verifier that fetches keys from source
+func NewVerifier(source KeySource) *Verifier {
+	return &Verifier{
+		Issuer:   DefaultIssuer,
+		Audience: DefaultIssuer,
+		source:   source,
+	}
+}
+
+// claims are the claims of an access token
+type claims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role"`
+}
+
+// Verify checks an access token and returns the caller it was issued to
+func (v *Verifier) Verify(ctx context.Context, token string) (principal.Principal, error) {
+	var c claims
+	_, err := jwt.ParseWithClaims(token, &c, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := v.key(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		if key.algorithm != token.Method.Alg() {
+			return nil, fmt.Errorf("key %q is not for %s", kid, token.Method.Alg())
+		}
+		return key.key, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(v.Issuer),
+		jwt.WithAudience(v.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(v.now),
+	)
+	if err != nil {
+		return principal.Principal{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	userID, err := strconv.ParseUint(c.Subject, 10, 32)
+	if err != nil || userID == 0 {
+		return principal.Principal{}, fmt.Errorf("%w: bad subject %q", ErrInvalidToken, c.Subject)
+	}
+	return principal.Principal{UserID: uint32(userID), Role: c.Role}, nil
+}
+
+// now returns the current time
+func (v *Verifier) now() time.Time {
+	if v.clock != nil {
+		return v.clock()
+	}
+	return time.Now()
+}
+
+// key returns the verification key with an ID. The cached keys are fetched
+// again when they are old or, at most every minKeyFetchInterval, when the ID
+// is unknown; if fetching fails the cached keys are kept.
+func (v *Verifier) key(ctx context.Context, kid string) (publicKey, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := v.now()
+	key, ok := v.keys[kid]
+	age := now.Sub(v.fetchedAt)
+	if (!ok && age >= minKeyFetchInterval) || age >= keyRefreshInterval {
+		if err := v.fetch(ctx, now); err != nil && !ok {
+			return publicKey{}, err
+		}
+		key, ok = v.keys[kid]
+	}
+	if !ok {
+		return publicKey{}, fmt.Errorf("unknown key %q", kid)
+	}
+	return key, nil
+}
+
+// fetch replaces the cached keys with those from the source
+func (v *Verifier) fetch(ctx context.Context, now time.Time) error {
+	v.fetchedAt = now
+	jwks, err := v.source(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch signing keys: %w", err)
+	}
+
+	keys := make(map[string]publicKey, len(jwks))
+	for _, jwk := range jwks {
+		key, err := parseJWK(jwk)
+		if err != nil {
+			return fmt.Errorf("signing key %q: %w", jwk.Kid, err)
+		}
+		keys[jwk.Kid] = key
+	}
+	v.keys = keys
+	return nil
+}
+
+// parseJWK converts an RSA or Ed25519 JSON Web Key to a public key
+func parseJWK(jwk *userv1.JSONWebKey) (publicKey, error) {
+	decode := base64.RawURLEncoding.DecodeString
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decode(jwk.N)
+		if err != nil {
+			return publicKey{}, err
+		}
+		e, err := decode(jwk.E)
+		if err != nil {
+			return publicKey{}, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return publicKey{}, errors.New("RSA exponent too large")
+		}
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+		return publicKey{algorithm: jwt.SigningMethodRS256.Alg(), key: key}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return publicKey{}, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := decode(jwk.X)
+		if err != nil {
+			return publicKey{}, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return publicKey{}, errors.New("bad Ed25519 key size")
+		}
+		return publicKey{algorithm: jwt.SigningMethodEdDSA.Alg(), key: ed25519.PublicKey(x)}, nil
+	}
+	return publicKey{}, fmt.Errorf("unsupported key type %q", jwk.Kty)
+}
\ No newline at end of file
@@ -8,8 +8,8 @@ import (
 	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
 	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
 	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/douglasswm/student-cafe-protos/mtls"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // ServiceClients holds all gRPC clients for backend services
@@ -26,10 +26,20 @@ func NewServiceClients() (*ServiceClients, error) {
 	menuAddr := getEnv("MENU_SERVICE_GRPC_ADDR", "menu-service:9092")
 	orderAddr := getEnv("ORDER_SERVICE_GRPC_ADDR", "order-service:9093")
 
+	// The services only accept callers with a certificate from the cafe's CA
+	tlsConfig, err := mtls.FromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure TLS: %w", err)
+	}
+	creds, err := tlsConfig.ClientCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS credentials: %w", err)
+	}
+
 	log.Printf("Connecting to User Service at %s", userAddr)
 	// Create gRPC connection to user service
 	userConn, err := grpc.NewClient(userAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()))
+		grpc.WithTransportCredentials(creds))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to user service: %w", err)
 	}
@@ -37,7 +47,7 @@ func NewServiceClients() (*ServiceClients, error) {
 	log.Printf("Connecting to Menu Service at %s", menuAddr)
 	// Create gRPC connection to menu service
 	menuConn, err := grpc.NewClient(menuAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()))
+		grpc.WithTransportCredentials(creds))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to menu service: %w", err)
 	}
@@ -45,7 +55,7 @@ func NewServiceClients() (*ServiceClients, error) {
 	log.Printf("Connecting to Order Service at %s", orderAddr)
 	// Create gRPC connection to order service
 	orderConn, err := grpc.NewClient(orderAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()))
+		grpc.WithTransportCredentials(creds))
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to order service: %w", err)
 	}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+)
+
+// jwksMaxAge is how long, in seconds, clients may cache the JSON Web Key Set.
+// Keep a retired signing key verifiable for at least this long.
+const jwksMaxAge = "300"
+
+// Register handles POST /api/auth/register
+// Translates HTTP request to gRPC Register call
+func (h *Handlers) Register(w http.ResponseWriter, r *http.Request) {
+	// Parse HTTP JSON request body
+	var req struct {
+		Name     string `json:"name"`
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Call gRPC service
+	resp, err := h.clients.UserClient.Register(backendContext(context.Background(), r), &userv1.RegisterRequest{
+		Name:     req.Name,
+		Email:    req.Email,
+		Password: req.Password,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusCreated)
+	writeProtoJSON(w, resp)
+}
+
+// Login handles POST /api/auth/login
+// Translates HTTP request to gRPC Login call
+func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
+	// Parse HTTP JSON request body
+	var req struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Call gRPC service
+	resp, err := h.clients.UserClient.Login(backendContext(context.Background(), r), &userv1.LoginRequest{
+		Email:    req.Email,
+		Password: req.Password,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	writeProtoJSON(w, resp)
+}
+
+// RefreshToken handles POST /api/auth/refresh
+// Translates HTTP request to gRPC RefreshToken call
+func (h *Handlers) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	// Parse HTTP JSON request body
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Call gRPC service
+	resp, err := h.clients.UserClient.RefreshToken(backendContext(context.Background(), r), &userv1.RefreshTokenRequest{
+		RefreshToken: req.RefreshToken,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	writeProtoJSON(w, resp.Tokens)
+}
+
+// GetJWKS handles GET /.well-known/jwks.json
+// Translates HTTP request to gRPC GetJWKS call. The keys are written as a
+// standard JSON Web Key Set, leaving out members that do not apply to a key
+// type, so off-the-shelf JWT libraries can verify access tokens.
+func (h *Handlers) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	// Call gRPC service
+	resp, err := h.clients.UserClient.GetJWKS(backendContext(context.Background(), r), &userv1.GetJWKSRequest{})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	type jwk struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid,omitempty"`
+		Use string `json:"use,omitempty"`
+		Alg string `json:"alg,omitempty"`
+		Crv string `json:"crv,omitempty"`
+		X   string `json:"x,omitempty"`
+		N   string `json:"n,omitempty"`
+		E   string `json:"e,omitempty"`
+	}
+	set := struct {
+		Keys []jwk `json:"keys"`
+	}{Keys: []jwk{}}
+	for _, key := range resp.Keys {
+		set.Keys = append(set.Keys, jwk{
+			Kty: key.Kty, Kid: key.Kid, Use: key.Use, Alg: key.Alg,
+			Crv: key.Crv, X: key.X, N: key.N, E: key.E,
+		})
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/jwk-set+json")
+	w.Header().Set("Cache-Control", "public, max-age="+jwksMaxAge)
+	json.NewEncoder(w).Encode(set)
+}
\ No newline at end of file
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// CreateCategory handles POST /api/categories
+// Translates HTTP request to gRPC CreateCategory call
+func (h *Handlers) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	// Parse HTTP JSON request body
+	var req struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Position    *int32 `json:"position"` // Optional, omit to add the category last
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Call gRPC service
+	resp, err := h.clients.MenuClient.CreateCategory(backendContext(context.Background(), r), &menuv1.CreateCategoryRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Position:    req.Position,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	writeProtoJSON(w, resp.Category)
+}
+
+// GetCategory handles GET /api/categories/{id}
+// Translates HTTP request to gRPC GetCategory call
+func (h *Handlers) GetCategory(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from URL path
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	// Call gRPC service
+	resp, err := h.clients.MenuClient.GetCategory(backendContext(context.Background(), r), &menuv1.GetCategoryRequest{
+		Id: uint32(id),
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	writeProtoJSON(w, resp.Category)
+}
+
+// ListCategories handles GET /api/categories
+// Translates HTTP request to gRPC ListCategories call
+func (h *Handlers) ListCategories(w http.ResponseWriter, r *http.Request) {
+	// Call gRPC service
+	resp, err := h.clients.MenuClient.ListCategories(backendContext(context.Background(), r), &menuv1.ListCategoriesRequest{})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	writeProtoJSONList(w, resp.Categories)
+}
+
+// UpdateCategory handles PATCH /api/categories/{id}
+// Translates HTTP request to gRPC UpdateCategory call. Only the fields present
+// in the body are updated.
+func (h *Handlers) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from URL path
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	// Parse HTTP JSON request body, keeping track of which fields were sent
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	category := &menuv1.Category{Id: uint32(id)}
+	mask := &fieldmaskpb.FieldMask{}
+	for name, value := range fields {
+		var err error
+		switch name {
+		case "name":
+			err = json.Unmarshal(value, &category.Name)
+		case "description":
+			err = json.Unmarshal(value, &category.Description)
+		case "position":
+			err = json.Unmarshal(value, &category.Position)
+		default:
+			http.Error(w, fmt.Sprintf("field %q cannot be updated", name), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s", name), http.StatusBadRequest)
+			return
+		}
+		mask.Paths = append(mask.Paths, name)
+	}
+	sort.Strings(mask.Paths)
+
+	// Call gRPC service
+	resp, err := h.clients.MenuClient.UpdateCategory(backendContext(context.Background(), r), &menuv1.UpdateCategoryRequest{
+		Category:   category,
+		UpdateMask: mask,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	writeProtoJSON(w, resp.Category)
+}
+
+// DeleteCategory handles DELETE /api/categories/{id}
+// Translates HTTP request to gRPC DeleteCategory call
+func (h *Handlers) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from URL path
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid category ID", http.StatusBadRequest)
+		return
+	}
+
+	// Call gRPC service
+	_, err = h.clients.MenuClient.DeleteCategory(backendContext(context.Background(), r), &menuv1.DeleteCategoryRequest{
+		Id: uint32(id),
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
\ No newline at end of file
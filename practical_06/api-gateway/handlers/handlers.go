@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 
+	"api-gateway/auth"
 	"api-gateway/grpc"
 
+	"github.com/douglasswm/student-cafe-protos/principal"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 // Handlers holds the HTTP handlers and gRPC clients
@@ -19,6 +24,48 @@ func NewHandlers(clients *grpc.ServiceClients) *Handlers {
 	return &Handlers{clients: clients}
 }
 
+// backendContext returns ctx carrying the caller the gateway authenticated for
+// r, if any, as gRPC metadata, for the calls made to backend services
+func backendContext(ctx context.Context, r *http.Request) context.Context {
+	if p, ok := auth.FromContext(r.Context()); ok {
+		return principal.NewOutgoingContext(ctx, p)
+	}
+	return ctx
+}
+
+// protoJSON renders proto messages in responses. Field names match the .proto
+// files, zero values are kept so a free item still shows its price, and 64-bit
+// integers such as Money.minor_units are strings so JavaScript clients read
+// them exactly.
+var protoJSON = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// writeProtoJSON writes msg as the JSON response body
+func writeProtoJSON(w http.ResponseWriter, msg proto.Message) {
+	data, err := protoJSON.Marshal(msg)
+	if err != nil {
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+	w.Write(data)
+}
+
+// writeProtoJSONList writes msgs as a JSON array response body
+func writeProtoJSONList[T proto.Message](w http.ResponseWriter, msgs []T) {
+	data := []byte{'['}
+	for i, msg := range msgs {
+		if i > 0 {
+			data = append(data, ',')
+		}
+		item, err := protoJSON.Marshal(msg)
+		if err != nil {
+			http.Error(w, "failed to encode response", http.StatusInternalServerError)
+			return
+		}
+		data = append(data, item...)
+	}
+	w.Write(append(data, ']'))
+}
+
 // handleGRPCError converts gRPC errors to appropriate HTTP status codes
 func handleGRPCError(w http.ResponseWriter, err error) {
 	st, ok := status.FromError(err)
@@ -35,7 +82,7 @@ func handleGRPCError(w http.ResponseWriter, err error) {
 		httpStatus = http.StatusNotFound
 	case codes.InvalidArgument:
 		httpStatus = http.StatusBadRequest
-	case codes.AlreadyExists:
+	case codes.AlreadyExists, codes.ResourceExhausted, codes.Aborted:
 		httpStatus = http.StatusConflict
 	case codes.PermissionDenied:
 		httpStatus = http.StatusForbidden
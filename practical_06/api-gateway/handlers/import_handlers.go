@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	commonv1 "github.com/douglasswm/student-cafe-protos/gen/go/common/v1"
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// maxImportSize bounds the size of an uploaded import file
+const maxImportSize = 10 << 20
+
+// importColumns are the columns a CSV import may have. name and price are required.
+var importColumns = []string{"name", "description", "price", "currency_code", "stock", "category_id", "allergens", "dietary_tags", "calories"}
+
+// ImportMenuItems handles POST /api/menu/import
+// Streams an uploaded file of menu items into the gRPC ImportMenuItems call.
+// The body is either a JSON array of items shaped like the POST /api/menu body,
+// or CSV (Content-Type: text/csv) with a header row naming the columns; prices
+// are in minor units and allergens and dietary_tags are separated by ';'.
+// ?dry_run=true validates the file without saving it and ?upsert=true updates
+// items with the same name instead of creating new ones. The response is the
+// per-row report: 200 when every row was valid, 422 when nothing was saved
+// because some rows failed.
+func (h *Handlers) ImportMenuItems(w http.ResponseWriter, r *http.Request) {
+	options := &menuv1.ImportOptions{}
+	if v := r.URL.Query().Get("dry_run"); v != "" {
+		dryRun, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "invalid dry_run", http.StatusBadRequest)
+			return
+		}
+		options.DryRun = dryRun
+	}
+	if v := r.URL.Query().Get("upsert"); v != "" {
+		upsert, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "invalid upsert", http.StatusBadRequest)
+			return
+		}
+		options.UpsertByName = upsert
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+	var next func() (*menuv1.CreateMenuItemRequest, error)
+	var err error
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		next, err = csvImportRows(body)
+	case "application/json", "":
+		next, err = jsonImportRows(body)
+	default:
+		http.Error(w, "import must be text/csv or application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Cancelling the call abandons the import, so nothing is saved
+	ctx, cancel := context.WithCancel(backendContext(r.Context(), r))
+	defer cancel()
+
+	// Call gRPC service, sending the rows as they are parsed
+	stream, err := h.clients.MenuClient.ImportMenuItems(ctx)
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	err = stream.Send(&menuv1.ImportMenuItemsRequest{
+		Payload: &menuv1.ImportMenuItemsRequest_Options{Options: options},
+	})
+	for row := 1; err == nil; row++ {
+		item, parseErr := next()
+		if parseErr == io.EOF {
+			break
+		}
+		if parseErr != nil {
+			cancel()
+			http.Error(w, fmt.Sprintf("row %d: %v", row, parseErr), http.StatusBadRequest)
+			return
+		}
+		err = stream.Send(&menuv1.ImportMenuItemsRequest{
+			Payload: &menuv1.ImportMenuItemsRequest_MenuItem{MenuItem: item},
+		})
+	}
+	// A failed Send means the service ended the call; CloseAndRecv returns why
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	if resp.Failed > 0 {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	writeProtoJSON(w, resp)
+}
+
+// jsonImportRows reads a JSON array of menu items one element at a time
+func jsonImportRows(body io.Reader) (func() (*menuv1.CreateMenuItemRequest, error), error) {
+	dec := json.NewDecoder(body)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return nil, errors.New("import body must be a JSON array of menu items")
+	}
+
+	return func() (*menuv1.CreateMenuItemRequest, error) {
+		if !dec.More() {
+			return nil, io.EOF
+		}
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, errors.New("invalid JSON")
+		}
+		item := &menuv1.CreateMenuItemRequest{}
+		if err := protojson.Unmarshal(raw, item); err != nil {
+			return nil, fmt.Errorf("invalid menu item: %v", err)
+		}
+		return item, nil
+	}, nil
+}
+
+// csvImportRows reads CSV menu items, checking the header row up front
+func csvImportRows(body io.Reader) (func() (*menuv1.CreateMenuItemRequest, error), error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("CSV import must start with a header row")
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(importColumns, name) {
+			return nil, fmt.Errorf("unknown column %q, expected some of %s", name, strings.Join(importColumns, ", "))
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("duplicate column %q", name)
+		}
+		columns[name] = i
+	}
+	for _, name := range []string{"name", "price"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	return func() (*menuv1.CreateMenuItemRequest, error) {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, errors.New("invalid CSV")
+		}
+		return csvImportRow(columns, record)
+	}, nil
+}
+
+// csvImportRow converts one CSV record. Empty cells leave the field unset.
+func csvImportRow(columns map[string]int, record []string) (*menuv1.CreateMenuItemRequest, error) {
+	cell := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	item := &menuv1.CreateMenuItemRequest{
+		Name:        cell("name"),
+		Description: cell("description"),
+		Allergens:   splitList(cell("allergens")),
+		DietaryTags: splitList(cell("dietary_tags")),
+	}
+
+	minorUnits, err := strconv.ParseInt(cell("price"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid price %q, expected minor units such as 350", cell("price"))
+	}
+	item.Price = &commonv1.Money{MinorUnits: minorUnits, CurrencyCode: cell("currency_code")}
+
+	if v := cell("stock"); v != "" {
+		stock, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stock %q", v)
+		}
+		s := int32(stock)
+		item.Stock = &s
+	}
+
+	if v := cell("category_id"); v != "" {
+		categoryID, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid category_id %q", v)
+		}
+		item.CategoryId = uint32(categoryID)
+	}
+
+	if v := cell("calories"); v != "" {
+		calories, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid calories %q", v)
+		}
+		c := int32(calories)
+		item.Calories = &c
+	}
+
+	return item, nil
+}
+
+// splitList splits a ';' separated CSV cell, e.g. "milk;gluten"
+func splitList(value string) []string {
+	var codes []string
+	for _, code := range strings.Split(value, ";") {
+		if code = strings.TrimSpace(code); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
\ No newline at end of file
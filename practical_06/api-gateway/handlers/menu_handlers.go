@@ -3,11 +3,19 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
+	commonv1 "github.com/douglasswm/student-cafe-protos/gen/go/common/v1"
 	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
 	"github.com/go-chi/chi/v5"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 // CreateMenuItem handles POST /api/menu
@@ -15,9 +23,18 @@ import (
 func (h *Handlers) CreateMenuItem(w http.ResponseWriter, r *http.Request) {
 	// Parse HTTP JSON request body
 	var req struct {
-		Name        string  `json:"name"`
-		Description string  `json:"description"`
-		Price       float64 `json:"price"`
+		Name        string          `json:"name"`
+		Description string          `json:"description"`
+		Price       json.RawMessage `json:"price"`        // Money, e.g. {"currency_code": "USD", "minor_units": 350}
+		Stock       *int32          `json:"stock"`        // Optional, omit for untracked stock
+		CategoryID  uint32          `json:"category_id"`  // Optional, omit to leave the item uncategorised
+		Allergens   []string        `json:"allergens"`    // Optional allergen codes, e.g. ["milk", "gluten"]
+		DietaryTags []string        `json:"dietary_tags"` // Optional dietary tag codes, e.g. ["vegan"]
+		Calories    *int32          `json:"calories"`     // Optional, omit when not known
+		// Optional, e.g. [{"weekday": "monday", "start_time": "07:00", "end_time": "11:00"}]; omit for always
+		Availability []*menuv1.AvailabilityWindow `json:"availability"`
+		// Optional, e.g. [{"name": "Milk", "min_selections": 1, "max_selections": 1, "modifiers": [...]}]
+		ModifierGroups json.RawMessage `json:"modifier_groups"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -25,11 +42,30 @@ func (h *Handlers) CreateMenuItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	price := &commonv1.Money{}
+	if len(req.Price) == 0 || protojson.Unmarshal(req.Price, price) != nil {
+		http.Error(w, `price must be an object such as {"currency_code": "USD", "minor_units": 350}`, http.StatusBadRequest)
+		return
+	}
+
+	modifierGroups, err := parseModifierGroups(req.ModifierGroups)
+	if err != nil {
+		http.Error(w, "invalid modifier_groups", http.StatusBadRequest)
+		return
+	}
+
 	// Call gRPC service
-	resp, err := h.clients.MenuClient.CreateMenuItem(context.Background(), &menuv1.CreateMenuItemRequest{
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       req.Price,
+	resp, err := h.clients.MenuClient.CreateMenuItem(backendContext(context.Background(), r), &menuv1.CreateMenuItemRequest{
+		Name:           req.Name,
+		Description:    req.Description,
+		Price:          price,
+		Stock:          req.Stock,
+		CategoryId:     req.CategoryID,
+		Allergens:      req.Allergens,
+		DietaryTags:    req.DietaryTags,
+		Calories:       req.Calories,
+		Availability:   req.Availability,
+		ModifierGroups: modifierGroups,
 	})
 
 	if err != nil {
@@ -40,7 +76,7 @@ func (h *Handlers) CreateMenuItem(w http.ResponseWriter, r *http.Request) {
 	// Return HTTP JSON response
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(resp.MenuItem)
+	writeProtoJSON(w, resp.MenuItem)
 }
 
 // GetMenuItem handles GET /api/menu/{id}
@@ -54,9 +90,20 @@ func (h *Handlers) GetMenuItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Deleted items are only returned when asked for, e.g. to show a past order
+	includeDeleted := false
+	if v := r.URL.Query().Get("include_deleted"); v != "" {
+		includeDeleted, err = strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "invalid include_deleted", http.StatusBadRequest)
+			return
+		}
+	}
+
 	// Call gRPC service
-	resp, err := h.clients.MenuClient.GetMenuItem(context.Background(), &menuv1.GetMenuItemRequest{
-		Id: uint32(id),
+	resp, err := h.clients.MenuClient.GetMenuItem(backendContext(context.Background(), r), &menuv1.GetMenuItemRequest{
+		Id:             uint32(id),
+		IncludeDeleted: includeDeleted,
 	})
 
 	if err != nil {
@@ -66,14 +113,221 @@ func (h *Handlers) GetMenuItem(w http.ResponseWriter, r *http.Request) {
 
 	// Return HTTP JSON response
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp.MenuItem)
+	writeProtoJSON(w, resp.MenuItem)
 }
 
 // GetMenu handles GET /api/menu
-// Translates HTTP request to gRPC GetMenu call
+// Translates HTTP request to gRPC GetMenu call.
+// Supports the query parameters q, min_price and max_price (in minor units of
+// the menu's currency), category_id, available, available_now,
+// exclude_allergens and dietary_tags (comma separated codes), max_calories,
+// order_by, page_size and page_token. The token for the next page, if any, is returned in the
+// X-Next-Page-Token response header, and the menu revision the response reflects
+// in X-Menu-Revision. With ?group_by=category the response is
+// a list of sections, one per category, instead of a list of items.
 func (h *Handlers) GetMenu(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &menuv1.GetMenuRequest{
+		Query:     query.Get("q"),
+		OrderBy:   query.Get("order_by"),
+		PageToken: query.Get("page_token"),
+	}
+
+	switch query.Get("group_by") {
+	case "":
+	case "category":
+		req.GroupByCategory = true
+	default:
+		http.Error(w, "invalid group_by, expected category", http.StatusBadRequest)
+		return
+	}
+
+	if v := query.Get("min_price"); v != "" {
+		minorUnits, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid min_price", http.StatusBadRequest)
+			return
+		}
+		req.MinPrice = &commonv1.Money{MinorUnits: minorUnits}
+	}
+
+	if v := query.Get("max_price"); v != "" {
+		minorUnits, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid max_price", http.StatusBadRequest)
+			return
+		}
+		req.MaxPrice = &commonv1.Money{MinorUnits: minorUnits}
+	}
+
+	if v := query.Get("category_id"); v != "" {
+		categoryID, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			http.Error(w, "invalid category_id", http.StatusBadRequest)
+			return
+		}
+		req.CategoryId = uint32(categoryID)
+	}
+
+	if v := query.Get("available"); v != "" {
+		available, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "invalid available", http.StatusBadRequest)
+			return
+		}
+		req.AvailableOnly = available
+	}
+
+	if v := query.Get("available_now"); v != "" {
+		availableNow, err := strconv.ParseBool(v)
+		if err != nil {
+			http.Error(w, "invalid available_now", http.StatusBadRequest)
+			return
+		}
+		req.AvailableNow = availableNow
+	}
+
+	req.ExcludeAllergens = splitCodes(query["exclude_allergens"])
+	req.DietaryTags = splitCodes(query["dietary_tags"])
+
+	if v := query.Get("max_calories"); v != "" {
+		maxCalories, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			http.Error(w, "invalid max_calories", http.StatusBadRequest)
+			return
+		}
+		calories := int32(maxCalories)
+		req.MaxCalories = &calories
+	}
+
+	if v := query.Get("page_size"); v != "" {
+		pageSize, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			http.Error(w, "invalid page_size", http.StatusBadRequest)
+			return
+		}
+		req.PageSize = int32(pageSize)
+	}
+
+	// Call gRPC service
+	resp, err := h.clients.MenuClient.GetMenu(backendContext(context.Background(), r), req)
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	if resp.NextPageToken != "" {
+		w.Header().Set("X-Next-Page-Token", resp.NextPageToken)
+	}
+	w.Header().Set("X-Menu-Revision", strconv.FormatUint(resp.Revision, 10))
+	w.Header().Set("Content-Type", "application/json")
+	if req.GroupByCategory {
+		writeProtoJSONList(w, resp.Sections)
+		return
+	}
+	writeProtoJSONList(w, resp.MenuItems)
+}
+
+// parseModifierGroups parses a JSON array of modifier groups. Price deltas are
+// Money objects, so the groups are read with the proto JSON mapping.
+func parseModifierGroups(data json.RawMessage) ([]*menuv1.ModifierGroup, error) {
+	var raw []json.RawMessage
+	if len(data) == 0 {
+		return nil, nil
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	groups := make([]*menuv1.ModifierGroup, len(raw))
+	for i, group := range raw {
+		groups[i] = &menuv1.ModifierGroup{}
+		if err := protojson.Unmarshal(group, groups[i]); err != nil {
+			return nil, err
+		}
+	}
+	return groups, nil
+}
+
+// splitCodes collects the codes of a query parameter that may be repeated,
+// comma separated or both, e.g. ?exclude_allergens=milk,eggs&exclude_allergens=soy
+func splitCodes(values []string) []string {
+	var codes []string
+	for _, value := range values {
+		for _, code := range strings.Split(value, ",") {
+			if code = strings.TrimSpace(code); code != "" {
+				codes = append(codes, code)
+			}
+		}
+	}
+	return codes
+}
+
+// UpdateMenuItem handles PATCH /api/menu/{id}
+// Translates HTTP request to gRPC UpdateMenuItem call. Only the fields present
+// in the body are updated; "stock": null stops tracking stock for the item
+// and "calories": null clears its calories.
+func (h *Handlers) UpdateMenuItem(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from URL path
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid menu item ID", http.StatusBadRequest)
+		return
+	}
+
+	// Parse HTTP JSON request body, keeping track of which fields were sent
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	item := &menuv1.MenuItem{Id: uint32(id)}
+	mask := &fieldmaskpb.FieldMask{}
+	for name, value := range fields {
+		var err error
+		switch name {
+		case "name":
+			err = json.Unmarshal(value, &item.Name)
+		case "description":
+			err = json.Unmarshal(value, &item.Description)
+		case "price":
+			item.Price = &commonv1.Money{}
+			err = protojson.Unmarshal(value, item.Price)
+		case "stock":
+			err = json.Unmarshal(value, &item.Stock)
+		case "category_id":
+			err = json.Unmarshal(value, &item.CategoryId)
+		case "allergens":
+			err = json.Unmarshal(value, &item.Allergens)
+		case "dietary_tags":
+			err = json.Unmarshal(value, &item.DietaryTags)
+		case "calories":
+			err = json.Unmarshal(value, &item.Calories)
+		case "availability":
+			err = json.Unmarshal(value, &item.Availability)
+		case "modifier_groups":
+			item.ModifierGroups, err = parseModifierGroups(value)
+		default:
+			http.Error(w, fmt.Sprintf("field %q cannot be updated", name), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s", name), http.StatusBadRequest)
+			return
+		}
+		mask.Paths = append(mask.Paths, name)
+	}
+	sort.Strings(mask.Paths)
+
 	// Call gRPC service
-	resp, err := h.clients.MenuClient.GetMenu(context.Background(), &menuv1.GetMenuRequest{})
+	resp, err := h.clients.MenuClient.UpdateMenuItem(backendContext(context.Background(), r), &menuv1.UpdateMenuItemRequest{
+		MenuItem:   item,
+		UpdateMask: mask,
+	})
 
 	if err != nil {
 		handleGRPCError(w, err)
@@ -82,5 +336,117 @@ func (h *Handlers) GetMenu(w http.ResponseWriter, r *http.Request) {
 
 	// Return HTTP JSON response
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp.MenuItems)
+	writeProtoJSON(w, resp.MenuItem)
+}
+
+// DeleteMenuItem handles DELETE /api/menu/{id}
+// Translates HTTP request to gRPC DeleteMenuItem call
+func (h *Handlers) DeleteMenuItem(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from URL path
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid menu item ID", http.StatusBadRequest)
+		return
+	}
+
+	// Call gRPC service
+	_, err = h.clients.MenuClient.DeleteMenuItem(backendContext(context.Background(), r), &menuv1.DeleteMenuItemRequest{
+		Id: uint32(id),
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// WatchMenu handles GET /api/menu/events
+// Translates the gRPC WatchMenu stream into Server-Sent Events, one "menu"
+// event per change with the menu revision as its id. Clients resume with the
+// Last-Event-ID header, which EventSource sends when it reconnects, or with
+// ?since_revision= set to the revision of a GET /api/menu response.
+func (h *Handlers) WatchMenu(w http.ResponseWriter, r *http.Request) {
+	req := &menuv1.WatchMenuRequest{}
+	since := r.Header.Get("Last-Event-ID")
+	if v := r.URL.Query().Get("since_revision"); v != "" {
+		since = v
+	}
+	if since != "" {
+		revision, err := strconv.ParseUint(since, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid since_revision", http.StatusBadRequest)
+			return
+		}
+		req.SinceRevision = &revision
+	}
+
+	// Call gRPC service, tied to the lifetime of the HTTP request
+	stream, err := h.clients.MenuClient.WatchMenu(backendContext(r.Context(), r), req)
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// The menu may not change for a long time, so the stream starts straight
+	// away; errors such as an unknown revision arrive as an "error" event
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	rc.Flush()
+
+	// Receive on a separate goroutine so heartbeats can be sent while waiting
+	type result struct {
+		resp *menuv1.WatchMenuResponse
+		err  error
+	}
+	results := make(chan result)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			select {
+			case results <- result{resp, err}:
+			case <-r.Context().Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			rc.Flush()
+		case res := <-results:
+			if res.err != nil {
+				st, _ := status.FromError(res.err)
+				data, _ := json.Marshal(map[string]string{"error": st.Message()})
+				fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+				rc.Flush()
+				return
+			}
+			data, err := protoJSON.Marshal(res.resp.Event)
+			if err != nil {
+				return
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: menu\ndata: %s\n\n", res.resp.Event.Revision, data); err != nil {
+				return
+			}
+			rc.Flush()
+		}
+	}
 }
\ No newline at end of file
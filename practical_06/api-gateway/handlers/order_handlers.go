@@ -3,22 +3,29 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
 	"github.com/go-chi/chi/v5"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // CreateOrder handles POST /api/orders
-// Translates HTTP request to gRPC CreateOrder call
+// Translates HTTP request to gRPC CreateOrder call. The order is placed for
+// the authenticated caller; a user_id in the body is ignored.
 func (h *Handlers) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	// Parse HTTP JSON request body
 	var req struct {
-		UserID uint32 `json:"user_id"`
-		Items  []struct {
-			MenuItemID uint32 `json:"menu_item_id"`
-			Quantity   uint32 `json:"quantity"`
+		Items []struct {
+			MenuItemID  uint32   `json:"menu_item_id"`
+			Quantity    uint32   `json:"quantity"`
+			ModifierIDs []uint32 `json:"modifier_ids"` // Optional, chosen from the item's modifier groups
+			Note        string   `json:"note"`         // Optional, e.g. "no onions"
 		} `json:"items"`
 	}
 
@@ -31,15 +38,22 @@ func (h *Handlers) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	var items []*orderv1.OrderItemRequest
 	for _, item := range req.Items {
 		items = append(items, &orderv1.OrderItemRequest{
-			MenuItemId: item.MenuItemID,
-			Quantity:   int32(item.Quantity),
+			MenuItemId:  item.MenuItemID,
+			Quantity:    int32(item.Quantity),
+			ModifierIds: item.ModifierIDs,
+			Note:        item.Note,
 		})
 	}
 
+	// Forward the Idempotency-Key so retried requests do not place the order twice
+	ctx := backendContext(context.Background(), r)
+	if key := r.Header.Get("Idempotency-Key"); key != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "idempotency-key", key)
+	}
+
 	// Call gRPC service
-	resp, err := h.clients.OrderClient.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
-		UserId: req.UserID,
-		Items:  items,
+	resp, err := h.clients.OrderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		Items: items,
 	})
 
 	if err != nil {
@@ -50,7 +64,7 @@ func (h *Handlers) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	// Return HTTP JSON response
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(resp.Order)
+	writeProtoJSON(w, resp.Order)
 }
 
 // GetOrder handles GET /api/orders/{id}
@@ -65,7 +79,7 @@ func (h *Handlers) GetOrder(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Call gRPC service
-	resp, err := h.clients.OrderClient.GetOrder(context.Background(), &orderv1.GetOrderRequest{
+	resp, err := h.clients.OrderClient.GetOrder(backendContext(context.Background(), r), &orderv1.GetOrderRequest{
 		Id: uint32(id),
 	})
 
@@ -76,14 +90,45 @@ func (h *Handlers) GetOrder(w http.ResponseWriter, r *http.Request) {
 
 	// Return HTTP JSON response
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp.Order)
+	writeProtoJSON(w, resp.Order)
 }
 
 // GetOrders handles GET /api/orders
-// Translates HTTP request to gRPC GetOrders call
+// Translates HTTP request to gRPC GetOrders call.
+// Supports the query parameters user_id, status, created_after, created_before,
+// order_by, page_size and page_token. The token for the next page, if any,
+// is returned in the X-Next-Page-Token response header. Only staff and owners
+// may list other users' orders; everyone else gets their own.
 func (h *Handlers) GetOrders(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := &orderv1.GetOrdersRequest{
+		Status:        query.Get("status"),
+		CreatedAfter:  query.Get("created_after"),
+		CreatedBefore: query.Get("created_before"),
+		OrderBy:       query.Get("order_by"),
+		PageToken:     query.Get("page_token"),
+	}
+
+	if v := query.Get("user_id"); v != "" {
+		userID, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			http.Error(w, "invalid user_id", http.StatusBadRequest)
+			return
+		}
+		req.UserId = uint32(userID)
+	}
+
+	if v := query.Get("page_size"); v != "" {
+		pageSize, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			http.Error(w, "invalid page_size", http.StatusBadRequest)
+			return
+		}
+		req.PageSize = int32(pageSize)
+	}
+
 	// Call gRPC service
-	resp, err := h.clients.OrderClient.GetOrders(context.Background(), &orderv1.GetOrdersRequest{})
+	resp, err := h.clients.OrderClient.GetOrders(backendContext(context.Background(), r), req)
 
 	if err != nil {
 		handleGRPCError(w, err)
@@ -91,6 +136,152 @@ func (h *Handlers) GetOrders(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return HTTP JSON response
+	if resp.NextPageToken != "" {
+		w.Header().Set("X-Next-Page-Token", resp.NextPageToken)
+	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp.Orders)
+	writeProtoJSONList(w, resp.Orders)
+}
+
+// UpdateOrderStatus handles PATCH /api/orders/{id}/status
+// Translates HTTP request to gRPC UpdateOrderStatus call
+func (h *Handlers) UpdateOrderStatus(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from URL path
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid order ID", http.StatusBadRequest)
+		return
+	}
+
+	// Parse HTTP JSON request body
+	var req struct {
+		Status string `json:"status"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Call gRPC service
+	resp, err := h.clients.OrderClient.UpdateOrderStatus(backendContext(context.Background(), r), &orderv1.UpdateOrderStatusRequest{
+		Id:     uint32(id),
+		Status: req.Status,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	writeProtoJSON(w, resp.Order)
+}
+
+// sseHeartbeatInterval is how often an idle event stream sends a comment to keep proxies from closing it
+const sseHeartbeatInterval = 15 * time.Second
+
+// WatchOrder handles GET /api/orders/{id}/events
+// Translates the gRPC WatchOrder stream into Server-Sent Events
+func (h *Handlers) WatchOrder(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from URL path
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid order ID", http.StatusBadRequest)
+		return
+	}
+
+	// Call gRPC service, tied to the lifetime of the HTTP request
+	stream, err := h.clients.OrderClient.WatchOrder(backendContext(r.Context(), r), &orderv1.WatchOrderRequest{
+		Id: uint32(id),
+	})
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Errors such as NotFound arrive with the first message, before any headers are sent
+	first, err := stream.Recv()
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	// Receive on a separate goroutine so heartbeats can be sent while waiting
+	type result struct {
+		resp *orderv1.WatchOrderResponse
+		err  error
+	}
+	results := make(chan result)
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			select {
+			case results <- result{resp, err}:
+			case <-r.Context().Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	if err := writeOrderEvent(w, first.Order); err != nil {
+		return
+	}
+	rc.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			rc.Flush()
+		case res := <-results:
+			if res.err == io.EOF {
+				// Order reached a final status
+				fmt.Fprint(w, "event: end\ndata: {}\n\n")
+				rc.Flush()
+				return
+			}
+			if res.err != nil {
+				st, _ := status.FromError(res.err)
+				data, _ := json.Marshal(map[string]string{"error": st.Message()})
+				fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+				rc.Flush()
+				return
+			}
+			if err := writeOrderEvent(w, res.resp.Order); err != nil {
+				return
+			}
+			rc.Flush()
+		}
+	}
+}
+
+// writeOrderEvent writes an order snapshot as a single SSE "order" event
+func writeOrderEvent(w io.Writer, order *orderv1.Order) error {
+	data, err := protoJSON.Marshal(order)
+	if err != nil {
+		return err
+	}
+	// The number of recorded transitions identifies how far along the order is
+	_, err = fmt.Fprintf(w, "id: %d\nevent: order\ndata: %s\n\n", len(order.StatusHistory), data)
+	return err
 }
\ No newline at end of file
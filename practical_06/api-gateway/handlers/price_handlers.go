@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	commonv1 "github.com/douglasswm/student-cafe-protos/gen/go/common/v1"
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// GetPriceHistory handles GET /api/menu/{id}/prices
+// Translates HTTP request to gRPC GetPriceHistory call. The response lists
+// every price of the item, scheduled ones included, by when they take effect.
+func (h *Handlers) GetPriceHistory(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from URL path
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid menu item ID", http.StatusBadRequest)
+		return
+	}
+
+	// Call gRPC service
+	resp, err := h.clients.MenuClient.GetPriceHistory(backendContext(context.Background(), r), &menuv1.GetPriceHistoryRequest{
+		MenuItemId: uint32(id),
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	writeProtoJSONList(w, resp.PriceChanges)
+}
+
+// SchedulePriceChange handles POST /api/menu/{id}/prices
+// Translates HTTP request to gRPC SchedulePriceChange call
+func (h *Handlers) SchedulePriceChange(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from URL path
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid menu item ID", http.StatusBadRequest)
+		return
+	}
+
+	// Parse HTTP JSON request body
+	var req struct {
+		Price         json.RawMessage `json:"price"`          // Money, e.g. {"currency_code": "USD", "minor_units": 350}
+		EffectiveFrom string          `json:"effective_from"` // RFC3339 time in the future
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	price := &commonv1.Money{}
+	if len(req.Price) == 0 || protojson.Unmarshal(req.Price, price) != nil {
+		http.Error(w, `price must be an object such as {"currency_code": "USD", "minor_units": 350}`, http.StatusBadRequest)
+		return
+	}
+
+	// Call gRPC service
+	resp, err := h.clients.MenuClient.SchedulePriceChange(backendContext(context.Background(), r), &menuv1.SchedulePriceChangeRequest{
+		MenuItemId:    uint32(id),
+		Price:         price,
+		EffectiveFrom: req.EffectiveFrom,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	writeProtoJSON(w, resp.PriceChange)
+}
\ No newline at end of file
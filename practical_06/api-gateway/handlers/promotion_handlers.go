@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// maxPromotionSize bounds the body of a promotion
+const maxPromotionSize = 64 << 10
+
+// CreatePromotion handles POST /api/promotions
+// Translates HTTP request to gRPC CreatePromotion call. The body is a
+// promotion in proto JSON form, e.g.
+// {"name": "Pastry happy hour", "kind": "percentage", "percent_off": 20, "category_ids": [2]}
+func (h *Handlers) CreatePromotion(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPromotionSize))
+	if err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	promotion := &orderv1.Promotion{}
+	if err := protojson.Unmarshal(body, promotion); err != nil {
+		http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Call gRPC service
+	resp, err := h.clients.OrderClient.CreatePromotion(backendContext(context.Background(), r), &orderv1.CreatePromotionRequest{
+		Promotion: promotion,
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	writeProtoJSON(w, resp.Promotion)
+}
+
+// ListPromotions handles GET /api/promotions
+// Translates HTTP request to gRPC ListPromotions call
+func (h *Handlers) ListPromotions(w http.ResponseWriter, r *http.Request) {
+	// Call gRPC service
+	resp, err := h.clients.OrderClient.ListPromotions(backendContext(context.Background(), r), &orderv1.ListPromotionsRequest{})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	writeProtoJSONList(w, resp.Promotions)
+}
+
+// DeletePromotion handles DELETE /api/promotions/{id}
+// Translates HTTP request to gRPC DeletePromotion call
+func (h *Handlers) DeletePromotion(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from URL path
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid promotion ID", http.StatusBadRequest)
+		return
+	}
+
+	// Call gRPC service
+	_, err = h.clients.OrderClient.DeletePromotion(backendContext(context.Background(), r), &orderv1.DeletePromotionRequest{
+		Id: uint32(id),
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
\ No newline at end of file
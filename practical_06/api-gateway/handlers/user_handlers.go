@@ -3,11 +3,14 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 
 	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
 	"github.com/go-chi/chi/v5"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 // CreateUser handles POST /api/users
@@ -18,6 +21,8 @@ func (h *Handlers) CreateUser(w http.ResponseWriter, r *http.Request) {
 		Name        string `json:"name"`
 		Email       string `json:"email"`
 		IsCafeOwner bool   `json:"is_cafe_owner"`
+		IsStaff     bool   `json:"is_staff"`
+		Password    string `json:"password"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -26,10 +31,12 @@ func (h *Handlers) CreateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Call gRPC service
-	resp, err := h.clients.UserClient.CreateUser(context.Background(), &userv1.CreateUserRequest{
+	resp, err := h.clients.UserClient.CreateUser(backendContext(context.Background(), r), &userv1.CreateUserRequest{
 		Name:        req.Name,
 		Email:       req.Email,
 		IsCafeOwner: req.IsCafeOwner,
+		IsStaff:     req.IsStaff,
+		Password:    req.Password,
 	})
 
 	if err != nil {
@@ -40,7 +47,7 @@ func (h *Handlers) CreateUser(w http.ResponseWriter, r *http.Request) {
 	// Return HTTP JSON response
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(resp.User)
+	writeProtoJSON(w, resp.User)
 }
 
 // GetUser handles GET /api/users/{id}
@@ -55,7 +62,7 @@ func (h *Handlers) GetUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Call gRPC service
-	resp, err := h.clients.UserClient.GetUser(context.Background(), &userv1.GetUserRequest{
+	resp, err := h.clients.UserClient.GetUser(backendContext(context.Background(), r), &userv1.GetUserRequest{
 		Id: uint32(id),
 	})
 
@@ -66,14 +73,14 @@ func (h *Handlers) GetUser(w http.ResponseWriter, r *http.Request) {
 
 	// Return HTTP JSON response
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp.User)
+	writeProtoJSON(w, resp.User)
 }
 
 // GetUsers handles GET /api/users
 // Translates HTTP request to gRPC GetUsers call
 func (h *Handlers) GetUsers(w http.ResponseWriter, r *http.Request) {
 	// Call gRPC service
-	resp, err := h.clients.UserClient.GetUsers(context.Background(), &userv1.GetUsersRequest{})
+	resp, err := h.clients.UserClient.GetUsers(backendContext(context.Background(), r), &userv1.GetUsersRequest{})
 
 	if err != nil {
 		handleGRPCError(w, err)
@@ -82,5 +89,115 @@ func (h *Handlers) GetUsers(w http.ResponseWriter, r *http.Request) {
 
 	// Return HTTP JSON response
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp.Users)
+	writeProtoJSONList(w, resp.Users)
+}
+
+// UpdateUser handles PATCH /api/users/{id}
+// Translates HTTP request to gRPC UpdateUser call. Only the fields present in
+// the body are updated.
+func (h *Handlers) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from URL path
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	// Parse HTTP JSON request body, keeping track of which fields were sent
+	var fields map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&fields); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	req := &userv1.UpdateUserRequest{
+		User:       &userv1.User{Id: uint32(id)},
+		UpdateMask: &fieldmaskpb.FieldMask{},
+	}
+	for name, value := range fields {
+		var err error
+		switch name {
+		case "name":
+			err = json.Unmarshal(value, &req.User.Name)
+		case "email":
+			err = json.Unmarshal(value, &req.User.Email)
+		case "is_cafe_owner":
+			err = json.Unmarshal(value, &req.User.IsCafeOwner)
+		case "is_staff":
+			err = json.Unmarshal(value, &req.User.IsStaff)
+		case "password":
+			err = json.Unmarshal(value, &req.Password)
+		default:
+			http.Error(w, fmt.Sprintf("field %q cannot be updated", name), http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s", name), http.StatusBadRequest)
+			return
+		}
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, name)
+	}
+	sort.Strings(req.UpdateMask.Paths)
+
+	// Call gRPC service
+	resp, err := h.clients.UserClient.UpdateUser(backendContext(context.Background(), r), req)
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	writeProtoJSON(w, resp.User)
+}
+
+// DeleteUser handles DELETE /api/users/{id}
+// Translates HTTP request to gRPC DeleteUser call. Responds with the user's
+// erasure: 200 once it has completed, or 202 while their orders are still to
+// be detached.
+func (h *Handlers) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	// Extract ID from URL path
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	// Call gRPC service
+	resp, err := h.clients.UserClient.DeleteUser(backendContext(context.Background(), r), &userv1.DeleteUserRequest{
+		Id: uint32(id),
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	if resp.Erasure.Status != "completed" {
+		w.WriteHeader(http.StatusAccepted)
+	}
+	writeProtoJSON(w, resp.Erasure)
+}
+
+// ListErasures handles GET /api/users/erasures
+// Translates HTTP request to gRPC ListErasures call, optionally filtered by ?status=
+func (h *Handlers) ListErasures(w http.ResponseWriter, r *http.Request) {
+	// Call gRPC service
+	resp, err := h.clients.UserClient.ListErasures(backendContext(context.Background(), r), &userv1.ListErasuresRequest{
+		Status: r.URL.Query().Get("status"),
+	})
+
+	if err != nil {
+		handleGRPCError(w, err)
+		return
+	}
+
+	// Return HTTP JSON response
+	w.Header().Set("Content-Type", "application/json")
+	writeProtoJSONList(w, resp.Erasures)
 }
\ No newline at end of file
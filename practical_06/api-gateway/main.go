@@ -3,7 +3,9 @@ package main
 import (
 	"log"
 	"net/http"
+	"os"
 
+	"api-gateway/auth"
 	"api-gateway/grpc"
 	"api-gateway/handlers"
 
@@ -22,25 +24,67 @@ func main() {
 	// Create handlers with gRPC clients
 	h := handlers.NewHandlers(clients)
 
+	// Verify access tokens against the User Service's signing keys
+	verifier := auth.NewVerifier(auth.UserServiceKeys(clients.UserClient))
+	if issuer := os.Getenv("JWT_ISSUER"); issuer != "" {
+		verifier.Issuer = issuer
+	}
+	if audience := os.Getenv("JWT_AUDIENCE"); audience != "" {
+		verifier.Audience = audience
+	}
+
 	// Setup HTTP router
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
+	r.Use(verifier.Authenticate)
+
+	// Routes registered on protected reject anonymous callers with 401
+	protected := r.With(auth.RequireAuth)
+
+	// Auth routes - HTTP to gRPC translation
+	r.Post("/api/auth/register", h.Register)
+	r.Post("/api/auth/login", h.Login)
+	r.Post("/api/auth/refresh", h.RefreshToken)
+	r.Get("/.well-known/jwks.json", h.GetJWKS)
 
 	// User routes - HTTP to gRPC translation
-	r.Post("/api/users", h.CreateUser)
-	r.Get("/api/users/{id}", h.GetUser)
-	r.Get("/api/users", h.GetUsers)
+	protected.Post("/api/users", h.CreateUser)
+	protected.Get("/api/users/erasures", h.ListErasures)
+	protected.Get("/api/users/{id}", h.GetUser)
+	protected.Get("/api/users", h.GetUsers)
+	protected.Patch("/api/users/{id}", h.UpdateUser)
+	protected.Delete("/api/users/{id}", h.DeleteUser)
 
 	// Menu routes - HTTP to gRPC translation
-	r.Post("/api/menu", h.CreateMenuItem)
+	protected.Post("/api/menu", h.CreateMenuItem)
 	r.Get("/api/menu/{id}", h.GetMenuItem)
+	protected.Patch("/api/menu/{id}", h.UpdateMenuItem)
+	protected.Delete("/api/menu/{id}", h.DeleteMenuItem)
 	r.Get("/api/menu", h.GetMenu)
+	protected.Post("/api/menu/import", h.ImportMenuItems)
+	r.Get("/api/menu/events", h.WatchMenu)
+	r.Get("/api/menu/{id}/prices", h.GetPriceHistory)
+	protected.Post("/api/menu/{id}/prices", h.SchedulePriceChange)
+
+	// Category routes - HTTP to gRPC translation
+	protected.Post("/api/categories", h.CreateCategory)
+	r.Get("/api/categories", h.ListCategories)
+	r.Get("/api/categories/{id}", h.GetCategory)
+	protected.Patch("/api/categories/{id}", h.UpdateCategory)
+	protected.Delete("/api/categories/{id}", h.DeleteCategory)
 
 	// Order routes - HTTP to gRPC translation
-	r.Post("/api/orders", h.CreateOrder)
-	r.Get("/api/orders/{id}", h.GetOrder)
-	r.Get("/api/orders", h.GetOrders)
+	protected.Post("/api/orders", h.CreateOrder)
+	protected.Get("/api/orders/{id}", h.GetOrder)
+	protected.Get("/api/orders", h.GetOrders)
+	protected.Patch("/api/orders/{id}/status", h.UpdateOrderStatus)
+	protected.Get("/api/orders/{id}/events", h.WatchOrder)
+
+	// Promotion routes - HTTP to gRPC translation
+	protected.Post("/api/promotions", h.CreatePromotion)
+	protected.Get("/api/promotions", h.ListPromotions)
+	protected.Delete("/api/promotions/{id}", h.DeletePromotion)
 
 	log.Println("API Gateway starting on :8080 (HTTP→gRPC translation layer)")
 	if err := http.ListenAndServe(":8080", r); err != nil {
@@ -0,0 +1,38 @@
+#!/bin/sh
+# Generates the cafe's CA and a certificate for the API gateway and each
+# service, for mutual TLS between them. Existing certificates are kept, so
+# running it again is harmless; delete the directories to start over.
+#
+# The CA and its key go to ca_dir, which nothing else should be able to read.
+# Each name gets its own directory in certs_dir holding cert.pem, key.pem and
+# a copy of ca.pem, so it can be mounted on its own.
+set -eu
+
+certs_dir="${1:-/certs}"
+ca_dir="${2:-/ca}"
+names="api-gateway user-service menu-service order-service"
+days=825
+
+mkdir -p "$certs_dir" "$ca_dir"
+cd "$ca_dir"
+
+if [ ! -f ca.pem ]; then
+  openssl req -x509 -newkey rsa:3072 -nodes -days 3650 \
+    -subj "/CN=Student Cafe CA" -keyout ca-key.pem -out ca.pem
+  chmod 600 ca-key.pem
+fi
+
+for name in $names; do
+  out="$certs_dir/$name"
+  mkdir -p "$out"
+  cp ca.pem "$out/ca.pem"
+  [ -f "$out/cert.pem" ] && continue
+
+  openssl req -newkey rsa:3072 -nodes -subj "/CN=$name" \
+    -keyout "$out/key.pem" -out "$name.csr"
+  printf 'subjectAltName=DNS:%s,DNS:localhost\nextendedKeyUsage=serverAuth,clientAuth\n' "$name" > "$name.ext"
+  openssl x509 -req -in "$name.csr" -CA ca.pem -CAkey ca-key.pem -CAcreateserial \
+    -days "$days" -extfile "$name.ext" -out "$out/cert.pem"
+  rm "$name.csr" "$name.ext"
+  chmod 600 "$out/key.pem"
+done
\ No newline at end of file
@@ -69,31 +69,37 @@ echo -e "${YELLOW}Service Endpoints:${NC}"
 echo ""
 echo "HTTP Endpoints (REST):"
 echo "  - API Gateway:    http://localhost:8080"
-echo "  - User Service:   http://localhost:8081"
-echo "  - Menu Service:   http://localhost:8082"
-echo "  - Order Service:  http://localhost:8083"
 echo ""
-echo "gRPC Endpoints (Internal):"
-echo "  - User Service:   localhost:9091"
-echo "  - Menu Service:   localhost:9092"
-echo "  - Order Service:  localhost:9093"
+echo "gRPC Endpoints (internal to cafe-network, mutual TLS only):"
+echo "  - User Service:   user-service:9091"
+echo "  - Menu Service:   menu-service:9092"
+echo "  - Order Service:  order-service:9093"
 echo ""
 echo -e "${YELLOW}Test Commands:${NC}"
 echo ""
-echo "# Create a menu item"
+echo "# Sign in as the owner created on start-up and keep the access token"
+echo "TOKEN=\$(curl -s -X POST http://localhost:8080/api/auth/login \\"
+echo "  -H 'Content-Type: application/json' \\"
+echo "  -d '{\"email\": \"owner@cafe.local\", \"password\": \"change-me-please\"}' \\"
+echo "  | sed -n 's/.*\"access_token\":\"\\([^\"]*\\)\".*/\\1/p')"
+echo ""
+echo "# Create a menu item (prices are in minor units: 250 is \$2.50)"
 echo "curl -X POST http://localhost:8080/api/menu \\"
+echo "  -H \"Authorization: Bearer \$TOKEN\" \\"
 echo "  -H 'Content-Type: application/json' \\"
-echo "  -d '{\"name\": \"Coffee\", \"description\": \"Hot coffee\", \"price\": 2.50}'"
+echo "  -d '{\"name\": \"Coffee\", \"description\": \"Hot coffee\", \"price\": {\"currency_code\": \"USD\", \"minor_units\": 250}}'"
 echo ""
 echo "# Create a user"
 echo "curl -X POST http://localhost:8080/api/users \\"
+echo "  -H \"Authorization: Bearer \$TOKEN\" \\"
 echo "  -H 'Content-Type: application/json' \\"
 echo "  -d '{\"name\": \"John Doe\", \"email\": \"john@example.com\", \"is_cafe_owner\": false}'"
 echo ""
-echo "# Create an order (demonstrates gRPC inter-service communication)"
+echo "# Create an order for the signed-in user (demonstrates gRPC inter-service communication)"
 echo "curl -X POST http://localhost:8080/api/orders \\"
+echo "  -H \"Authorization: Bearer \$TOKEN\" \\"
 echo "  -H 'Content-Type: application/json' \\"
-echo "  -d '{\"user_id\": 1, \"items\": [{\"menu_item_id\": 1, \"quantity\": 2}]}'"
+echo "  -d '{\"items\": [{\"menu_item_id\": 1, \"quantity\": 2}]}'"
 echo ""
 echo -e "${YELLOW}View Logs:${NC}"
 echo "  docker-compose logs -f [service-name]"
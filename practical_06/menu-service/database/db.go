@@ -10,6 +10,10 @@ import (
 
 var DB *gorm.DB
 
+// SearchDocument is the full-text search document of a menu item. GetMenu
+// must search with this exact expression for Postgres to use idx_menu_items_search.
+const SearchDocument = `to_tsvector('english', coalesce(name, '') || ' ' || coalesce(description, ''))`
+
 func Connect(dsn string) error {
 	var err error
 	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
@@ -18,11 +22,48 @@ func Connect(dsn string) error {
 	}
 
 	// Only migrate menu-related tables
-	err = DB.AutoMigrate(&models.MenuItem{})
+	err = DB.AutoMigrate(&models.Menu{}, &models.MenuItem{}, &models.AvailabilityWindow{}, &models.ModifierGroup{}, &models.Modifier{}, &models.StockReservation{}, &models.MenuEvent{}, &models.PriceChange{})
+	if err != nil {
+		return err
+	}
+
+	if err := migratePrices(); err != nil {
+		return err
+	}
+
+	if err := backfillPriceHistory(); err != nil {
+		return err
+	}
+
+	err = DB.Exec(`CREATE INDEX IF NOT EXISTS idx_menu_items_search ON menu_items USING GIN (` + SearchDocument + `)`).Error
 	if err != nil {
 		return err
 	}
 
 	log.Println("Menu database connected")
 	return nil
+}
+
+// migratePrices moves prices stored as floats, before amounts were kept in
+// minor units, into the price_minor_units and price_currency_code columns
+func migratePrices() error {
+	if !DB.Migrator().HasColumn(&models.MenuItem{}, "price") {
+		return nil
+	}
+
+	return DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`UPDATE menu_items SET price_minor_units = ROUND(price * 100), price_currency_code = ?`, models.DefaultCurrency).Error
+		if err != nil {
+			return err
+		}
+		return tx.Migrator().DropColumn(&models.MenuItem{}, "price")
+	})
+}
+
+// backfillPriceHistory starts the price history of items created before it
+// was kept with their current price, effective from when they were created
+func backfillPriceHistory() error {
+	return DB.Exec(`INSERT INTO price_changes (created_at, menu_item_id, price_minor_units, price_currency_code, effective_from)
+		SELECT NOW(), id, price_minor_units, price_currency_code, created_at FROM menu_items
+		WHERE NOT EXISTS (SELECT 1 FROM price_changes WHERE price_changes.menu_item_id = menu_items.id)`).Error
 }
\ No newline at end of file
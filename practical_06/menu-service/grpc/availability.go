@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"menu-service/models"
+)
+
+// now returns the current time in the cafe's time zone
+func (s *MenuServer) now() time.Time {
+	t := time.Now()
+	if s.clock != nil {
+		t = s.clock()
+	}
+	if s.Location != nil {
+		return t.In(s.Location)
+	}
+	return t.UTC()
+}
+
+// itemToProto converts a menu item to its proto message, flagging it as
+// unavailable when it cannot be ordered at this time
+func (s *MenuServer) itemToProto(item *models.MenuItem) *menuv1.MenuItem {
+	protoItem := modelToProto(item)
+	protoItem.Unavailable = !item.AvailableAt(s.now())
+	return protoItem
+}
+
+// availableAt restricts a menu item query to the items that can be ordered at
+// t, in the cafe's time zone: those without windows and those with one containing t
+func availableAt(q *gorm.DB, t time.Time) *gorm.DB {
+	minute := t.Hour()*60 + t.Minute()
+	return q.Where(
+		`NOT EXISTS (SELECT 1 FROM availability_windows w WHERE w.menu_item_id = menu_items.id AND w.deleted_at IS NULL)
+		OR EXISTS (SELECT 1 FROM availability_windows w WHERE w.menu_item_id = menu_items.id AND w.deleted_at IS NULL
+			AND w.weekday = ? AND w.start_minute <= ? AND w.end_minute > ?)`,
+		int(t.Weekday()), minute, minute,
+	)
+}
+
+// windowsFromProto validates the availability windows sent by a client
+func windowsFromProto(windows []*menuv1.AvailabilityWindow) ([]models.AvailabilityWindow, error) {
+	result := make([]models.AvailabilityWindow, len(windows))
+	for i, window := range windows {
+		weekday, ok := parseWeekday(window.Weekday)
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid weekday %q, expected e.g. monday", window.Weekday)
+		}
+		start, err := parseClock(window.StartTime)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid start_time: %v", err)
+		}
+		end, err := parseClock(window.EndTime)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid end_time: %v", err)
+		}
+		if start >= end {
+			return nil, status.Errorf(codes.InvalidArgument, "availability on %s must end after it starts", window.Weekday)
+		}
+
+		result[i] = models.AvailabilityWindow{Weekday: weekday, StartMinute: start, EndMinute: end}
+	}
+	return result, nil
+}
+
+// windowsToProto converts availability windows to their proto messages
+func windowsToProto(windows []models.AvailabilityWindow) []*menuv1.AvailabilityWindow {
+	result := make([]*menuv1.AvailabilityWindow, len(windows))
+	for i, window := range windows {
+		result[i] = &menuv1.AvailabilityWindow{
+			Weekday:   strings.ToLower(window.Weekday.String()),
+			StartTime: formatClock(window.StartMinute),
+			EndTime:   formatClock(window.EndMinute),
+		}
+	}
+	return result
+}
+
+// parseWeekday parses a lower case day name such as "monday"
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if name == strings.ToLower(day.String()) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// parseClock parses a "HH:MM" time of day into minutes after midnight. "24:00"
+// is accepted as the end of the day.
+func parseClock(clock string) (int, error) {
+	var hour, minute int
+	if len(clock) != 5 {
+		return 0, fmt.Errorf("%q is not in HH:MM format", clock)
+	}
+	if _, err := fmt.Sscanf(clock, "%2d:%2d", &hour, &minute); err != nil {
+		return 0, fmt.Errorf("%q is not in HH:MM format", clock)
+	}
+	total := hour*60 + minute
+	if hour < 0 || minute < 0 || minute > 59 || total > models.MinutesPerDay {
+		return 0, fmt.Errorf("%q is not a time of day", clock)
+	}
+	return total, nil
+}
+
+// formatClock formats minutes after midnight as "HH:MM"
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// replaceAvailability swaps the availability windows of a menu item for new ones
+func replaceAvailability(tx *gorm.DB, menuItemID uint, windows []models.AvailabilityWindow) error {
+	if err := tx.Unscoped().Where("menu_item_id = ?", menuItemID).Delete(&models.AvailabilityWindow{}).Error; err != nil {
+		return err
+	}
+	if len(windows) == 0 {
+		return nil
+	}
+	for i := range windows {
+		windows[i].MenuItemID = menuItemID
+	}
+	return tx.Create(&windows).Error
+}
\ No newline at end of file
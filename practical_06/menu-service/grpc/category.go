@@ -0,0 +1,245 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"menu-service/database"
+	"menu-service/models"
+)
+
+// CreateCategory adds a category to the menu
+func (s *MenuServer) CreateCategory(ctx context.Context, req *menuv1.CreateCategoryRequest) (*menuv1.CreateCategoryResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	category := models.Menu{
+		Name:        name,
+		Description: req.Description,
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := checkCategoryName(tx, name, 0); err != nil {
+			return err
+		}
+
+		if req.Position != nil {
+			category.Position = int(*req.Position)
+		} else {
+			// Add the category after the existing ones
+			var last sql.NullInt64
+			if err := tx.Model(&models.Menu{}).Select("MAX(position)").Scan(&last).Error; err != nil {
+				return status.Errorf(codes.Internal, "failed to get category positions: %v", err)
+			}
+			if last.Valid {
+				category.Position = int(last.Int64) + 1
+			}
+		}
+
+		if err := tx.Create(&category).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to create category: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &menuv1.CreateCategoryResponse{
+		Category: categoryToProto(&category),
+	}, nil
+}
+
+// GetCategory retrieves a category by ID
+func (s *MenuServer) GetCategory(ctx context.Context, req *menuv1.GetCategoryRequest) (*menuv1.GetCategoryResponse, error) {
+	category, err := findCategory(database.DB, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &menuv1.GetCategoryResponse{
+		Category: categoryToProto(category),
+	}, nil
+}
+
+// ListCategories retrieves all categories in menu order
+func (s *MenuServer) ListCategories(ctx context.Context, req *menuv1.ListCategoriesRequest) (*menuv1.ListCategoriesResponse, error) {
+	categories, err := listCategories(database.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	protoCategories := make([]*menuv1.Category, len(categories))
+	for i := range categories {
+		protoCategories[i] = categoryToProto(&categories[i])
+	}
+
+	return &menuv1.ListCategoriesResponse{
+		Categories: protoCategories,
+	}, nil
+}
+
+// UpdateCategory changes the fields of a category listed in the update mask
+func (s *MenuServer) UpdateCategory(ctx context.Context, req *menuv1.UpdateCategoryRequest) (*menuv1.UpdateCategoryResponse, error) {
+	if req.Category == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "category is required")
+	}
+	if len(req.UpdateMask.GetPaths()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "update_mask must list the fields to update")
+	}
+
+	updates := make(map[string]interface{})
+	for _, path := range req.UpdateMask.Paths {
+		switch path {
+		case "name":
+			name := strings.TrimSpace(req.Category.Name)
+			if name == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "name is required")
+			}
+			updates["name"] = name
+		case "description":
+			updates["description"] = req.Category.Description
+		case "position":
+			updates["position"] = int(req.Category.Position)
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", path)
+		}
+	}
+
+	var category *models.Menu
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if category, err = findCategory(tx, req.Category.Id); err != nil {
+			return err
+		}
+
+		if name, ok := updates["name"].(string); ok {
+			if err := checkCategoryName(tx, name, category.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(category).Updates(updates).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to update category: %v", err)
+		}
+
+		category, err = findCategory(tx, req.Category.Id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &menuv1.UpdateCategoryResponse{
+		Category: categoryToProto(category),
+	}, nil
+}
+
+// DeleteCategory soft deletes a category. Its items stay on the menu, uncategorised.
+func (s *MenuServer) DeleteCategory(ctx context.Context, req *menuv1.DeleteCategoryRequest) (*menuv1.DeleteCategoryResponse, error) {
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.Menu{}, req.Id)
+		if result.Error != nil {
+			return status.Errorf(codes.Internal, "failed to delete category: %v", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return status.Errorf(codes.NotFound, "category not found")
+		}
+
+		// Items still on the menu change category, so watchers are told about them
+		var itemIDs []uint
+		if err := tx.Model(&models.MenuItem{}).Where("menu_id = ?", req.Id).Pluck("id", &itemIDs).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to get menu items: %v", err)
+		}
+
+		// Deleted items are moved too, so no item is left in a category that is gone
+		err := tx.Unscoped().Model(&models.MenuItem{}).
+			Where("menu_id = ?", req.Id).
+			Update("menu_id", nil).Error
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to uncategorise menu items: %v", err)
+		}
+
+		changes := make([]menuChange, len(itemIDs))
+		for i, id := range itemIDs {
+			changes[i] = menuChange{models.EventUpdated, id}
+		}
+		return recordMenuEvents(tx, changes...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.watchers.notify()
+
+	return &menuv1.DeleteCategoryResponse{}, nil
+}
+
+// findCategory loads a category, returning NotFound if it does not exist
+func findCategory(tx *gorm.DB, id uint32) (*models.Menu, error) {
+	var category models.Menu
+	if err := tx.First(&category, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Errorf(codes.NotFound, "category not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get category: %v", err)
+	}
+	return &category, nil
+}
+
+// listCategories loads all categories in menu order
+func listCategories(tx *gorm.DB) ([]models.Menu, error) {
+	var categories []models.Menu
+	if err := tx.Order("position, id").Find(&categories).Error; err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get categories: %v", err)
+	}
+	return categories, nil
+}
+
+// checkCategoryName rejects a name already used by a category other than id
+func checkCategoryName(tx *gorm.DB, name string, id uint) error {
+	var count int64
+	if err := tx.Model(&models.Menu{}).Where("name = ? AND id <> ?", name, id).Count(&count).Error; err != nil {
+		return status.Errorf(codes.Internal, "failed to check category name: %v", err)
+	}
+	if count > 0 {
+		return status.Errorf(codes.AlreadyExists, "category %q already exists", name)
+	}
+	return nil
+}
+
+// categoryReference validates the category a menu item is put in. 0 means
+// no category and is returned as nil.
+func categoryReference(tx *gorm.DB, id uint32) (*uint, error) {
+	if id == 0 {
+		return nil, nil
+	}
+
+	category, err := findCategory(tx, id)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Errorf(codes.InvalidArgument, "category %d not found", id)
+		}
+		return nil, err
+	}
+	return &category.ID, nil
+}
+
+// categoryToProto converts a GORM Menu model to proto Category message
+func categoryToProto(category *models.Menu) *menuv1.Category {
+	return &menuv1.Category{
+		Id:          uint32(category.ID),
+		Name:        category.Name,
+		Description: category.Description,
+		Position:    int32(category.Position),
+		CreatedAt:   category.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   category.UpdatedAt.Format(time.RFC3339),
+	}
+}
\ No newline at end of file
@@ -0,0 +1,201 @@
+package grpc
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"menu-service/database"
+	"menu-service/models"
+)
+
+const (
+	// maxImportRows bounds one import so it fits in a single transaction
+	maxImportRows = 1000
+
+	importCreated = "created"
+	importUpdated = "updated"
+	importFailed  = "failed"
+)
+
+// errImportRolledBack makes the import transaction roll back without failing the RPC
+var errImportRolledBack = errors.New("import rolled back")
+
+// ImportMenuItems creates or updates the streamed rows in one transaction.
+// Every row is validated; if any fails, or the import is a dry run, nothing is saved.
+func (s *MenuServer) ImportMenuItems(stream menuv1.MenuService_ImportMenuItemsServer) error {
+	options, rows, err := receiveImport(stream)
+	if err != nil {
+		return err
+	}
+
+	resp := &menuv1.ImportMenuItemsResponse{}
+	now := s.now()
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		var changes []menuChange
+		for i, row := range rows {
+			result := &menuv1.ImportRowResult{Row: int32(i + 1), Name: row.Name}
+
+			action, id, err := importRow(tx, row, options.UpsertByName, now)
+			if status.Code(err) == codes.Internal {
+				return err
+			}
+
+			switch {
+			case err != nil:
+				result.Action = importFailed
+				result.Error = status.Convert(err).Message()
+				resp.Failed++
+			case action == importCreated:
+				result.Action = importCreated
+				result.MenuItemId = uint32(id)
+				resp.Created++
+				changes = append(changes, menuChange{models.EventCreated, id})
+			default:
+				result.Action = importUpdated
+				result.MenuItemId = uint32(id)
+				resp.Updated++
+				changes = append(changes, menuChange{models.EventUpdated, id})
+			}
+			resp.Results = append(resp.Results, result)
+		}
+
+		if resp.Failed > 0 || options.DryRun {
+			return errImportRolledBack
+		}
+		return recordMenuEvents(tx, changes...)
+	})
+
+	switch {
+	case err == nil:
+		resp.Committed = true
+		s.watchers.notify()
+	case errors.Is(err, errImportRolledBack):
+		// New items were never saved, so their IDs mean nothing
+		for _, result := range resp.Results {
+			if result.Action == importCreated {
+				result.MenuItemId = 0
+			}
+		}
+	default:
+		return err
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+// receiveImport reads the whole import stream: optional options first, then the rows
+func receiveImport(stream menuv1.MenuService_ImportMenuItemsServer) (*menuv1.ImportOptions, []*menuv1.CreateMenuItemRequest, error) {
+	options := &menuv1.ImportOptions{}
+	var rows []*menuv1.CreateMenuItemRequest
+
+	for first := true; ; first = false {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return options, rows, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch payload := req.Payload.(type) {
+		case *menuv1.ImportMenuItemsRequest_Options:
+			if !first {
+				return nil, nil, status.Errorf(codes.InvalidArgument, "options must be the first message of an import")
+			}
+			if payload.Options != nil {
+				options = payload.Options
+			}
+		case *menuv1.ImportMenuItemsRequest_MenuItem:
+			if payload.MenuItem == nil {
+				return nil, nil, status.Errorf(codes.InvalidArgument, "row %d is empty", len(rows)+1)
+			}
+			if len(rows) == maxImportRows {
+				return nil, nil, status.Errorf(codes.InvalidArgument, "an import may have at most %d rows", maxImportRows)
+			}
+			rows = append(rows, payload.MenuItem)
+		default:
+			return nil, nil, status.Errorf(codes.InvalidArgument, "import message must set options or menu_item")
+		}
+	}
+}
+
+// importRow saves one import row in tx, returning whether it was created or
+// updated and the item's ID. With upsert, a row named like an existing item
+// replaces that item's fields. Price changes take effect at now.
+func importRow(tx *gorm.DB, row *menuv1.CreateMenuItemRequest, upsert bool, now time.Time) (string, uint, error) {
+	if strings.TrimSpace(row.Name) == "" {
+		return "", 0, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	menuItem, err := menuItemFromRequest(tx, row)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if upsert {
+		var existing []models.MenuItem
+		if err := tx.Where("name = ?", row.Name).Limit(2).Find(&existing).Error; err != nil {
+			return "", 0, status.Errorf(codes.Internal, "failed to look up menu item: %v", err)
+		}
+		if len(existing) > 1 {
+			return "", 0, status.Errorf(codes.InvalidArgument, "several menu items are named %q", row.Name)
+		}
+		if len(existing) == 1 {
+			if err := replaceMenuItem(tx, &existing[0], &menuItem, now); err != nil {
+				return "", 0, err
+			}
+			return importUpdated, existing[0].ID, nil
+		}
+	}
+
+	if err := tx.Create(&menuItem).Error; err != nil {
+		return "", 0, status.Errorf(codes.Internal, "failed to create menu item: %v", err)
+	}
+	if _, err := recordPrice(tx, menuItem.ID, menuItem.Price, now); err != nil {
+		return "", 0, err
+	}
+	return importCreated, menuItem.ID, nil
+}
+
+// replaceMenuItem overwrites every field of existing with those of item,
+// recording the new price in the item's history if it changed
+func replaceMenuItem(tx *gorm.DB, existing, item *models.MenuItem, now time.Time) error {
+	current := []models.MenuItem{*existing}
+	if err := withEffectivePrices(tx, current, now); err != nil {
+		return err
+	}
+	if current[0].Price != item.Price {
+		if _, err := recordPrice(tx, existing.ID, item.Price, now); err != nil {
+			return err
+		}
+	}
+
+	updates := map[string]interface{}{
+		"name":                item.Name,
+		"description":         item.Description,
+		"price_minor_units":   item.Price.MinorUnits,
+		"price_currency_code": item.Price.CurrencyCode,
+		"stock":               item.Stock,
+		"menu_id":             item.MenuID,
+		"allergens":           item.Allergens,
+		"dietary_tags":        item.DietaryTags,
+		"calories":            item.Calories,
+	}
+	if err := tx.Model(existing).Updates(updates).Error; err != nil {
+		return status.Errorf(codes.Internal, "failed to update menu item: %v", err)
+	}
+
+	if err := replaceAvailability(tx, existing.ID, item.Availability); err != nil {
+		return status.Errorf(codes.Internal, "failed to update availability: %v", err)
+	}
+	if err := replaceModifierGroups(tx, existing.ID, item.ModifierGroups); err != nil {
+		return status.Errorf(codes.Internal, "failed to update modifier groups: %v", err)
+	}
+	return nil
+}
\ No newline at end of file
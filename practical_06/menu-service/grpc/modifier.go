@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"strings"
+
+	commonv1 "github.com/douglasswm/student-cafe-protos/gen/go/common/v1"
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"menu-service/models"
+)
+
+// modifierGroupsFromProto validates the modifier groups sent by a client
+func modifierGroupsFromProto(groups []*menuv1.ModifierGroup) ([]models.ModifierGroup, error) {
+	result := make([]models.ModifierGroup, len(groups))
+	for i, group := range groups {
+		name := strings.TrimSpace(group.Name)
+		if name == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "modifier groups must have a name")
+		}
+		if len(group.Modifiers) == 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "modifier group %q has no modifiers", name)
+		}
+		if group.MinSelections < 0 || group.MaxSelections < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "selection limits of modifier group %q must not be negative", name)
+		}
+		if group.MaxSelections != 0 && group.MaxSelections < group.MinSelections {
+			return nil, status.Errorf(codes.InvalidArgument, "modifier group %q allows fewer selections than it requires", name)
+		}
+		if int(group.MinSelections) > len(group.Modifiers) {
+			return nil, status.Errorf(codes.InvalidArgument, "modifier group %q requires more selections than it has modifiers", name)
+		}
+
+		result[i] = models.ModifierGroup{
+			Name:          name,
+			MinSelections: int(group.MinSelections),
+			MaxSelections: int(group.MaxSelections),
+			Modifiers:     make([]models.Modifier, len(group.Modifiers)),
+		}
+		for j, modifier := range group.Modifiers {
+			modifierName := strings.TrimSpace(modifier.Name)
+			if modifierName == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "modifiers in group %q must have a name", name)
+			}
+			delta, err := priceDeltaFromProto(modifier.PriceDelta)
+			if err != nil {
+				return nil, err
+			}
+			result[i].Modifiers[j] = models.Modifier{Name: modifierName, PriceDelta: delta}
+		}
+	}
+	return result, nil
+}
+
+// priceDeltaFromProto validates the price delta of a modifier. Unlike prices,
+// deltas may be negative, and an unset delta is free.
+func priceDeltaFromProto(delta *commonv1.Money) (models.Money, error) {
+	if delta == nil {
+		return models.Money{CurrencyCode: models.DefaultCurrency}, nil
+	}
+	if delta.CurrencyCode != "" && delta.CurrencyCode != models.DefaultCurrency {
+		return models.Money{}, status.Errorf(codes.InvalidArgument, "modifier prices must be in %s", models.DefaultCurrency)
+	}
+	return models.Money{MinorUnits: delta.MinorUnits, CurrencyCode: models.DefaultCurrency}, nil
+}
+
+// modifierGroupsToProto converts modifier groups to their proto messages
+func modifierGroupsToProto(groups []models.ModifierGroup) []*menuv1.ModifierGroup {
+	result := make([]*menuv1.ModifierGroup, len(groups))
+	for i, group := range groups {
+		result[i] = &menuv1.ModifierGroup{
+			Id:            uint32(group.ID),
+			Name:          group.Name,
+			MinSelections: int32(group.MinSelections),
+			MaxSelections: int32(group.MaxSelections),
+			Modifiers:     make([]*menuv1.Modifier, len(group.Modifiers)),
+		}
+		for j, modifier := range group.Modifiers {
+			result[i].Modifiers[j] = &menuv1.Modifier{
+				Id:         uint32(modifier.ID),
+				Name:       modifier.Name,
+				PriceDelta: moneyToProto(modifier.PriceDelta),
+			}
+		}
+	}
+	return result
+}
+
+// replaceModifierGroups swaps the modifier groups of a menu item for new ones.
+// Orders keep their own copy of the modifiers chosen, so old ones can go.
+func replaceModifierGroups(tx *gorm.DB, menuItemID uint, groups []models.ModifierGroup) error {
+	old := tx.Model(&models.ModifierGroup{}).Unscoped().Select("id").Where("menu_item_id = ?", menuItemID)
+	if err := tx.Unscoped().Where("modifier_group_id IN (?)", old).Delete(&models.Modifier{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Unscoped().Where("menu_item_id = ?", menuItemID).Delete(&models.ModifierGroup{}).Error; err != nil {
+		return err
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	for i := range groups {
+		groups[i].MenuItemID = menuItemID
+	}
+	return tx.Create(&groups).Error
+}
\ No newline at end of file
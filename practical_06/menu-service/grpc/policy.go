@@ -0,0 +1,32 @@
+package grpc
+
+import (
+	"github.com/douglasswm/student-cafe-protos/authz"
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"github.com/douglasswm/student-cafe-protos/principal"
+)
+
+// Policy says who may call each MenuService method. Anyone may browse the
+// menu, only owners change it, and stock is only moved by the Order Service.
+var Policy = authz.Policy{
+	menuv1.MenuService_GetMenuItem_FullMethodName:       authz.Public(),
+	menuv1.MenuService_BatchGetMenuItems_FullMethodName: authz.Public(),
+	menuv1.MenuService_GetMenu_FullMethodName:           authz.Public(),
+	menuv1.MenuService_GetPriceHistory_FullMethodName:   authz.Public(),
+	menuv1.MenuService_WatchMenu_FullMethodName:         authz.Public(),
+	menuv1.MenuService_GetCategory_FullMethodName:       authz.Public(),
+	menuv1.MenuService_ListCategories_FullMethodName:    authz.Public(),
+
+	menuv1.MenuService_CreateMenuItem_FullMethodName:      authz.Allow(principal.RoleOwner),
+	menuv1.MenuService_UpdateMenuItem_FullMethodName:      authz.Allow(principal.RoleOwner),
+	menuv1.MenuService_DeleteMenuItem_FullMethodName:      authz.Allow(principal.RoleOwner),
+	menuv1.MenuService_ImportMenuItems_FullMethodName:     authz.Allow(principal.RoleOwner),
+	menuv1.MenuService_SchedulePriceChange_FullMethodName: authz.Allow(principal.RoleOwner),
+	menuv1.MenuService_CreateCategory_FullMethodName:      authz.Allow(principal.RoleOwner),
+	menuv1.MenuService_UpdateCategory_FullMethodName:      authz.Allow(principal.RoleOwner),
+	menuv1.MenuService_DeleteCategory_FullMethodName:      authz.Allow(principal.RoleOwner),
+
+	menuv1.MenuService_ReserveStock_FullMethodName: authz.Allow(principal.RoleService),
+	menuv1.MenuService_ReleaseStock_FullMethodName: authz.Allow(principal.RoleService),
+	menuv1.MenuService_CommitStock_FullMethodName:  authz.Allow(principal.RoleService),
+}
\ No newline at end of file
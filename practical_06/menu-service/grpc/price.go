@@ -0,0 +1,203 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"time"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"menu-service/database"
+	"menu-service/models"
+)
+
+// GetPriceHistory lists every price a menu item has had or is scheduled to have
+func (s *MenuServer) GetPriceHistory(ctx context.Context, req *menuv1.GetPriceHistoryRequest) (*menuv1.GetPriceHistoryResponse, error) {
+	var menuItem models.MenuItem
+	if err := database.DB.Unscoped().First(&menuItem, req.MenuItemId).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, status.Errorf(codes.NotFound, "menu item not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get menu item: %v", err)
+	}
+
+	var changes []models.PriceChange
+	if err := database.DB.Where("menu_item_id = ?", menuItem.ID).Order("effective_from").Order("id").Find(&changes).Error; err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get price history: %v", err)
+	}
+
+	resp := &menuv1.GetPriceHistoryResponse{}
+	for i := range changes {
+		resp.PriceChanges = append(resp.PriceChanges, priceChangeToProto(&changes[i]))
+	}
+	return resp, nil
+}
+
+// SchedulePriceChange adds a future price to a menu item's history.
+// ApplyScheduledPrices stores it on the item once it takes effect.
+func (s *MenuServer) SchedulePriceChange(ctx context.Context, req *menuv1.SchedulePriceChangeRequest) (*menuv1.SchedulePriceChangeResponse, error) {
+	price, err := priceFromProto(req.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	effectiveFrom, err := time.Parse(time.RFC3339, req.EffectiveFrom)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid effective_from: %v", err)
+	}
+	if !effectiveFrom.After(s.now()) {
+		return nil, status.Errorf(codes.InvalidArgument, "effective_from must be in the future; update the menu item to change its price now")
+	}
+
+	var change models.PriceChange
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		var menuItem models.MenuItem
+		if err := tx.First(&menuItem, req.MenuItemId).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return status.Errorf(codes.NotFound, "menu item not found")
+			}
+			return status.Errorf(codes.Internal, "failed to get menu item: %v", err)
+		}
+
+		change, err = recordPrice(tx, menuItem.ID, price, effectiveFrom)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &menuv1.SchedulePriceChangeResponse{
+		PriceChange: priceChangeToProto(&change),
+	}, nil
+}
+
+// ApplyScheduledPrices stores the prices of scheduled changes that have taken
+// effect on their menu items, so the menu can be filtered and sorted by them,
+// and tells WatchMenu clients. It returns how many items changed price.
+func (s *MenuServer) ApplyScheduledPrices() (int, error) {
+	now := s.now()
+	var changed []menuChange
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var due []models.PriceChange
+		if err := effectivePrices(tx, now).
+			Select("price_changes.*").
+			Joins("JOIN menu_items ON menu_items.id = price_changes.menu_item_id AND menu_items.deleted_at IS NULL").
+			Where("menu_items.price_minor_units <> price_changes.price_minor_units OR menu_items.price_currency_code <> price_changes.price_currency_code").
+			Find(&due).Error; err != nil {
+			return err
+		}
+
+		for _, change := range due {
+			// Another instance may have applied the change in the meantime
+			result := tx.Model(&models.MenuItem{}).
+				Where("id = ? AND (price_minor_units <> ? OR price_currency_code <> ?)", change.MenuItemID, change.Price.MinorUnits, change.Price.CurrencyCode).
+				Updates(map[string]interface{}{
+					"price_minor_units":   change.Price.MinorUnits,
+					"price_currency_code": change.Price.CurrencyCode,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected > 0 {
+				changed = append(changed, menuChange{models.EventUpdated, change.MenuItemID})
+			}
+		}
+		return recordMenuEvents(tx, changed...)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(changed) > 0 {
+		s.watchers.notify()
+	}
+	return len(changed), nil
+}
+
+// RunPriceScheduler calls ApplyScheduledPrices every interval until ctx is done
+func (s *MenuServer) RunPriceScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := s.ApplyScheduledPrices(); err != nil {
+			log.Printf("price scheduler: %v", err)
+		}
+	}
+}
+
+// recordPrice appends a price to a menu item's history
+func recordPrice(tx *gorm.DB, menuItemID uint, price models.Money, effectiveFrom time.Time) (models.PriceChange, error) {
+	change := models.PriceChange{
+		MenuItemID:    menuItemID,
+		Price:         price,
+		EffectiveFrom: effectiveFrom.UTC(),
+	}
+	if err := tx.Create(&change).Error; err != nil {
+		return change, status.Errorf(codes.Internal, "failed to record price: %v", err)
+	}
+	return change, nil
+}
+
+// effectivePrices queries, for each menu item with a price history, the
+// change that is in effect at t: the latest one effective by then
+func effectivePrices(db *gorm.DB, t time.Time) *gorm.DB {
+	// Times are kept in UTC so SQLite, which compares them as text, orders them correctly
+	t = t.UTC()
+	return db.Model(&models.PriceChange{}).
+		Where("price_changes.effective_from <= ?", t).
+		Where(`NOT EXISTS (SELECT 1 FROM price_changes later
+			WHERE later.menu_item_id = price_changes.menu_item_id AND later.effective_from <= ?
+			AND (later.effective_from > price_changes.effective_from
+				OR (later.effective_from = price_changes.effective_from AND later.id > price_changes.id)))`, t)
+}
+
+// withEffectivePrices replaces the stored price of each item with the price in
+// effect at t. They differ when a scheduled change has taken effect but
+// ApplyScheduledPrices has not stored it yet.
+func withEffectivePrices(db *gorm.DB, items []models.MenuItem, t time.Time) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(items))
+	for i := range items {
+		ids[i] = items[i].ID
+	}
+
+	var changes []models.PriceChange
+	if err := effectivePrices(db, t).Where("price_changes.menu_item_id IN ?", ids).Find(&changes).Error; err != nil {
+		return status.Errorf(codes.Internal, "failed to get prices: %v", err)
+	}
+
+	prices := make(map[uint]models.Money, len(changes))
+	for _, change := range changes {
+		prices[change.MenuItemID] = change.Price
+	}
+	for i := range items {
+		if price, ok := prices[items[i].ID]; ok {
+			items[i].Price = price
+		}
+	}
+	return nil
+}
+
+// priceChangeToProto converts a price history entry to its proto message
+func priceChangeToProto(change *models.PriceChange) *menuv1.PriceChange {
+	return &menuv1.PriceChange{
+		Id:            uint32(change.ID),
+		MenuItemId:    uint32(change.MenuItemID),
+		Price:         moneyToProto(change.Price),
+		EffectiveFrom: change.EffectiveFrom.Format(time.RFC3339),
+		CreatedAt:     change.CreatedAt.Format(time.RFC3339),
+	}
+}
\ No newline at end of file
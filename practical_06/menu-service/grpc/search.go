@@ -0,0 +1,263 @@
+package grpc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	commonv1 "github.com/douglasswm/student-cafe-protos/gen/go/common/v1"
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"menu-service/database"
+	"menu-service/models"
+)
+
+const maxMenuPageSize = 100
+
+// menuFiltered reports whether a GetMenu request narrows down the menu
+func menuFiltered(req *menuv1.GetMenuRequest) bool {
+	return strings.TrimSpace(req.Query) != "" || req.MinPrice != nil || req.MaxPrice != nil ||
+		req.CategoryId != 0 || req.AvailableOnly ||
+		len(req.ExcludeAllergens) > 0 || len(req.DietaryTags) > 0 || req.MaxCalories != nil ||
+		req.AvailableNow
+}
+
+// filterMenu adds the filters of a GetMenu request to a menu item query. now
+// is the current time in the cafe's time zone.
+func filterMenu(q *gorm.DB, req *menuv1.GetMenuRequest, now time.Time) (*gorm.DB, error) {
+	if text := strings.TrimSpace(req.Query); text != "" {
+		q = search(q, text)
+	}
+
+	minPrice, err := priceBound("min_price", req.MinPrice)
+	if err != nil {
+		return nil, err
+	}
+	maxPrice, err := priceBound("max_price", req.MaxPrice)
+	if err != nil {
+		return nil, err
+	}
+	if minPrice != nil && maxPrice != nil && *minPrice > *maxPrice {
+		return nil, status.Errorf(codes.InvalidArgument, "min_price must not be greater than max_price")
+	}
+	if minPrice != nil {
+		q = q.Where("price_minor_units >= ?", *minPrice)
+	}
+	if maxPrice != nil {
+		q = q.Where("price_minor_units <= ?", *maxPrice)
+	}
+
+	if req.CategoryId != 0 {
+		q = q.Where("menu_id = ?", req.CategoryId)
+	}
+	if req.AvailableOnly {
+		q = q.Where("stock IS NULL OR stock > 0")
+	}
+	if req.AvailableNow {
+		q = availableAt(q, now)
+	}
+
+	allergens, err := models.ParseAllergens(req.ExcludeAllergens)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if allergens != 0 {
+		q = q.Where("allergens & ? = 0", allergens)
+	}
+
+	tags, err := models.ParseDietaryTags(req.DietaryTags)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if tags != 0 {
+		q = q.Where("dietary_tags & ? = ?", tags, tags)
+	}
+
+	// Items with unknown calories never match a calorie limit
+	if req.MaxCalories != nil {
+		q = q.Where("calories <= ?", *req.MaxCalories)
+	}
+
+	return q, nil
+}
+
+// search restricts a menu item query to the items matching a free-text query.
+// Postgres uses full-text search; other databases, such as the SQLite used in
+// tests, fall back to requiring every word in the name or description.
+func search(q *gorm.DB, text string) *gorm.DB {
+	if database.DB.Dialector.Name() == "postgres" {
+		return q.Where(database.SearchDocument+" @@ plainto_tsquery('english', ?)", text)
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	for _, word := range strings.Fields(text) {
+		pattern := "%" + strings.ToLower(replacer.Replace(word)) + "%"
+		q = q.Where(`LOWER(name) LIKE ? ESCAPE '\' OR LOWER(description) LIKE ? ESCAPE '\'`, pattern, pattern)
+	}
+	return q
+}
+
+// priceBound validates a price filter, returning nil when it is not set
+func priceBound(field string, price *commonv1.Money) (*int64, error) {
+	if price == nil {
+		return nil, nil
+	}
+	if price.MinorUnits < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "%s must not be negative", field)
+	}
+	if price.CurrencyCode != "" && price.CurrencyCode != models.DefaultCurrency {
+		return nil, status.Errorf(codes.InvalidArgument, "%s must be in %s", field, models.DefaultCurrency)
+	}
+	return &price.MinorUnits, nil
+}
+
+// menuSort describes how a GetMenu listing is ordered.
+// Items are always tie-broken on ID so pages are stable.
+type menuSort struct {
+	column string // "id", "name", "price_minor_units" or "created_at"
+	desc   bool
+}
+
+// parseMenuSort parses the order_by field of GetMenuRequest
+func parseMenuSort(orderBy string) (menuSort, error) {
+	fields := strings.Fields(strings.ToLower(orderBy))
+	sort := menuSort{column: "id"}
+
+	if len(fields) == 0 {
+		return sort, nil
+	}
+	if len(fields) > 2 {
+		return sort, fmt.Errorf("invalid order_by %q", orderBy)
+	}
+
+	switch fields[0] {
+	case "id", "name", "created_at":
+		sort.column = fields[0]
+	case "price":
+		sort.column = "price_minor_units"
+	default:
+		return sort, fmt.Errorf("cannot sort menu items by %q", fields[0])
+	}
+
+	if len(fields) == 2 {
+		switch fields[1] {
+		case "asc":
+		case "desc":
+			sort.desc = true
+		default:
+			return sort, fmt.Errorf("invalid sort direction %q", fields[1])
+		}
+	}
+
+	return sort, nil
+}
+
+// apply adds the ORDER BY clause for this sort to a query
+func (m menuSort) apply(q *gorm.DB) *gorm.DB {
+	dir := "ASC"
+	if m.desc {
+		dir = "DESC"
+	}
+	if m.column == "id" {
+		return q.Order("id " + dir)
+	}
+	return q.Order(m.column + " " + dir).Order("id " + dir)
+}
+
+// menuPageToken is the decoded form of an opaque GetMenu page token. It
+// remembers the sort key of the last item returned (keyset pagination) and
+// a fingerprint of the query so a token cannot be replayed against different filters.
+type menuPageToken struct {
+	Query     string    `json:"q"`
+	Name      string    `json:"n,omitempty"`
+	Price     int64     `json:"p,omitempty"`
+	CreatedAt time.Time `json:"c"`
+	ID        uint      `json:"i"`
+}
+
+// menuQueryFingerprint identifies the filters and sort of a GetMenu request
+func menuQueryFingerprint(req *menuv1.GetMenuRequest) string {
+	maxCalories := ""
+	if req.MaxCalories != nil {
+		maxCalories = fmt.Sprint(*req.MaxCalories)
+	}
+	key := fmt.Sprintf("%s|%s|%s|%d|%t|%s|%s|%s|%s|%t",
+		strings.TrimSpace(req.Query), priceKey(req.MinPrice), priceKey(req.MaxPrice),
+		req.CategoryId, req.AvailableOnly, strings.ToLower(strings.TrimSpace(req.OrderBy)),
+		strings.Join(req.ExcludeAllergens, ","), strings.Join(req.DietaryTags, ","), maxCalories,
+		req.AvailableNow)
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// priceKey formats a price filter for menuQueryFingerprint
+func priceKey(price *commonv1.Money) string {
+	if price == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d %s", price.MinorUnits, price.CurrencyCode)
+}
+
+// encodeMenuPageToken builds the token that continues after the given item
+func encodeMenuPageToken(fingerprint string, sort menuSort, last *models.MenuItem) string {
+	pt := menuPageToken{Query: fingerprint, ID: last.ID}
+	switch sort.column {
+	case "name":
+		pt.Name = last.Name
+	case "price_minor_units":
+		pt.Price = last.Price.MinorUnits
+	case "created_at":
+		pt.CreatedAt = last.CreatedAt
+	}
+
+	data, _ := json.Marshal(pt)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeMenuPageToken parses a page token and checks it belongs to the same query
+func decodeMenuPageToken(token, fingerprint string) (*menuPageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("malformed page token")
+	}
+
+	var pt menuPageToken
+	if err := json.Unmarshal(data, &pt); err != nil {
+		return nil, fmt.Errorf("malformed page token")
+	}
+	if pt.Query != fingerprint {
+		return nil, fmt.Errorf("page token does not match the request filters")
+	}
+
+	return &pt, nil
+}
+
+// after restricts a query to the rows that come after the token's position
+func (pt *menuPageToken) after(q *gorm.DB, sort menuSort) *gorm.DB {
+	op := ">"
+	if sort.desc {
+		op = "<"
+	}
+
+	var value interface{}
+	switch sort.column {
+	case "id":
+		return q.Where("id "+op+" ?", pt.ID)
+	case "name":
+		value = pt.Name
+	case "price_minor_units":
+		value = pt.Price
+	case "created_at":
+		value = pt.CreatedAt
+	}
+	return q.Where(
+		fmt.Sprintf("%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?)", sort.column, op),
+		value, value, pt.ID,
+	)
+}
\ No newline at end of file
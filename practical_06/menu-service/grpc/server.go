@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	commonv1 "github.com/douglasswm/student-cafe-protos/gen/go/common/v1"
 	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -15,6 +16,12 @@ import (
 // MenuServer implements the gRPC MenuService
 type MenuServer struct {
 	menuv1.UnimplementedMenuServiceServer
+
+	// Location is the cafe's time zone, which availability windows are in. UTC when nil.
+	Location *time.Location
+
+	clock    func() time.Time // Replaces time.Now in tests
+	watchers menuWatchers
 }
 
 // NewMenuServer creates a new gRPC menu server
@@ -24,61 +31,536 @@ func NewMenuServer() *MenuServer {
 
 // GetMenuItem retrieves a menu item by ID
 func (s *MenuServer) GetMenuItem(ctx context.Context, req *menuv1.GetMenuItemRequest) (*menuv1.GetMenuItemResponse, error) {
+	db := database.DB
+	if req.IncludeDeleted {
+		db = db.Unscoped()
+	}
+
 	var menuItem models.MenuItem
-	if err := database.DB.First(&menuItem, req.Id).Error; err != nil {
+	if err := withDetails(db).First(&menuItem, req.Id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, status.Errorf(codes.NotFound, "menu item not found")
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get menu item: %v", err)
 	}
 
+	items := []models.MenuItem{menuItem}
+	if err := withEffectivePrices(database.DB, items, s.now()); err != nil {
+		return nil, err
+	}
+
 	return &menuv1.GetMenuItemResponse{
-		MenuItem: modelToProto(&menuItem),
+		MenuItem: s.itemToProto(&items[0]),
 	}, nil
 }
 
-// GetMenu retrieves all menu items
+// maxBatchGetMenuItems bounds the number of IDs accepted by BatchGetMenuItems
+const maxBatchGetMenuItems = 500
+
+// BatchGetMenuItems retrieves several menu items in a single query
+func (s *MenuServer) BatchGetMenuItems(ctx context.Context, req *menuv1.BatchGetMenuItemsRequest) (*menuv1.BatchGetMenuItemsResponse, error) {
+	// De-duplicate while keeping the order the IDs were requested in
+	ids := make([]uint32, 0, len(req.Ids))
+	seen := make(map[uint32]bool, len(req.Ids))
+	for _, id := range req.Ids {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	if len(ids) > maxBatchGetMenuItems {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d menu items can be fetched at once", maxBatchGetMenuItems)
+	}
+	if len(ids) == 0 {
+		return &menuv1.BatchGetMenuItemsResponse{}, nil
+	}
+
+	db := database.DB
+	if req.IncludeDeleted {
+		db = db.Unscoped()
+	}
+
+	var menuItems []models.MenuItem
+	if err := withDetails(db).Where("id IN ?", ids).Find(&menuItems).Error; err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get menu items: %v", err)
+	}
+	if err := withEffectivePrices(database.DB, menuItems, s.now()); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint32]*models.MenuItem, len(menuItems))
+	for i := range menuItems {
+		byID[uint32(menuItems[i].ID)] = &menuItems[i]
+	}
+
+	resp := &menuv1.BatchGetMenuItemsResponse{}
+	for _, id := range ids {
+		if item, ok := byID[id]; ok {
+			resp.MenuItems = append(resp.MenuItems, s.itemToProto(item))
+		} else {
+			resp.MissingIds = append(resp.MissingIds, id)
+		}
+	}
+
+	return resp, nil
+}
+
+// GetMenu retrieves the menu items matching the request filters, either as
+// one page of a flat list or grouped by category
 func (s *MenuServer) GetMenu(ctx context.Context, req *menuv1.GetMenuRequest) (*menuv1.GetMenuResponse, error) {
+	sort, err := parseMenuSort(req.OrderBy)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "page_size must not be negative")
+	}
+	if pageSize > maxMenuPageSize {
+		pageSize = maxMenuPageSize
+	}
+	if req.GroupByCategory && (pageSize != 0 || req.PageToken != "") {
+		return nil, status.Errorf(codes.InvalidArgument, "group_by_category cannot be combined with pagination")
+	}
+
+	// Read the revision first; changes made while the menu is read are then replayed to a watcher starting from it
+	revision, err := latestRevision(database.DB)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get menu revision: %v", err)
+	}
+
+	query, err := filterMenu(database.DB.Model(&models.MenuItem{}), req, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := menuQueryFingerprint(req)
+	if req.PageToken != "" {
+		token, err := decodeMenuPageToken(req.PageToken, fingerprint)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token: %v", err)
+		}
+		query = token.after(query, sort)
+	}
+
+	// Fetch one extra row to find out whether there is another page
+	query = sort.apply(query)
+	if pageSize > 0 {
+		query = query.Limit(pageSize + 1)
+	}
+
 	var menuItems []models.MenuItem
-	if err := database.DB.Find(&menuItems).Error; err != nil {
+	if err := withDetails(query).Find(&menuItems).Error; err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get menu: %v", err)
 	}
+	if err := withEffectivePrices(database.DB, menuItems, s.now()); err != nil {
+		return nil, err
+	}
+
+	if req.GroupByCategory {
+		resp, err := s.groupByCategory(menuItems, !menuFiltered(req))
+		if err != nil {
+			return nil, err
+		}
+		resp.Revision = revision
+		return resp, nil
+	}
+
+	var nextPageToken string
+	if pageSize > 0 && len(menuItems) > pageSize {
+		menuItems = menuItems[:pageSize]
+		nextPageToken = encodeMenuPageToken(fingerprint, sort, &menuItems[pageSize-1])
+	}
 
 	protoItems := make([]*menuv1.MenuItem, len(menuItems))
 	for i, item := range menuItems {
-		protoItems[i] = modelToProto(&item)
+		protoItems[i] = s.itemToProto(&item)
 	}
 
 	return &menuv1.GetMenuResponse{
-		MenuItems: protoItems,
+		MenuItems:     protoItems,
+		NextPageToken: nextPageToken,
+		Revision:      revision,
 	}, nil
 }
 
+// groupByCategory puts menu items into one section per category, in menu
+// order, followed by a section of uncategorised items if there are any.
+// Categories without items get an empty section only when includeEmpty is set.
+func (s *MenuServer) groupByCategory(menuItems []models.MenuItem, includeEmpty bool) (*menuv1.GetMenuResponse, error) {
+	categories, err := listCategories(database.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered := make([]*menuv1.MenuSection, len(categories))
+	sections := make(map[uint]*menuv1.MenuSection, len(categories))
+	for i := range categories {
+		ordered[i] = &menuv1.MenuSection{Category: categoryToProto(&categories[i])}
+		sections[categories[i].ID] = ordered[i]
+	}
+
+	uncategorised := &menuv1.MenuSection{}
+	for i := range menuItems {
+		section := uncategorised
+		if menuItems[i].MenuID != nil && sections[*menuItems[i].MenuID] != nil {
+			section = sections[*menuItems[i].MenuID]
+		}
+		section.MenuItems = append(section.MenuItems, s.itemToProto(&menuItems[i]))
+	}
+
+	resp := &menuv1.GetMenuResponse{}
+	for _, section := range ordered {
+		if includeEmpty || len(section.MenuItems) > 0 {
+			resp.Sections = append(resp.Sections, section)
+		}
+	}
+	if len(uncategorised.MenuItems) > 0 {
+		resp.Sections = append(resp.Sections, uncategorised)
+	}
+
+	return resp, nil
+}
+
 // CreateMenuItem creates a new menu item
 func (s *MenuServer) CreateMenuItem(ctx context.Context, req *menuv1.CreateMenuItemRequest) (*menuv1.CreateMenuItemResponse, error) {
+	menuItem, err := menuItemFromRequest(database.DB, req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&menuItem).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to create menu item: %v", err)
+		}
+		if _, err := recordPrice(tx, menuItem.ID, menuItem.Price, s.now()); err != nil {
+			return err
+		}
+		return recordMenuEvents(tx, menuChange{models.EventCreated, menuItem.ID})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.watchers.notify()
+
+	return &menuv1.CreateMenuItemResponse{
+		MenuItem: s.itemToProto(&menuItem),
+	}, nil
+}
+
+// menuItemFromRequest validates a new menu item, looking up its category in db
+func menuItemFromRequest(db *gorm.DB, req *menuv1.CreateMenuItemRequest) (models.MenuItem, error) {
+	price, err := priceFromProto(req.Price)
+	if err != nil {
+		return models.MenuItem{}, err
+	}
+
 	menuItem := models.MenuItem{
 		Name:        req.Name,
 		Description: req.Description,
-		Price:       req.Price,
+		Price:       price,
 	}
 
-	if err := database.DB.Create(&menuItem).Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create menu item: %v", err)
+	if req.Stock != nil {
+		if *req.Stock < 0 {
+			return models.MenuItem{}, status.Errorf(codes.InvalidArgument, "stock must not be negative")
+		}
+		stock := int(*req.Stock)
+		menuItem.Stock = &stock
 	}
 
-	return &menuv1.CreateMenuItemResponse{
-		MenuItem: modelToProto(&menuItem),
+	if menuItem.Allergens, err = models.ParseAllergens(req.Allergens); err != nil {
+		return models.MenuItem{}, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if menuItem.DietaryTags, err = models.ParseDietaryTags(req.DietaryTags); err != nil {
+		return models.MenuItem{}, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if menuItem.Calories, err = caloriesFromProto(req.Calories); err != nil {
+		return models.MenuItem{}, err
+	}
+	if menuItem.Availability, err = windowsFromProto(req.Availability); err != nil {
+		return models.MenuItem{}, err
+	}
+	if menuItem.ModifierGroups, err = modifierGroupsFromProto(req.ModifierGroups); err != nil {
+		return models.MenuItem{}, err
+	}
+
+	if menuItem.MenuID, err = categoryReference(db, req.CategoryId); err != nil {
+		return models.MenuItem{}, err
+	}
+
+	return menuItem, nil
+}
+
+// UpdateMenuItem changes the fields of a menu item listed in the update mask
+func (s *MenuServer) UpdateMenuItem(ctx context.Context, req *menuv1.UpdateMenuItemRequest) (*menuv1.UpdateMenuItemResponse, error) {
+	if req.MenuItem == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "menu_item is required")
+	}
+	if len(req.UpdateMask.GetPaths()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "update_mask must list the fields to update")
+	}
+
+	updates := make(map[string]interface{})
+	var price *models.Money
+	updateCategory := false
+	var availability []models.AvailabilityWindow
+	updateAvailability := false
+	var modifierGroups []models.ModifierGroup
+	updateModifierGroups := false
+	for _, path := range req.UpdateMask.Paths {
+		switch path {
+		case "name":
+			updates["name"] = req.MenuItem.Name
+		case "description":
+			updates["description"] = req.MenuItem.Description
+		case "price":
+			p, err := priceFromProto(req.MenuItem.Price)
+			if err != nil {
+				return nil, err
+			}
+			price = &p
+			updates["price_minor_units"] = p.MinorUnits
+			updates["price_currency_code"] = p.CurrencyCode
+		case "stock":
+			if req.MenuItem.Stock == nil {
+				updates["stock"] = nil
+			} else if *req.MenuItem.Stock < 0 {
+				return nil, status.Errorf(codes.InvalidArgument, "stock must not be negative")
+			} else {
+				updates["stock"] = int(*req.MenuItem.Stock)
+			}
+		case "category_id":
+			// Validated in the transaction below
+			updateCategory = true
+		case "allergens":
+			allergens, err := models.ParseAllergens(req.MenuItem.Allergens)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+			}
+			updates["allergens"] = allergens
+		case "dietary_tags":
+			tags, err := models.ParseDietaryTags(req.MenuItem.DietaryTags)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+			}
+			updates["dietary_tags"] = tags
+		case "calories":
+			calories, err := caloriesFromProto(req.MenuItem.Calories)
+			if err != nil {
+				return nil, err
+			}
+			updates["calories"] = calories
+		case "availability":
+			windows, err := windowsFromProto(req.MenuItem.Availability)
+			if err != nil {
+				return nil, err
+			}
+			availability = windows
+			updateAvailability = true
+			// The windows live in their own table, so touch the item itself too
+			updates["updated_at"] = s.now()
+		case "modifier_groups":
+			groups, err := modifierGroupsFromProto(req.MenuItem.ModifierGroups)
+			if err != nil {
+				return nil, err
+			}
+			modifierGroups = groups
+			updateModifierGroups = true
+			updates["updated_at"] = s.now()
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", path)
+		}
+	}
+
+	var menuItem models.MenuItem
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&menuItem, req.MenuItem.Id).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return status.Errorf(codes.NotFound, "menu item not found")
+			}
+			return status.Errorf(codes.Internal, "failed to get menu item: %v", err)
+		}
+
+		if updateCategory {
+			menuID, err := categoryReference(tx, req.MenuItem.CategoryId)
+			if err != nil {
+				return err
+			}
+			updates["menu_id"] = menuID
+		}
+
+		if price != nil {
+			// Compare with the price in effect, which a scheduled change may have set
+			now := s.now()
+			current := []models.MenuItem{menuItem}
+			if err := withEffectivePrices(tx, current, now); err != nil {
+				return err
+			}
+			if current[0].Price != *price {
+				if _, err := recordPrice(tx, menuItem.ID, *price, now); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := tx.Model(&menuItem).Updates(updates).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to update menu item: %v", err)
+		}
+
+		if updateAvailability {
+			if err := replaceAvailability(tx, menuItem.ID, availability); err != nil {
+				return status.Errorf(codes.Internal, "failed to update availability: %v", err)
+			}
+		}
+
+		if updateModifierGroups {
+			if err := replaceModifierGroups(tx, menuItem.ID, modifierGroups); err != nil {
+				return status.Errorf(codes.Internal, "failed to update modifier groups: %v", err)
+			}
+		}
+
+		if err := recordMenuEvents(tx, menuChange{models.EventUpdated, menuItem.ID}); err != nil {
+			return err
+		}
+
+		// Read back the stored values, e.g. stock that changed since the item was loaded
+		if err := withDetails(tx).First(&menuItem, menuItem.ID).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to get menu item: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.watchers.notify()
+
+	return &menuv1.UpdateMenuItemResponse{
+		MenuItem: s.itemToProto(&menuItem),
 	}, nil
 }
 
+// DeleteMenuItem soft deletes a menu item, so it drops off the menu and can no
+// longer be ordered but past orders can still look it up with include_deleted
+func (s *MenuServer) DeleteMenuItem(ctx context.Context, req *menuv1.DeleteMenuItemRequest) (*menuv1.DeleteMenuItemResponse, error) {
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.MenuItem{}, req.Id)
+		if result.Error != nil {
+			return status.Errorf(codes.Internal, "failed to delete menu item: %v", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return status.Errorf(codes.NotFound, "menu item not found")
+		}
+		return recordMenuEvents(tx, menuChange{models.EventDeleted, uint(req.Id)})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.watchers.notify()
+
+	return &menuv1.DeleteMenuItemResponse{}, nil
+}
+
+// withDetails preloads the associations returned with every menu item
+func withDetails(db *gorm.DB) *gorm.DB {
+	byID := func(db *gorm.DB) *gorm.DB { return db.Order("id") }
+	return db.Preload("Availability").
+		Preload("ModifierGroups", byID).
+		Preload("ModifierGroups.Modifiers", byID)
+}
+
 // modelToProto converts a GORM MenuItem model to proto MenuItem message
 func modelToProto(item *models.MenuItem) *menuv1.MenuItem {
-	return &menuv1.MenuItem{
+	protoItem := &menuv1.MenuItem{
 		Id:          uint32(item.ID),
 		Name:        item.Name,
 		Description: item.Description,
-		Price:       item.Price,
+		Price:       moneyToProto(item.Price),
 		CreatedAt:   item.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   item.UpdatedAt.Format(time.RFC3339),
 	}
+
+	if item.Stock != nil {
+		stock := int32(*item.Stock)
+		protoItem.Stock = &stock
+	}
+
+	if item.MenuID != nil {
+		protoItem.CategoryId = uint32(*item.MenuID)
+	}
+
+	protoItem.Allergens = item.Allergens.Codes(models.Allergens)
+	protoItem.DietaryTags = item.DietaryTags.Codes(models.DietaryTags)
+	if item.Calories != nil {
+		calories := int32(*item.Calories)
+		protoItem.Calories = &calories
+	}
+
+	protoItem.Availability = windowsToProto(item.Availability)
+	protoItem.ModifierGroups = modifierGroupsToProto(item.ModifierGroups)
+
+	if item.DeletedAt.Valid {
+		protoItem.DeletedAt = item.DeletedAt.Time.Format(time.RFC3339)
+	}
+
+	return protoItem
+}
+
+// caloriesFromProto validates the calories sent by a client; unset means not known
+func caloriesFromProto(calories *int32) (*int, error) {
+	if calories == nil {
+		return nil, nil
+	}
+	if *calories < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "calories must not be negative")
+	}
+	value := int(*calories)
+	return &value, nil
+}
+
+// moneyToProto converts a Money model to its proto message
+func moneyToProto(m models.Money) *commonv1.Money {
+	return &commonv1.Money{
+		CurrencyCode: m.CurrencyCode,
+		MinorUnits:   m.MinorUnits,
+	}
+}
+
+// priceFromProto validates a price sent by a client. An empty currency
+// code means the menu's default currency.
+func priceFromProto(price *commonv1.Money) (models.Money, error) {
+	if price == nil {
+		return models.Money{}, status.Errorf(codes.InvalidArgument, "price is required")
+	}
+	if price.MinorUnits < 0 {
+		return models.Money{}, status.Errorf(codes.InvalidArgument, "price must not be negative")
+	}
+
+	currency := price.CurrencyCode
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+	if !validCurrencyCode(currency) {
+		return models.Money{}, status.Errorf(codes.InvalidArgument, "invalid currency code %q, expected an ISO 4217 code such as USD", currency)
+	}
+	if currency != models.DefaultCurrency {
+		return models.Money{}, status.Errorf(codes.InvalidArgument, "menu prices must be in %s", models.DefaultCurrency)
+	}
+
+	return models.Money{MinorUnits: price.MinorUnits, CurrencyCode: currency}, nil
+}
+
+// validCurrencyCode reports whether code looks like an ISO 4217 code: three upper case letters
+func validCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
 }
\ No newline at end of file
@@ -0,0 +1,227 @@
+package grpc
+
+import (
+	"context"
+	"sort"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"menu-service/database"
+	"menu-service/models"
+)
+
+// ReserveStock holds stock for every line of a reservation, or for none of them.
+// Stock is decremented with a conditional UPDATE so concurrent reservations can
+// never take an item below zero.
+func (s *MenuServer) ReserveStock(ctx context.Context, req *menuv1.ReserveStockRequest) (*menuv1.ReserveStockResponse, error) {
+	if req.ReservationId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "reservation_id is required")
+	}
+
+	// Merge lines for the same item
+	quantities := make(map[uint]int)
+	for _, line := range req.Lines {
+		if line.Quantity <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "quantity for menu item %d must be positive", line.MenuItemId)
+		}
+		quantities[uint(line.MenuItemId)] += int(line.Quantity)
+	}
+
+	// Lock rows in a consistent order to avoid deadlocks between reservations
+	itemIDs := make([]uint, 0, len(quantities))
+	for id := range quantities {
+		itemIDs = append(itemIDs, id)
+	}
+	sort.Slice(itemIDs, func(i, j int) bool { return itemIDs[i] < itemIDs[j] })
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		// Retried reservations are a no-op, unless they were already released
+		var existing []models.StockReservation
+		if err := tx.Where("reservation_id = ?", req.ReservationId).Find(&existing).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to check reservation: %v", err)
+		}
+		if len(existing) > 0 {
+			if existing[0].Status == models.ReservationReleased {
+				return status.Errorf(codes.FailedPrecondition, "reservation %s was already released", req.ReservationId)
+			}
+			return nil
+		}
+
+		var soldOut []menuChange
+		for _, id := range itemIDs {
+			qty := quantities[id]
+
+			var item models.MenuItem
+			if err := tx.First(&item, id).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return status.Errorf(codes.NotFound, "menu item %d not found", id)
+				}
+				return status.Errorf(codes.Internal, "failed to get menu item: %v", err)
+			}
+
+			if item.Stock != nil {
+				result := tx.Model(&models.MenuItem{}).
+					Where("id = ? AND stock >= ?", id, qty).
+					Update("stock", gorm.Expr("stock - ?", qty))
+				if result.Error != nil {
+					return status.Errorf(codes.Internal, "failed to reserve stock: %v", result.Error)
+				}
+				if result.RowsAffected == 0 {
+					return status.Errorf(codes.ResourceExhausted, "menu item %d is out of stock", id)
+				}
+
+				// The row is locked now, so this is the stock this reservation left
+				remaining, err := remainingStock(tx, id)
+				if err != nil {
+					return err
+				}
+				if remaining == 0 {
+					soldOut = append(soldOut, menuChange{models.EventSoldOut, id})
+				}
+			}
+
+			reservation := models.StockReservation{
+				ReservationID: req.ReservationId,
+				MenuItemID:    id,
+				Quantity:      qty,
+				Status:        models.ReservationReserved,
+			}
+			if err := tx.Create(&reservation).Error; err != nil {
+				return status.Errorf(codes.Internal, "failed to record reservation: %v", err)
+			}
+		}
+		return recordMenuEvents(tx, soldOut...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.watchers.notify()
+
+	return &menuv1.ReserveStockResponse{}, nil
+}
+
+// ReleaseStock returns reserved stock. Releasing an unknown or already
+// released reservation succeeds so callers can safely retry compensation.
+// An unknown reservation is recorded as released, so a ReserveStock call that
+// arrives after its compensation cannot hold stock nobody will release.
+func (s *MenuServer) ReleaseStock(ctx context.Context, req *menuv1.ReleaseStockRequest) (*menuv1.ReleaseStockResponse, error) {
+	if req.ReservationId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "reservation_id is required")
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var reservations []models.StockReservation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("reservation_id = ?", req.ReservationId).
+			Find(&reservations).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to get reservation: %v", err)
+		}
+		if len(reservations) == 0 {
+			marker := models.StockReservation{
+				ReservationID: req.ReservationId,
+				Status:        models.ReservationReleased,
+			}
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&marker).Error; err != nil {
+				return status.Errorf(codes.Internal, "failed to record release: %v", err)
+			}
+			return nil
+		}
+
+		var restocked []menuChange
+		for _, reservation := range reservations {
+			switch reservation.Status {
+			case models.ReservationReleased:
+				continue
+			case models.ReservationCommitted:
+				return status.Errorf(codes.FailedPrecondition, "reservation %s was already committed", req.ReservationId)
+			}
+
+			// Claim the row first so a concurrent release cannot return the stock twice
+			result := tx.Model(&models.StockReservation{}).
+				Where("id = ? AND status = ?", reservation.ID, models.ReservationReserved).
+				Update("status", models.ReservationReleased)
+			if result.Error != nil {
+				return status.Errorf(codes.Internal, "failed to release reservation: %v", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				continue
+			}
+
+			result = tx.Model(&models.MenuItem{}).
+				Where("id = ? AND stock IS NOT NULL", reservation.MenuItemID).
+				Update("stock", gorm.Expr("stock + ?", reservation.Quantity))
+			if result.Error != nil {
+				return status.Errorf(codes.Internal, "failed to return stock: %v", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				continue
+			}
+
+			// An item that was sold out can be ordered again
+			stock, err := remainingStock(tx, reservation.MenuItemID)
+			if err != nil {
+				return err
+			}
+			if stock == reservation.Quantity {
+				restocked = append(restocked, menuChange{models.EventUpdated, reservation.MenuItemID})
+			}
+		}
+		return recordMenuEvents(tx, restocked...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.watchers.notify()
+
+	return &menuv1.ReleaseStockResponse{}, nil
+}
+
+// CommitStock marks reserved stock as sold. Committing twice is a no-op.
+func (s *MenuServer) CommitStock(ctx context.Context, req *menuv1.CommitStockRequest) (*menuv1.CommitStockResponse, error) {
+	if req.ReservationId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "reservation_id is required")
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var reservations []models.StockReservation
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("reservation_id = ?", req.ReservationId).
+			Find(&reservations).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to get reservation: %v", err)
+		}
+		if len(reservations) == 0 {
+			return status.Errorf(codes.NotFound, "reservation %s not found", req.ReservationId)
+		}
+
+		for _, reservation := range reservations {
+			if reservation.Status == models.ReservationReleased {
+				return status.Errorf(codes.FailedPrecondition, "reservation %s was already released", req.ReservationId)
+			}
+		}
+
+		err := tx.Model(&models.StockReservation{}).
+			Where("reservation_id = ? AND status = ?", req.ReservationId, models.ReservationReserved).
+			Update("status", models.ReservationCommitted).Error
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to commit reservation: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &menuv1.CommitStockResponse{}, nil
+}
+
+// remainingStock reads the stock of a menu item that tracks stock
+func remainingStock(tx *gorm.DB, id uint) (int, error) {
+	var stock int
+	if err := tx.Model(&models.MenuItem{}).Where("id = ?", id).Select("stock").Scan(&stock).Error; err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get stock: %v", err)
+	}
+	return stock, nil
+}
\ No newline at end of file
@@ -0,0 +1,179 @@
+package grpc
+
+import (
+	"sync"
+	"time"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+	"menu-service/database"
+	"menu-service/models"
+)
+
+const (
+	// menuWatchBatch is how many events WatchMenu reads from the database at a time
+	menuWatchBatch = 100
+
+	// menuWatchPollInterval is how often WatchMenu looks for events written by
+	// other menu-service instances, which cannot wake it up directly
+	menuWatchPollInterval = 5 * time.Second
+)
+
+// menuWatchers wakes WatchMenu subscribers when new menu events are committed.
+// The zero value is ready to use.
+type menuWatchers struct {
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+// subscribe returns a channel that receives a value after new events are
+// committed, together with a function that must be called to unsubscribe
+func (w *menuWatchers) subscribe() (<-chan struct{}, func()) {
+	// Subscribers read the events themselves, so one pending wake-up is enough
+	ch := make(chan struct{}, 1)
+
+	w.mu.Lock()
+	if w.subs == nil {
+		w.subs = make(map[chan struct{}]struct{})
+	}
+	w.subs[ch] = struct{}{}
+	w.mu.Unlock()
+
+	return ch, func() {
+		w.mu.Lock()
+		delete(w.subs, ch)
+		w.mu.Unlock()
+	}
+}
+
+// notify wakes every subscriber without blocking
+func (w *menuWatchers) notify() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for ch := range w.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// menuChange is a change to a menu item to record as a MenuEvent
+type menuChange struct {
+	Type   string // see models.Event* constants
+	ItemID uint
+}
+
+// recordMenuEvents writes an event, with a snapshot of the item, for each change.
+// It must be the last write of a transaction: it locks the events table so that
+// events commit in revision order, and a watcher never skips a revision that
+// commits late.
+func recordMenuEvents(tx *gorm.DB, changes ...menuChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if tx.Dialector.Name() == "postgres" {
+		if err := tx.Exec("LOCK TABLE menu_events IN EXCLUSIVE MODE").Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to lock menu events: %v", err)
+		}
+	}
+
+	for _, change := range changes {
+		var item models.MenuItem
+		if err := withDetails(tx.Unscoped()).First(&item, change.ItemID).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to get menu item: %v", err)
+		}
+
+		snapshot, err := proto.Marshal(modelToProto(&item))
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to encode menu item: %v", err)
+		}
+
+		event := models.MenuEvent{Type: change.Type, MenuItemID: item.ID, Snapshot: snapshot}
+		if err := tx.Create(&event).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to record menu event: %v", err)
+		}
+	}
+	return nil
+}
+
+// latestRevision returns the revision of the newest menu event, 0 when there is none
+func latestRevision(db *gorm.DB) (uint64, error) {
+	var revision uint64
+	err := db.Model(&models.MenuEvent{}).Select("COALESCE(MAX(id), 0)").Scan(&revision).Error
+	return revision, err
+}
+
+// eventToProto decodes a stored menu event
+func eventToProto(event *models.MenuEvent) (*menuv1.MenuEvent, error) {
+	item := &menuv1.MenuItem{}
+	if err := proto.Unmarshal(event.Snapshot, item); err != nil {
+		return nil, err
+	}
+
+	return &menuv1.MenuEvent{
+		Revision:   event.ID,
+		Type:       event.Type,
+		MenuItemId: uint32(event.MenuItemID),
+		MenuItem:   item,
+		CreatedAt:  event.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+// WatchMenu streams menu events, first replaying those after since_revision if it is set
+func (s *MenuServer) WatchMenu(req *menuv1.WatchMenuRequest, stream menuv1.MenuService_WatchMenuServer) error {
+	ctx := stream.Context()
+
+	// Subscribe before reading the revision so no change can slip in between
+	changed, unsubscribe := s.watchers.subscribe()
+	defer unsubscribe()
+
+	revision, err := latestRevision(database.DB)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get menu revision: %v", err)
+	}
+	if req.SinceRevision != nil {
+		if *req.SinceRevision > revision {
+			return status.Errorf(codes.OutOfRange, "revision %d is newer than the latest revision %d", *req.SinceRevision, revision)
+		}
+		revision = *req.SinceRevision
+	}
+
+	poll := time.NewTicker(menuWatchPollInterval)
+	defer poll.Stop()
+
+	for {
+		var events []models.MenuEvent
+		if err := database.DB.Where("id > ?", revision).Order("id").Limit(menuWatchBatch).Find(&events).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to get menu events: %v", err)
+		}
+
+		for i := range events {
+			event, err := eventToProto(&events[i])
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to decode menu event %d: %v", events[i].ID, err)
+			}
+			if err := stream.Send(&menuv1.WatchMenuResponse{Event: event}); err != nil {
+				return err
+			}
+			revision = events[i].ID
+		}
+
+		// A full batch means there may be more to catch up on
+		if len(events) == menuWatchBatch {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-changed:
+		case <-poll.C:
+		}
+	}
+}
\ No newline at end of file
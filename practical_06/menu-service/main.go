@@ -1,17 +1,26 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 	"os"
+	"time"
+	_ "time/tzdata" // Time zone database for images without one
 	"menu-service/database"
 	grpcserver "menu-service/grpc"
+	"menu-service/models"
 
+	"github.com/douglasswm/student-cafe-protos/authz"
 	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"github.com/douglasswm/student-cafe-protos/mtls"
 	"google.golang.org/grpc"
 )
 
+// priceSchedulerInterval is how often scheduled price changes are applied
+const priceSchedulerInterval = time.Minute
+
 func main() {
 	// Connect to dedicated menu database
 	dsn := os.Getenv("DATABASE_URL")
@@ -19,10 +28,28 @@ func main() {
 		dsn = "host=localhost user=postgres password=postgres dbname=menu_db port=5432 sslmode=disable"
 	}
 
+	// Currency of menu prices, as an ISO 4217 code
+	if currency := os.Getenv("CURRENCY"); currency != "" {
+		models.DefaultCurrency = currency
+	}
+
+	// Time zone of the cafe, which menu availability windows are in
+	menuServer := grpcserver.NewMenuServer()
+	if tz := os.Getenv("CAFE_TIMEZONE"); tz != "" {
+		location, err := time.LoadLocation(tz)
+		if err != nil {
+			log.Fatalf("Invalid CAFE_TIMEZONE %q: %v", tz, err)
+		}
+		menuServer.Location = location
+	}
+
 	if err := database.Connect(dsn); err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
+	// Store scheduled prices on their menu items once they take effect
+	go menuServer.RunPriceScheduler(context.Background(), priceSchedulerInterval)
+
 	// Get gRPC port from environment
 	grpcPort := os.Getenv("GRPC_PORT")
 	if grpcPort == "" {
@@ -35,9 +62,19 @@ func main() {
 		log.Fatalf("Failed to listen on gRPC port %s: %v", grpcPort, err)
 	}
 
-	// Create and register gRPC server
-	s := grpc.NewServer()
-	menuv1.RegisterMenuServiceServer(s, grpcserver.NewMenuServer())
+	// Only the gateway and the other services may call, over mutual TLS
+	tlsConfig, err := mtls.FromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure TLS: %v", err)
+	}
+	creds, err := tlsConfig.ServerCredentials()
+	if err != nil {
+		log.Fatalf("Failed to load TLS credentials: %v", err)
+	}
+
+	// Create and register gRPC server, authorizing calls by the caller's role
+	s := grpc.NewServer(append(authz.ServerOptions(grpcserver.Policy), grpc.Creds(creds))...)
+	menuv1.RegisterMenuServiceServer(s, menuServer)
 
 	log.Printf("Menu service (gRPC only) starting on :%s", grpcPort)
 	if err := s.Serve(lis); err != nil {
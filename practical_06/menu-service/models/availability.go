@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MinutesPerDay is the end of the last possible availability window
+const MinutesPerDay = 24 * 60
+
+// AvailabilityWindow is a weekly time range during which a menu item can be
+// ordered, in the cafe's time zone. Items without windows can always be ordered.
+type AvailabilityWindow struct {
+	gorm.Model
+	MenuItemID  uint         `json:"menu_item_id" gorm:"index"`
+	Weekday     time.Weekday `json:"weekday"`      // 0 is Sunday
+	StartMinute int          `json:"start_minute"` // Minutes after midnight, inclusive
+	EndMinute   int          `json:"end_minute"`   // Minutes after midnight, exclusive; at most MinutesPerDay
+}
+
+// Contains reports whether t, in the cafe's time zone, falls within the window
+func (w AvailabilityWindow) Contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	return t.Weekday() == w.Weekday && minute >= w.StartMinute && minute < w.EndMinute
+}
+
+// AvailableAt reports whether the item can be ordered at t, in the cafe's time
+// zone. Its Availability must have been loaded.
+func (m *MenuItem) AvailableAt(t time.Time) bool {
+	if len(m.Availability) == 0 {
+		return true
+	}
+	for _, window := range m.Availability {
+		if window.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
\ No newline at end of file
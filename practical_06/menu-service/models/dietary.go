@@ -0,0 +1,57 @@
+package models
+
+import "fmt"
+
+// Allergens are the allergen codes a menu item can be flagged with. An item's
+// flags are stored as a bit mask indexed by this list, so codes may only ever
+// be appended to it.
+var Allergens = []string{
+	"celery", "crustaceans", "eggs", "fish", "gluten", "lupin", "milk",
+	"molluscs", "mustard", "peanuts", "sesame", "soy", "sulphites", "tree_nuts",
+}
+
+// DietaryTags are the diets a menu item can be tagged as suiting. Like
+// Allergens, codes may only ever be appended.
+var DietaryTags = []string{"vegetarian", "vegan", "halal", "kosher", "gluten_free"}
+
+// Flags is a set of codes from one of the lists above, bit i standing for the i-th code
+type Flags uint32
+
+// ParseAllergens converts allergen codes to flags, rejecting unknown codes
+func ParseAllergens(codes []string) (Flags, error) {
+	return parseFlags(Allergens, "allergen", codes)
+}
+
+// ParseDietaryTags converts dietary tag codes to flags, rejecting unknown codes
+func ParseDietaryTags(codes []string) (Flags, error) {
+	return parseFlags(DietaryTags, "dietary tag", codes)
+}
+
+func parseFlags(list []string, kind string, codes []string) (Flags, error) {
+	var flags Flags
+	for _, code := range codes {
+		bit := -1
+		for i, known := range list {
+			if code == known {
+				bit = i
+				break
+			}
+		}
+		if bit < 0 {
+			return 0, fmt.Errorf("unknown %s %q", kind, code)
+		}
+		flags |= 1 << bit
+	}
+	return flags, nil
+}
+
+// Codes lists the codes in the set, in the order of list
+func (f Flags) Codes(list []string) []string {
+	var codes []string
+	for i, code := range list {
+		if f&(1<<i) != 0 {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
\ No newline at end of file
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Menu event types
+const (
+	EventCreated = "created"
+	EventUpdated = "updated"
+	EventDeleted = "deleted"
+	EventSoldOut = "sold_out"
+)
+
+// MenuEvent records a change to a menu item so WatchMenu clients can catch up
+// on the changes they missed. Events are written in the same transaction as
+// the change, and the ID is the menu revision the change produced.
+type MenuEvent struct {
+	ID         uint64    `json:"id" gorm:"primaryKey"`
+	CreatedAt  time.Time `json:"created_at"`
+	Type       string    `json:"type"` // see Event* constants
+	MenuItemID uint      `json:"menu_item_id" gorm:"index"`
+	Snapshot   []byte    `json:"snapshot"` // Protobuf encoded menu item as of the change
+}
\ No newline at end of file
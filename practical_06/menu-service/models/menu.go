@@ -2,16 +2,44 @@ package models
 
 import "gorm.io/gorm"
 
+// Menu is a category of the menu, e.g. Drinks or Breakfast, that groups menu items
 type Menu struct {
 	gorm.Model
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	MenuItems   []MenuItem `json:"menu_items" gorm:"references:MenuID"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Position    int        `json:"position" gorm:"index"` // Categories are shown in ascending position
+	MenuItems   []MenuItem `json:"menu_items" gorm:"foreignKey:MenuID"`
 }
 
 type MenuItem struct {
 	gorm.Model
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Price       Money  `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+	Stock       *int   `json:"stock"`                // Units left to sell, nil when stock is not tracked
+	MenuID      *uint  `json:"menu_id" gorm:"index"` // Category the item is listed under, nil when uncategorised
+	Allergens   Flags  `json:"allergens"`            // Allergens the item contains
+	DietaryTags Flags  `json:"dietary_tags"`         // Diets the item suits
+	Calories    *int   `json:"calories"`             // kcal per serving, nil when not known
+
+	Availability   []AvailabilityWindow `json:"availability" gorm:"foreignKey:MenuItemID"`    // When the item can be ordered, always when empty
+	ModifierGroups []ModifierGroup      `json:"modifier_groups" gorm:"foreignKey:MenuItemID"` // Choices offered when ordering the item
+}
+
+// Stock reservation statuses
+const (
+	ReservationReserved  = "reserved"
+	ReservationReleased  = "released"
+	ReservationCommitted = "committed"
+)
+
+// StockReservation is stock held for a single menu item under a caller-chosen reservation ID.
+// A reservation released before it was made is kept as a single released row
+// without a menu item.
+type StockReservation struct {
+	gorm.Model
+	ReservationID string `json:"reservation_id" gorm:"uniqueIndex:idx_reservation_item"`
+	MenuItemID    uint   `json:"menu_item_id" gorm:"uniqueIndex:idx_reservation_item"`
+	Quantity      int    `json:"quantity"`
+	Status        string `json:"status"`
 }
\ No newline at end of file
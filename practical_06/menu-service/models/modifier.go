@@ -0,0 +1,22 @@
+package models
+
+import "gorm.io/gorm"
+
+// ModifierGroup is a set of options to choose from when ordering a menu item,
+// e.g. Milk with Oat milk and Soy milk, or Extras with Extra shot
+type ModifierGroup struct {
+	gorm.Model
+	MenuItemID    uint       `json:"menu_item_id" gorm:"index"`
+	Name          string     `json:"name"`
+	MinSelections int        `json:"min_selections"` // The group is required when above 0
+	MaxSelections int        `json:"max_selections"` // 0 means no limit
+	Modifiers     []Modifier `json:"modifiers" gorm:"foreignKey:ModifierGroupID"`
+}
+
+// Modifier is a single option of a modifier group
+type Modifier struct {
+	gorm.Model
+	ModifierGroupID uint   `json:"modifier_group_id" gorm:"index"`
+	Name            string `json:"name"`
+	PriceDelta      Money  `json:"price_delta" gorm:"embedded;embeddedPrefix:price_delta_"` // Added to the item's price, may be negative
+}
\ No newline at end of file
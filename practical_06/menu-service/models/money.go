@@ -0,0 +1,11 @@
+package models
+
+// DefaultCurrency is the ISO 4217 currency of the menu. Prices created
+// without a currency, and prices migrated from before amounts had one, use it.
+var DefaultCurrency = "USD"
+
+// Money is an amount in the minor units of a currency, e.g. cents
+type Money struct {
+	MinorUnits   int64  `json:"minor_units"`
+	CurrencyCode string `json:"currency_code" gorm:"size:3"`
+}
\ No newline at end of file
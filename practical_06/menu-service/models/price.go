@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// PriceChange is an entry in a menu item's append-only price history. A change
+// effective in the future is scheduled and takes effect on its own; of two
+// changes effective at the same time, the later one applies.
+type PriceChange struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt     time.Time `json:"created_at"`
+	MenuItemID    uint      `json:"menu_item_id" gorm:"index:idx_price_changes_item_effective"`
+	Price         Money     `json:"price" gorm:"embedded;embeddedPrefix:price_"`
+	EffectiveFrom time.Time `json:"effective_from" gorm:"index:idx_price_changes_item_effective"`
+}
\ No newline at end of file
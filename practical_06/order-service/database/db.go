@@ -1,6 +1,7 @@
 package database
 
 import (
+	"fmt"
 	"log"
 	"order-service/models"
 
@@ -17,12 +18,102 @@ func Connect(dsn string) error {
 		return err
 	}
 
+	if err := Migrate(); err != nil {
+		return err
+	}
+
+	log.Println("Order database connected")
+	return nil
+}
+
+// Migrate brings the schema of DB up to date and converts data stored by
+// earlier versions. Each conversion only runs on data it has not seen yet.
+func Migrate() error {
+	// Checked before AutoMigrate adds the columns
+	backfill := hasUntotalledOrders()
+
 	// Only migrate order-related tables
-	err = DB.AutoMigrate(&models.Order{}, &models.OrderItem{})
+	err := DB.AutoMigrate(&models.Order{}, &models.OrderItem{}, &models.OrderItemModifier{}, &models.OrderStatusTransition{}, &models.OrderSaga{}, &models.OutboxEvent{}, &models.IdempotencyKey{}, &models.OrderTax{}, &models.OrderDiscount{}, &models.Promotion{}, &models.PromotionTarget{}, &models.PromotionWindow{})
 	if err != nil {
 		return err
 	}
 
-	log.Println("Order database connected")
+	if err := migrateAmounts(); err != nil {
+		return err
+	}
+
+	if backfill {
+		return backfillOrderTotals()
+	}
 	return nil
+}
+
+// legacyAmounts are the float columns amounts were stored in before they were
+// kept in minor units, and the prefix of the Money columns that replaced them
+var legacyAmounts = []struct {
+	model  interface{}
+	table  string
+	column string
+	prefix string
+}{
+	{&models.OrderItem{}, "order_items", "price", "price_"},
+	{&models.OrderItem{}, "order_items", "line_total", "line_total_"},
+	{&models.Order{}, "orders", "subtotal", "subtotal_"},
+	{&models.Order{}, "orders", "discount", "discount_"},
+	{&models.Order{}, "orders", "tax", "tax_"},
+	{&models.Order{}, "orders", "total", "total_"},
+	{&models.OrderTax{}, "order_taxes", "amount", "amount_"},
+}
+
+// migrateAmounts converts amounts stored as floats into minor units in
+// models.DefaultCurrency, then drops the float columns
+func migrateAmounts() error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		for _, legacy := range legacyAmounts {
+			if !tx.Migrator().HasColumn(legacy.model, legacy.column) {
+				continue
+			}
+
+			sql := fmt.Sprintf("UPDATE %s SET %sminor_units = ROUND(%s * 100), %scurrency_code = ?",
+				legacy.table, legacy.prefix, legacy.column, legacy.prefix)
+			if err := tx.Exec(sql, models.DefaultCurrency).Error; err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(legacy.model, legacy.column); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// hasUntotalledOrders reports whether the orders table was created before
+// totals were stored, as a float or in minor units
+func hasUntotalledOrders() bool {
+	migrator := DB.Migrator()
+	return migrator.HasTable(&models.Order{}) &&
+		!migrator.HasColumn(&models.Order{}, "total") &&
+		!migrator.HasColumn(&models.Order{}, "total_minor_units")
+}
+
+// backfillOrderTotals fills in the totals of orders placed before totals were
+// stored. The tax charged on those orders is unknown, so none is recorded.
+// It only runs straight after the total columns are added, when no order has
+// a total yet: later, a zero total can be a fully discounted order.
+func backfillOrderTotals() error {
+	err := DB.Exec(`UPDATE order_items SET
+			line_total_minor_units = price_minor_units * quantity,
+			line_total_currency_code = price_currency_code`).Error
+	if err != nil {
+		return err
+	}
+
+	return DB.Exec(`UPDATE orders SET
+			subtotal_minor_units = totals.subtotal, subtotal_currency_code = ?,
+			discount_minor_units = 0, discount_currency_code = ?,
+			tax_minor_units = 0, tax_currency_code = ?,
+			total_minor_units = totals.subtotal, total_currency_code = ?
+		FROM (SELECT order_id, SUM(line_total_minor_units) AS subtotal FROM order_items GROUP BY order_id) AS totals
+		WHERE totals.order_id = orders.id`,
+		models.DefaultCurrency, models.DefaultCurrency, models.DefaultCurrency, models.DefaultCurrency).Error
 }
\ No newline at end of file
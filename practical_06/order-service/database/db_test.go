@@ -0,0 +1,74 @@
+package database
+
+import (
+	"testing"
+
+	"order-service/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupLegacyDB creates an in-memory SQLite database with the orders schema
+// from before totals were stored, holding one order of 2 x 2.50
+func setupLegacyDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	require.NoError(t, err, "Failed to open test database")
+
+	require.NoError(t, db.Exec(`CREATE TABLE orders (
+		id integer PRIMARY KEY, created_at datetime, updated_at datetime, deleted_at datetime,
+		user_id integer, status text)`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE order_items (
+		id integer PRIMARY KEY, created_at datetime, updated_at datetime, deleted_at datetime,
+		order_id integer, menu_item_id integer, quantity integer, price real)`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO orders (id, user_id, status) VALUES (1, 1, 'completed')`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO order_items (id, order_id, menu_item_id, quantity, price) VALUES (1, 1, 1, 2, 2.5)`).Error)
+
+	DB = db
+	t.Cleanup(func() {
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		sqlDB.Close()
+	})
+	return db
+}
+
+func TestMigrate_BackfillsTotalsOnce(t *testing.T) {
+	db := setupLegacyDB(t)
+
+	require.NoError(t, Migrate())
+
+	var legacy models.Order
+	require.NoError(t, db.Preload("OrderItems").First(&legacy, 1).Error)
+	assert.Equal(t, int64(500), legacy.Subtotal.MinorUnits)
+	assert.Equal(t, int64(500), legacy.Total.MinorUnits)
+	require.Len(t, legacy.OrderItems, 1)
+	assert.Equal(t, int64(250), legacy.OrderItems[0].Price.MinorUnits)
+	assert.Equal(t, int64(500), legacy.OrderItems[0].LineTotal.MinorUnits)
+
+	// A free drink: fully discounted, so its total is legitimately zero
+	free := models.Order{
+		UserID:   1,
+		Status:   "pending",
+		Subtotal: models.Money{CurrencyCode: models.DefaultCurrency, MinorUnits: 300},
+		Discount: models.Money{CurrencyCode: models.DefaultCurrency, MinorUnits: 300},
+		Total:    models.Money{CurrencyCode: models.DefaultCurrency, MinorUnits: 0},
+		OrderItems: []models.OrderItem{{
+			MenuItemID: 2,
+			Quantity:   1,
+			Price:      models.Money{CurrencyCode: models.DefaultCurrency, MinorUnits: 300},
+			LineTotal:  models.Money{CurrencyCode: models.DefaultCurrency, MinorUnits: 300},
+		}},
+	}
+	require.NoError(t, db.Create(&free).Error)
+
+	// Every later boot migrates again
+	require.NoError(t, Migrate())
+
+	var reloaded models.Order
+	require.NoError(t, db.First(&reloaded, free.ID).Error)
+	assert.Equal(t, int64(300), reloaded.Discount.MinorUnits)
+	assert.Equal(t, int64(0), reloaded.Total.MinorUnits, "discounted order must keep its zero total")
+}
\ No newline at end of file
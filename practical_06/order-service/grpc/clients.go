@@ -4,10 +4,11 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/douglasswm/student-cafe-protos/authz"
 	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
 	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/douglasswm/student-cafe-protos/mtls"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // Clients holds gRPC client connections
@@ -29,10 +30,21 @@ func NewClients() (*Clients, error) {
 		menuServiceAddr = "menu-service:9092"
 	}
 
+	// The services only accept callers with a certificate from the cafe's CA
+	tlsConfig, err := mtls.FromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure TLS: %w", err)
+	}
+	creds, err := tlsConfig.ClientCredentials()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS credentials: %w", err)
+	}
+
 	// Connect to user service
 	userConn, err := grpc.NewClient(
 		userServiceAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
+		authz.AsService(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to user service at %s: %w", userServiceAddr, err)
@@ -41,7 +53,8 @@ func NewClients() (*Clients, error) {
 	// Connect to menu service
 	menuConn, err := grpc.NewClient(
 		menuServiceAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
+		authz.AsService(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to menu service at %s: %w", menuServiceAddr, err)
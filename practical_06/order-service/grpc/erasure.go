@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"order-service/database"
+	"order-service/models"
+)
+
+// DetachUserOrders detaches a deleted user's orders from them. The orders are
+// kept for accounting, but their user ID and the free-text notes on their lines
+// are cleared, the sagas, idempotency keys and outbox events that still carry
+// them are scrubbed, and an OrdersUserDetached event is published. Orders already detached are
+// left alone, so the User Service can safely call it again after a failure.
+func (s *OrderServer) DetachUserOrders(ctx context.Context, req *orderv1.DetachUserOrdersRequest) (*orderv1.DetachUserOrdersResponse, error) {
+	if req.UserId == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "user_id is required")
+	}
+	userID := uint(req.UserId)
+
+	var orderIDs []uint
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Order{}).Where("user_id = ?", userID).Pluck("id", &orderIDs).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to get orders: %v", err)
+		}
+
+		// Sagas still in flight would recreate the link, so scrub them even
+		// when there are no orders yet
+		if err := tx.Unscoped().Model(&models.OrderSaga{}).Where("user_id = ?", userID).Update("user_id", 0).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to detach order sagas: %v", err)
+		}
+		if len(orderIDs) == 0 {
+			return nil
+		}
+
+		now := s.now().UTC()
+		if err := tx.Unscoped().Model(&models.Order{}).Where("id IN ?", orderIDs).
+			Updates(map[string]interface{}{"user_id": 0, "user_detached_at": now}).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to detach orders: %v", err)
+		}
+		if err := tx.Unscoped().Model(&models.OrderItem{}).Where("order_id IN ?", orderIDs).Update("note", "").Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to clear order notes: %v", err)
+		}
+		// Stored responses repeat the user's ID and notes
+		if err := tx.Unscoped().Where("order_id IN ?", orderIDs).Delete(&models.IdempotencyKey{}).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to delete idempotency keys: %v", err)
+		}
+		// So do the events recorded for the orders
+		if err := scrubOrderEvents(tx, orderIDs); err != nil {
+			return status.Errorf(codes.Internal, "failed to scrub order events: %v", err)
+		}
+		if err := enqueueOrdersUserDetached(tx, userID, orderIDs); err != nil {
+			return status.Errorf(codes.Internal, "failed to record order event: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderv1.DetachUserOrdersResponse{
+		OrdersDetached: int64(len(orderIDs)),
+	}, nil
+}
\ No newline at end of file
@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"time"
+
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+	"order-service/models"
+	"order-service/outbox"
+)
+
+// enqueueOrderCreated records an OrderCreated event for a newly saved order
+func enqueueOrderCreated(tx *gorm.DB, order *models.Order) error {
+	return outbox.Enqueue(tx, models.EventOrderCreated, order.ID, func(eventID uint64, occurredAt time.Time) proto.Message {
+		return &orderv1.OrderCreated{
+			EventId:    eventID,
+			OccurredAt: occurredAt.Format(time.RFC3339),
+			Order:      modelToProto(order),
+		}
+	})
+}
+
+// enqueueOrderStatusChanged records an OrderStatusChanged event for a status transition
+func enqueueOrderStatusChanged(tx *gorm.DB, order *models.Order, transition *models.OrderStatusTransition) error {
+	return outbox.Enqueue(tx, models.EventOrderStatusChanged, order.ID, func(eventID uint64, occurredAt time.Time) proto.Message {
+		return &orderv1.OrderStatusChanged{
+			EventId:    eventID,
+			OccurredAt: occurredAt.Format(time.RFC3339),
+			OrderId:    uint32(order.ID),
+			UserId:     uint32(order.UserID),
+			Transition: &orderv1.OrderStatusTransition{
+				FromStatus: transition.FromStatus,
+				ToStatus:   transition.ToStatus,
+				Actor:      transition.Actor,
+				CreatedAt:  transition.CreatedAt.Format(time.RFC3339),
+			},
+		}
+	})
+}
+
+// enqueueOrdersUserDetached records an OrdersUserDetached event for the orders
+// of a deleted user
+func enqueueOrdersUserDetached(tx *gorm.DB, userID uint, orderIDs []uint) error {
+	return outbox.Enqueue(tx, models.EventOrdersUserDetached, userID, func(eventID uint64, occurredAt time.Time) proto.Message {
+		event := &orderv1.OrdersUserDetached{
+			EventId:    eventID,
+			OccurredAt: occurredAt.Format(time.RFC3339),
+			UserId:     uint32(userID),
+		}
+		for _, id := range orderIDs {
+			event.OrderIds = append(event.OrderIds, uint32(id))
+		}
+		return event
+	})
+}
+
+// scrubOrderEvents removes a detached user from the events of their orders:
+// events already published are deleted, and those still waiting for the relay
+// are rewritten without the user's ID and notes
+func scrubOrderEvents(tx *gorm.DB, orderIDs []uint) error {
+	eventTypes := []string{models.EventOrderCreated, models.EventOrderStatusChanged}
+	if err := tx.Unscoped().
+		Where("aggregate_id IN ? AND type IN ? AND published_at IS NOT NULL", orderIDs, eventTypes).
+		Delete(&models.OutboxEvent{}).Error; err != nil {
+		return err
+	}
+
+	var pending []models.OutboxEvent
+	if err := tx.Unscoped().
+		Where("aggregate_id IN ? AND type IN ? AND published_at IS NULL", orderIDs, eventTypes).
+		Find(&pending).Error; err != nil {
+		return err
+	}
+	for i := range pending {
+		payload, err := scrubOrderEvent(pending[i].Type, pending[i].Payload)
+		if err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&pending[i]).Update("payload", payload).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scrubOrderEvent clears the user's ID and notes from an encoded order event
+func scrubOrderEvent(eventType string, payload []byte) ([]byte, error) {
+	switch eventType {
+	case models.EventOrderCreated:
+		var event orderv1.OrderCreated
+		if err := proto.Unmarshal(payload, &event); err != nil {
+			return nil, err
+		}
+		if event.Order != nil {
+			event.Order.UserId = 0
+			for _, item := range event.Order.OrderItems {
+				item.Note = ""
+			}
+		}
+		return proto.Marshal(&event)
+	case models.EventOrderStatusChanged:
+		var event orderv1.OrderStatusChanged
+		if err := proto.Unmarshal(payload, &event); err != nil {
+			return nil, err
+		}
+		event.UserId = 0
+		return proto.Marshal(&event)
+	}
+	return payload, nil
+}
\ No newline at end of file
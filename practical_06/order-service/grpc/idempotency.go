@@ -0,0 +1,176 @@
+package grpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"strings"
+	"time"
+
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"order-service/database"
+	"order-service/models"
+)
+
+const (
+	// IdempotencyKeyMetadata is the gRPC metadata key carrying a client's Idempotency-Key
+	IdempotencyKeyMetadata = "idempotency-key"
+
+	maxIdempotencyKeyLength = 255
+	defaultIdempotencyTTL   = 24 * time.Hour
+
+	// idempotencyLockTimeout is how long a request may hold its key without
+	// finishing before it is considered abandoned, e.g. after a crash
+	idempotencyLockTimeout = time.Minute
+)
+
+// idempotencyKeyFrom returns the Idempotency-Key sent with a call, if any
+func idempotencyKeyFrom(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(IdempotencyKeyMetadata)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(values[0])
+}
+
+// createOrderHash fingerprints a CreateOrder request so a key cannot be reused for a different order
+func createOrderHash(req *orderv1.CreateOrderRequest) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// idempotencyTTL returns how long responses are kept for replays
+func (s *OrderServer) idempotencyTTL() time.Duration {
+	if s.IdempotencyTTL <= 0 {
+		return defaultIdempotencyTTL
+	}
+	return s.IdempotencyTTL
+}
+
+// claimIdempotencyKey reserves key for this request. If the key was already
+// used with the same request the original response is returned instead;
+// a different request under the same key is rejected.
+func (s *OrderServer) claimIdempotencyKey(key string, req *orderv1.CreateOrderRequest) (*models.IdempotencyKey, *orderv1.CreateOrderResponse, error) {
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "idempotency key must be at most %d characters", maxIdempotencyKeyLength)
+	}
+
+	hash, err := createOrderHash(req)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to fingerprint request: %v", err)
+	}
+
+	now := time.Now()
+	claim := models.IdempotencyKey{
+		Key:         key,
+		RequestHash: hash,
+		ExpiresAt:   now.Add(s.idempotencyTTL()),
+	}
+
+	var replay *orderv1.CreateOrderResponse
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		var existing models.IdempotencyKey
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where(&models.IdempotencyKey{Key: key}).First(&existing).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return status.Errorf(codes.Internal, "failed to look up idempotency key: %v", err)
+		}
+
+		if err == nil {
+			abandoned := len(existing.Response) == 0 && existing.CreatedAt.Before(now.Add(-idempotencyLockTimeout))
+			if existing.ExpiresAt.After(now) && !abandoned {
+				if existing.RequestHash != hash {
+					return status.Errorf(codes.InvalidArgument, "idempotency key was already used for a different request")
+				}
+				if len(existing.Response) == 0 {
+					return status.Errorf(codes.Aborted, "a request with this idempotency key is still in progress")
+				}
+
+				replay = &orderv1.CreateOrderResponse{}
+				if err := proto.Unmarshal(existing.Response, replay); err != nil {
+					return status.Errorf(codes.Internal, "failed to decode stored response: %v", err)
+				}
+				return nil
+			}
+
+			// The key has expired or its request never finished, so it is free again
+			if err := tx.Unscoped().Delete(&existing).Error; err != nil {
+				return status.Errorf(codes.Internal, "failed to free idempotency key: %v", err)
+			}
+		}
+
+		if err := tx.Create(&claim).Error; err != nil {
+			// Lost a race with a concurrent request using the same key
+			return status.Errorf(codes.Aborted, "a request with this idempotency key is still in progress")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if replay != nil {
+		return nil, replay, nil
+	}
+
+	return &claim, nil, nil
+}
+
+// completeIdempotencyKey stores the response of a claimed request for replays
+func completeIdempotencyKey(claim *models.IdempotencyKey, resp *orderv1.CreateOrderResponse) {
+	data, err := proto.Marshal(resp)
+	if err == nil {
+		err = database.DB.Model(claim).Updates(map[string]interface{}{
+			"response": data,
+			"order_id": resp.Order.Id,
+		}).Error
+	}
+	if err != nil {
+		// The order is placed; a replay will be treated as abandoned and may place it again
+		log.Printf("failed to store response for idempotency key %q: %v", claim.Key, err)
+	}
+}
+
+// releaseIdempotencyKey frees the key of a failed request so the client can retry with it
+func releaseIdempotencyKey(claim *models.IdempotencyKey) {
+	if err := database.DB.Unscoped().Delete(claim).Error; err != nil {
+		log.Printf("failed to release idempotency key %q: %v", claim.Key, err)
+	}
+}
+
+// PurgeExpiredIdempotencyKeys deletes keys whose responses are no longer kept
+func (s *OrderServer) PurgeExpiredIdempotencyKeys() (int64, error) {
+	result := database.DB.Unscoped().Where("expires_at < ?", time.Now()).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
+
+// RunIdempotencyKeyPurge calls PurgeExpiredIdempotencyKeys every interval until ctx is done
+func (s *OrderServer) RunIdempotencyKeyPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := s.PurgeExpiredIdempotencyKeys(); err != nil {
+			log.Printf("idempotency key purge: %v", err)
+		}
+	}
+}
\ No newline at end of file
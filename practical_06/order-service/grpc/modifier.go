@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"order-service/models"
+)
+
+// maxNoteLength bounds the free-text note of an order line, in characters
+const maxNoteLength = 200
+
+// maxLineQuantity bounds the quantity of an order line
+const maxLineQuantity = 100
+
+// selectModifiers checks the modifiers chosen for an order line against the
+// menu item's modifier groups and snapshots their names and prices, which
+// must be in the order's currency
+func selectModifiers(menuItem *menuv1.MenuItem, ids []uint32, currency string) ([]models.OrderItemModifier, error) {
+	type offer struct {
+		group    *menuv1.ModifierGroup
+		modifier *menuv1.Modifier
+	}
+	offered := make(map[uint32]offer)
+	for _, group := range menuItem.ModifierGroups {
+		for _, modifier := range group.Modifiers {
+			offered[modifier.Id] = offer{group: group, modifier: modifier}
+		}
+	}
+
+	selected := make([]models.OrderItemModifier, 0, len(ids))
+	perGroup := make(map[uint32]int)
+	seen := make(map[uint32]bool)
+	for _, id := range ids {
+		choice, ok := offered[id]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "modifier %d is not offered for menu item %d", id, menuItem.Id)
+		}
+		if seen[id] {
+			return nil, status.Errorf(codes.InvalidArgument, "modifier %d is chosen more than once for menu item %d", id, menuItem.Id)
+		}
+		seen[id] = true
+		perGroup[choice.group.Id]++
+
+		delta := moneyFromProto(choice.modifier.PriceDelta)
+		if delta.CurrencyCode == "" {
+			delta.CurrencyCode = currency
+		}
+		if delta.CurrencyCode != currency {
+			return nil, status.Errorf(codes.FailedPrecondition, "modifier %d is priced in %s, not %s", id, delta.CurrencyCode, currency)
+		}
+
+		selected = append(selected, models.OrderItemModifier{
+			ModifierID: uint(id),
+			GroupName:  choice.group.Name,
+			Name:       choice.modifier.Name,
+			PriceDelta: delta,
+		})
+	}
+
+	for _, group := range menuItem.ModifierGroups {
+		count := perGroup[group.Id]
+		if count < int(group.MinSelections) {
+			return nil, status.Errorf(codes.InvalidArgument, "choose at least %d from %q for menu item %d", group.MinSelections, group.Name, menuItem.Id)
+		}
+		if group.MaxSelections > 0 && count > int(group.MaxSelections) {
+			return nil, status.Errorf(codes.InvalidArgument, "choose at most %d from %q for menu item %d", group.MaxSelections, group.Name, menuItem.Id)
+		}
+	}
+
+	return selected, nil
+}
\ No newline at end of file
@@ -0,0 +1,130 @@
+package grpc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	"gorm.io/gorm"
+	"order-service/models"
+)
+
+const (
+	defaultOrdersPageSize = 50
+	maxOrdersPageSize     = 100
+)
+
+// orderSort describes how a GetOrders listing is ordered.
+// Orders are always tie-broken on ID so pages are stable.
+type orderSort struct {
+	column string // "created_at" or "id"
+	desc   bool
+}
+
+// parseOrderSort parses the order_by field of GetOrdersRequest
+func parseOrderSort(orderBy string) (orderSort, error) {
+	fields := strings.Fields(strings.ToLower(orderBy))
+	sort := orderSort{column: "created_at"}
+
+	if len(fields) == 0 {
+		return sort, nil
+	}
+	if len(fields) > 2 {
+		return sort, fmt.Errorf("invalid order_by %q", orderBy)
+	}
+
+	switch fields[0] {
+	case "created_at", "id":
+		sort.column = fields[0]
+	default:
+		return sort, fmt.Errorf("cannot sort orders by %q", fields[0])
+	}
+
+	if len(fields) == 2 {
+		switch fields[1] {
+		case "asc":
+		case "desc":
+			sort.desc = true
+		default:
+			return sort, fmt.Errorf("invalid sort direction %q", fields[1])
+		}
+	}
+
+	return sort, nil
+}
+
+// apply adds the ORDER BY clause for this sort to a query
+func (o orderSort) apply(q *gorm.DB) *gorm.DB {
+	dir := "ASC"
+	if o.desc {
+		dir = "DESC"
+	}
+	if o.column == "id" {
+		return q.Order("id " + dir)
+	}
+	return q.Order(o.column + " " + dir).Order("id " + dir)
+}
+
+// ordersPageToken is the decoded form of an opaque GetOrders page token.
+// It remembers where the previous page stopped (keyset pagination) and a
+// fingerprint of the query so a token cannot be replayed against different filters.
+type ordersPageToken struct {
+	Query     string    `json:"q"`
+	CreatedAt time.Time `json:"c"`
+	ID        uint      `json:"i"`
+}
+
+// ordersQueryFingerprint identifies the filters and sort of a GetOrders request
+func ordersQueryFingerprint(req *orderv1.GetOrdersRequest) string {
+	key := fmt.Sprintf("%d|%s|%s|%s|%s", req.UserId, req.Status, req.CreatedAfter, req.CreatedBefore, strings.ToLower(strings.TrimSpace(req.OrderBy)))
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
+
+// encodeOrdersPageToken builds the token that continues after the given order
+func encodeOrdersPageToken(fingerprint string, last *models.Order) string {
+	data, _ := json.Marshal(ordersPageToken{
+		Query:     fingerprint,
+		CreatedAt: last.CreatedAt,
+		ID:        last.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeOrdersPageToken parses a page token and checks it belongs to the same query
+func decodeOrdersPageToken(token, fingerprint string) (*ordersPageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("malformed page token")
+	}
+
+	var pt ordersPageToken
+	if err := json.Unmarshal(data, &pt); err != nil {
+		return nil, fmt.Errorf("malformed page token")
+	}
+	if pt.Query != fingerprint {
+		return nil, fmt.Errorf("page token does not match the request filters")
+	}
+
+	return &pt, nil
+}
+
+// after restricts a query to the rows that come after the token's position
+func (pt *ordersPageToken) after(q *gorm.DB, sort orderSort) *gorm.DB {
+	op := ">"
+	if sort.desc {
+		op = "<"
+	}
+	if sort.column == "id" {
+		return q.Where("id "+op+" ?", pt.ID)
+	}
+	return q.Where(
+		fmt.Sprintf("%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?)", sort.column, op),
+		pt.CreatedAt, pt.CreatedAt, pt.ID,
+	)
+}
\ No newline at end of file
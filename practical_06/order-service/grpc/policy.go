@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	"github.com/douglasswm/student-cafe-protos/authz"
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	"github.com/douglasswm/student-cafe-protos/principal"
+)
+
+// Policy says who may call each OrderService method. Every user may order and
+// follow their orders, staff progress orders, only owners manage promotions,
+// and only the User Service detaches the orders of deleted users. Which orders
+// a caller may see is checked by the methods.
+var Policy = authz.Policy{
+	orderv1.OrderService_CreateOrder_FullMethodName:    authz.Allow(authz.Users...),
+	orderv1.OrderService_GetOrders_FullMethodName:      authz.Allow(authz.Users...),
+	orderv1.OrderService_GetOrder_FullMethodName:       authz.Allow(authz.Users...),
+	orderv1.OrderService_WatchOrder_FullMethodName:     authz.Allow(authz.Users...),
+	orderv1.OrderService_ListPromotions_FullMethodName: authz.Allow(authz.Users...),
+
+	orderv1.OrderService_UpdateOrderStatus_FullMethodName: authz.Allow(principal.RoleOwner, principal.RoleStaff),
+
+	orderv1.OrderService_CreatePromotion_FullMethodName: authz.Allow(principal.RoleOwner),
+	orderv1.OrderService_DeletePromotion_FullMethodName: authz.Allow(principal.RoleOwner),
+
+	orderv1.OrderService_DetachUserOrders_FullMethodName: authz.Allow(principal.RoleService),
+}
\ No newline at end of file
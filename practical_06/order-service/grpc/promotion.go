@@ -0,0 +1,376 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"order-service/database"
+	"order-service/models"
+	"order-service/pricing"
+	"order-service/promotions"
+)
+
+// now returns the current time in the cafe's time zone
+func (s *OrderServer) now() time.Time {
+	t := time.Now()
+	if s.clock != nil {
+		t = s.clock()
+	}
+	if s.Location != nil {
+		return t.In(s.Location)
+	}
+	return t.UTC()
+}
+
+// CreatePromotion creates a promotion rule
+func (s *OrderServer) CreatePromotion(ctx context.Context, req *orderv1.CreatePromotionRequest) (*orderv1.CreatePromotionResponse, error) {
+	promotion, err := promotionFromProto(req.Promotion)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := database.DB.Create(&promotion).Error; err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create promotion: %v", err)
+	}
+
+	return &orderv1.CreatePromotionResponse{
+		Promotion: promotionToProto(&promotion),
+	}, nil
+}
+
+// ListPromotions lists the promotion rules, highest priority first
+func (s *OrderServer) ListPromotions(ctx context.Context, req *orderv1.ListPromotionsRequest) (*orderv1.ListPromotionsResponse, error) {
+	var list []models.Promotion
+	if err := withRules(database.DB).Order("priority DESC").Order("id").Find(&list).Error; err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list promotions: %v", err)
+	}
+
+	resp := &orderv1.ListPromotionsResponse{}
+	for i := range list {
+		resp.Promotions = append(resp.Promotions, promotionToProto(&list[i]))
+	}
+	return resp, nil
+}
+
+// DeletePromotion soft deletes a promotion rule, so it no longer applies to
+// new orders while orders it was applied to keep their discounts
+func (s *OrderServer) DeletePromotion(ctx context.Context, req *orderv1.DeletePromotionRequest) (*orderv1.DeletePromotionResponse, error) {
+	result := database.DB.Delete(&models.Promotion{}, req.Id)
+	if result.Error != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete promotion: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, status.Errorf(codes.NotFound, "promotion not found")
+	}
+	return &orderv1.DeletePromotionResponse{}, nil
+}
+
+// withRules loads the targets and windows of promotions
+func withRules(db *gorm.DB) *gorm.DB {
+	return db.Preload("Targets").Preload("Windows")
+}
+
+// applyPromotions works out the discounts the promotions active now give an
+// order, whose lines are priced in currency, and stores them on the order.
+// It returns their total.
+func (s *OrderServer) applyPromotions(order *models.Order, priced []pricing.Line, categories []uint, currency string) (int64, error) {
+	now := s.now()
+
+	var active []models.Promotion
+	if err := withRules(database.DB).Where("ends_at IS NULL OR ends_at > ?", now.UTC()).Find(&active).Error; err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get promotions: %v", err)
+	}
+
+	var rules []promotions.Rule
+	for i := range active {
+		// Fixed amounts only make sense in their own currency
+		if active[i].Kind == promotions.KindFixed && active[i].AmountOff.CurrencyCode != currency {
+			continue
+		}
+		rules = append(rules, ruleFromModel(&active[i]))
+	}
+
+	lines := make([]promotions.Line, len(order.OrderItems))
+	for i, item := range order.OrderItems {
+		lines[i] = promotions.Line{
+			MenuItemID: item.MenuItemID,
+			CategoryID: categories[i],
+			UnitPrice:  priced[i].UnitPrice,
+			Quantity:   priced[i].Quantity,
+		}
+	}
+
+	var total int64
+	order.Discounts = nil
+	for _, discount := range promotions.Apply(rules, lines, now) {
+		order.Discounts = append(order.Discounts, models.OrderDiscount{
+			PromotionID: discount.Rule.ID,
+			Name:        discount.Rule.Name,
+			Kind:        discount.Rule.Kind,
+			Amount:      models.Money{MinorUnits: discount.Amount, CurrencyCode: currency},
+		})
+		total += discount.Amount
+	}
+	return total, nil
+}
+
+// ruleFromModel converts a stored promotion to the rule it evaluates
+func ruleFromModel(promotion *models.Promotion) promotions.Rule {
+	rule := promotions.Rule{
+		ID:           promotion.ID,
+		Name:         promotion.Name,
+		Kind:         promotion.Kind,
+		PercentOff:   promotion.PercentOff,
+		AmountOff:    promotion.AmountOff.MinorUnits,
+		BuyQuantity:  promotion.BuyQuantity,
+		FreeQuantity: promotion.FreeQuantity,
+		StartsAt:     promotion.StartsAt,
+		EndsAt:       promotion.EndsAt,
+		Exclusive:    promotion.Exclusive,
+		Priority:     promotion.Priority,
+	}
+
+	var free promotions.Target
+	for _, target := range promotion.Targets {
+		t := &rule.Target
+		if target.Free {
+			t = &free
+		}
+		if target.MenuItemID != 0 {
+			t.MenuItemIDs = append(t.MenuItemIDs, target.MenuItemID)
+		}
+		if target.CategoryID != 0 {
+			t.CategoryIDs = append(t.CategoryIDs, target.CategoryID)
+		}
+	}
+	if !free.IsZero() {
+		rule.FreeTarget = &free
+	}
+
+	for _, window := range promotion.Windows {
+		rule.Windows = append(rule.Windows, promotions.Window{
+			Weekday:     window.Weekday,
+			StartMinute: window.StartMinute,
+			EndMinute:   window.EndMinute,
+		})
+	}
+	return rule
+}
+
+// promotionFromProto validates a promotion sent by a client
+func promotionFromProto(p *orderv1.Promotion) (models.Promotion, error) {
+	if p == nil {
+		return models.Promotion{}, status.Errorf(codes.InvalidArgument, "promotion is required")
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		return models.Promotion{}, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	promotion := models.Promotion{
+		Name:      p.Name,
+		Kind:      p.Kind,
+		Exclusive: p.Exclusive,
+		Priority:  int(p.Priority),
+	}
+
+	// Only the fields of the rule's kind may be set
+	percentSet := p.PercentOff != 0
+	amountSet := p.AmountOff != nil
+	bogoSet := p.BuyQuantity != 0 || p.FreeQuantity != 0 || len(p.FreeMenuItemIds) > 0 || len(p.FreeCategoryIds) > 0
+	switch p.Kind {
+	case promotions.KindPercentage:
+		if amountSet || bogoSet {
+			return models.Promotion{}, status.Errorf(codes.InvalidArgument, "percentage promotions only take percent_off")
+		}
+		if p.PercentOff < 1 || p.PercentOff > 100 {
+			return models.Promotion{}, status.Errorf(codes.InvalidArgument, "percent_off must be between 1 and 100")
+		}
+		promotion.PercentOff = int(p.PercentOff)
+	case promotions.KindFixed:
+		if percentSet || bogoSet {
+			return models.Promotion{}, status.Errorf(codes.InvalidArgument, "fixed promotions only take amount_off")
+		}
+		if p.AmountOff.GetMinorUnits() <= 0 {
+			return models.Promotion{}, status.Errorf(codes.InvalidArgument, "amount_off must be positive")
+		}
+		promotion.AmountOff = moneyFromProto(p.AmountOff)
+		if promotion.AmountOff.CurrencyCode == "" {
+			promotion.AmountOff.CurrencyCode = models.DefaultCurrency
+		}
+		if !validCurrencyCode(promotion.AmountOff.CurrencyCode) {
+			return models.Promotion{}, status.Errorf(codes.InvalidArgument, "invalid currency code %q, expected an ISO 4217 code such as USD", promotion.AmountOff.CurrencyCode)
+		}
+	case promotions.KindBOGO:
+		if percentSet || amountSet {
+			return models.Promotion{}, status.Errorf(codes.InvalidArgument, "bogo promotions only take buy_quantity and free_quantity")
+		}
+		if p.BuyQuantity < 1 || p.FreeQuantity < 1 {
+			return models.Promotion{}, status.Errorf(codes.InvalidArgument, "buy_quantity and free_quantity must be positive")
+		}
+		promotion.BuyQuantity = int(p.BuyQuantity)
+		promotion.FreeQuantity = int(p.FreeQuantity)
+	default:
+		return models.Promotion{}, status.Errorf(codes.InvalidArgument, "invalid kind %q, expected percentage, fixed or bogo", p.Kind)
+	}
+
+	for _, id := range p.MenuItemIds {
+		promotion.Targets = append(promotion.Targets, models.PromotionTarget{MenuItemID: uint(id)})
+	}
+	for _, id := range p.CategoryIds {
+		promotion.Targets = append(promotion.Targets, models.PromotionTarget{CategoryID: uint(id)})
+	}
+	for _, id := range p.FreeMenuItemIds {
+		promotion.Targets = append(promotion.Targets, models.PromotionTarget{Free: true, MenuItemID: uint(id)})
+	}
+	for _, id := range p.FreeCategoryIds {
+		promotion.Targets = append(promotion.Targets, models.PromotionTarget{Free: true, CategoryID: uint(id)})
+	}
+	for _, target := range promotion.Targets {
+		if target.MenuItemID == 0 && target.CategoryID == 0 {
+			return models.Promotion{}, status.Errorf(codes.InvalidArgument, "menu item and category IDs must not be 0")
+		}
+	}
+
+	for _, window := range p.Windows {
+		weekday, ok := parseWeekday(window.Weekday)
+		if !ok {
+			return models.Promotion{}, status.Errorf(codes.InvalidArgument, "invalid weekday %q, expected e.g. monday", window.Weekday)
+		}
+		start, err := parseClock(window.StartTime)
+		if err != nil {
+			return models.Promotion{}, status.Errorf(codes.InvalidArgument, "invalid start_time: %v", err)
+		}
+		end, err := parseClock(window.EndTime)
+		if err != nil {
+			return models.Promotion{}, status.Errorf(codes.InvalidArgument, "invalid end_time: %v", err)
+		}
+		if start >= end {
+			return models.Promotion{}, status.Errorf(codes.InvalidArgument, "promotion window on %s must end after it starts", window.Weekday)
+		}
+		promotion.Windows = append(promotion.Windows, models.PromotionWindow{Weekday: weekday, StartMinute: start, EndMinute: end})
+	}
+
+	var err error
+	if promotion.StartsAt, err = parseOptionalTime(p.StartsAt); err != nil {
+		return models.Promotion{}, status.Errorf(codes.InvalidArgument, "invalid starts_at: %v", err)
+	}
+	if promotion.EndsAt, err = parseOptionalTime(p.EndsAt); err != nil {
+		return models.Promotion{}, status.Errorf(codes.InvalidArgument, "invalid ends_at: %v", err)
+	}
+	if promotion.StartsAt != nil && promotion.EndsAt != nil && !promotion.EndsAt.After(*promotion.StartsAt) {
+		return models.Promotion{}, status.Errorf(codes.InvalidArgument, "ends_at must be after starts_at")
+	}
+
+	return promotion, nil
+}
+
+// promotionToProto converts a promotion to its proto message
+func promotionToProto(promotion *models.Promotion) *orderv1.Promotion {
+	p := &orderv1.Promotion{
+		Id:           uint32(promotion.ID),
+		Name:         promotion.Name,
+		Kind:         promotion.Kind,
+		PercentOff:   int32(promotion.PercentOff),
+		BuyQuantity:  int32(promotion.BuyQuantity),
+		FreeQuantity: int32(promotion.FreeQuantity),
+		Exclusive:    promotion.Exclusive,
+		Priority:     int32(promotion.Priority),
+		CreatedAt:    promotion.CreatedAt.Format(time.RFC3339),
+	}
+	if promotion.Kind == promotions.KindFixed {
+		p.AmountOff = moneyToProto(promotion.AmountOff)
+	}
+
+	for _, target := range promotion.Targets {
+		switch {
+		case target.MenuItemID != 0 && target.Free:
+			p.FreeMenuItemIds = append(p.FreeMenuItemIds, uint32(target.MenuItemID))
+		case target.MenuItemID != 0:
+			p.MenuItemIds = append(p.MenuItemIds, uint32(target.MenuItemID))
+		case target.Free:
+			p.FreeCategoryIds = append(p.FreeCategoryIds, uint32(target.CategoryID))
+		default:
+			p.CategoryIds = append(p.CategoryIds, uint32(target.CategoryID))
+		}
+	}
+
+	for _, window := range promotion.Windows {
+		p.Windows = append(p.Windows, &orderv1.PromotionWindow{
+			Weekday:   strings.ToLower(window.Weekday.String()),
+			StartTime: formatClock(window.StartMinute),
+			EndTime:   formatClock(window.EndMinute),
+		})
+	}
+
+	if promotion.StartsAt != nil {
+		p.StartsAt = promotion.StartsAt.Format(time.RFC3339)
+	}
+	if promotion.EndsAt != nil {
+		p.EndsAt = promotion.EndsAt.Format(time.RFC3339)
+	}
+	return p
+}
+
+// validCurrencyCode reports whether code looks like an ISO 4217 code: three upper case letters
+func validCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseOptionalTime parses an RFC 3339 time, returning nil for an empty string
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	t = t.UTC()
+	return &t, nil
+}
+
+// parseWeekday parses a lower case day name such as "monday"
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if name == strings.ToLower(day.String()) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// parseClock parses a "HH:MM" time of day into minutes after midnight. "24:00"
+// is accepted as the end of the day.
+func parseClock(clock string) (int, error) {
+	var hour, minute int
+	if len(clock) != 5 {
+		return 0, fmt.Errorf("%q is not in HH:MM format", clock)
+	}
+	if _, err := fmt.Sscanf(clock, "%2d:%2d", &hour, &minute); err != nil {
+		return 0, fmt.Errorf("%q is not in HH:MM format", clock)
+	}
+	total := hour*60 + minute
+	if hour < 0 || minute < 0 || minute > 59 || total > promotions.MinutesPerDay {
+		return 0, fmt.Errorf("%q is not a time of day", clock)
+	}
+	return total, nil
+}
+
+// formatClock formats minutes after midnight as "HH:MM"
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
\ No newline at end of file
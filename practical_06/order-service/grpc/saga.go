@@ -0,0 +1,274 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"order-service/database"
+	"order-service/models"
+)
+
+// sagaActor is recorded as the actor of status changes made by the order saga
+const sagaActor = "order-saga"
+
+// errSagaTakenOver is returned when a saga changed state behind our back,
+// which happens when recovery decided to compensate a slow saga
+var errSagaTakenOver = status.Errorf(codes.Aborted, "order saga was interrupted, please retry")
+
+// placeOrder runs the saga that places an order across services:
+//
+//  1. reserve stock for every line in the menu service
+//  2. save the order
+//  3. commit the reserved stock
+//
+// The saga state is persisted before every step. If a step fails the steps
+// already taken are compensated: reserved stock is released and a saved order
+// is cancelled. Sagas interrupted by a crash are finished by RecoverSagas.
+func (s *OrderServer) placeOrder(ctx context.Context, order *models.Order, lines []*menuv1.StockLine) error {
+	saga := models.OrderSaga{
+		UserID:        order.UserID,
+		ReservationID: newReservationID(),
+		State:         models.SagaStarted,
+	}
+	if err := database.DB.Create(&saga).Error; err != nil {
+		return status.Errorf(codes.Internal, "failed to start order saga: %v", err)
+	}
+	order.StockReservationID = saga.ReservationID
+
+	// Step 1: hold stock so two students cannot buy the last muffin
+	if _, err := s.MenuClient.ReserveStock(ctx, &menuv1.ReserveStockRequest{
+		ReservationId: saga.ReservationID,
+		Lines:         lines,
+	}); err != nil {
+		// The reservation may have gone through even if the reply was lost
+		s.abortSaga(ctx, &saga, err)
+		return reserveStockError(err)
+	}
+	if err := advanceSaga(database.DB, &saga, models.SagaStarted, models.SagaStockReserved); err != nil {
+		s.abortSaga(ctx, &saga, err)
+		return err
+	}
+
+	// Step 2: save the order and its event, recording it on the saga in the same transaction
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(order).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to create order: %v", err)
+		}
+		if err := enqueueOrderCreated(tx, order); err != nil {
+			return status.Errorf(codes.Internal, "failed to record order event: %v", err)
+		}
+		saga.OrderID = order.ID
+		return advanceSaga(tx, &saga, models.SagaStockReserved, models.SagaOrderCreated)
+	})
+	if err != nil {
+		saga.OrderID = 0
+		s.abortSaga(ctx, &saga, err)
+		return err
+	}
+
+	// Step 3: the order exists, so the reserved stock is now sold
+	if _, err := s.MenuClient.CommitStock(ctx, &menuv1.CommitStockRequest{ReservationId: saga.ReservationID}); err != nil {
+		if s.abortSaga(ctx, &saga, err) {
+			// The commit went through after all
+			return nil
+		}
+		return status.Errorf(codes.Unavailable, "failed to commit stock: %v", err)
+	}
+	if err := advanceSaga(database.DB, &saga, models.SagaOrderCreated, models.SagaCompleted); err != nil {
+		// Recovery got here first and will finish the saga
+		log.Printf("order saga %d: %v", saga.ID, err)
+	}
+
+	return nil
+}
+
+// reserveStockError maps a ReserveStock failure to the error returned by CreateOrder
+func reserveStockError(err error) error {
+	switch status.Code(err) {
+	case codes.ResourceExhausted, codes.InvalidArgument:
+		return err
+	case codes.NotFound:
+		return status.Errorf(codes.InvalidArgument, "%s", status.Convert(err).Message())
+	default:
+		return status.Errorf(codes.Unavailable, "failed to reserve stock: %v", err)
+	}
+}
+
+// advanceSaga moves a saga from one state to the next. The update only applies
+// if the saga is still in the expected state, so two parties can never both
+// drive the same saga forward.
+func advanceSaga(tx *gorm.DB, saga *models.OrderSaga, from, to string) error {
+	result := tx.Model(&models.OrderSaga{}).
+		Where("id = ? AND state = ?", saga.ID, from).
+		Updates(map[string]interface{}{"state": to, "order_id": saga.OrderID})
+	if result.Error != nil {
+		return status.Errorf(codes.Internal, "failed to update order saga: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errSagaTakenOver
+	}
+	saga.State = to
+	return nil
+}
+
+// abortSaga compensates a saga and logs if that fails; the saga is then left
+// compensating for RecoverSagas to retry. It reports whether the saga turned
+// out to be completed instead.
+func (s *OrderServer) abortSaga(ctx context.Context, saga *models.OrderSaga, cause error) bool {
+	// Compensation must run even if the caller has gone away
+	completed, err := s.compensateSaga(context.WithoutCancel(ctx), saga, cause)
+	if err != nil {
+		log.Printf("order saga %d: compensation failed, will retry: %v", saga.ID, err)
+	}
+	return completed
+}
+
+// compensateSaga undoes the steps a saga has taken: the reserved stock is
+// released and, if the order was saved, the order is cancelled. Every action
+// is idempotent so compensation can safely be retried.
+//
+// If the stock turns out to be committed already the order stands, and the
+// saga is completed instead; this is reported by the returned bool.
+func (s *OrderServer) compensateSaga(ctx context.Context, saga *models.OrderSaga, cause error) (bool, error) {
+	result := database.DB.Model(&models.OrderSaga{}).
+		Where("id = ? AND state NOT IN ?", saga.ID, models.SagaFinalStates).
+		Updates(map[string]interface{}{"state": models.SagaCompensating, "last_error": cause.Error()})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to mark saga as compensating: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		// Somebody else already finished the saga
+		if err := database.DB.First(saga, saga.ID).Error; err != nil {
+			return false, fmt.Errorf("failed to reload saga: %w", err)
+		}
+		if saga.State == models.SagaCompleted {
+			return true, nil
+		}
+
+		// A reservation that was still on its way when the saga was
+		// compensated must not keep the stock, so release it again
+		if _, err := s.MenuClient.ReleaseStock(ctx, &menuv1.ReleaseStockRequest{ReservationId: saga.ReservationID}); err != nil {
+			return false, fmt.Errorf("failed to release stock: %w", err)
+		}
+		return false, nil
+	}
+	if err := database.DB.First(saga, saga.ID).Error; err != nil {
+		return false, fmt.Errorf("failed to reload saga: %w", err)
+	}
+
+	if _, err := s.MenuClient.ReleaseStock(ctx, &menuv1.ReleaseStockRequest{ReservationId: saga.ReservationID}); err != nil {
+		if status.Code(err) != codes.FailedPrecondition {
+			return false, fmt.Errorf("failed to release stock: %w", err)
+		}
+
+		// The stock was sold, so the order must stand
+		if err := advanceSaga(database.DB, saga, models.SagaCompensating, models.SagaCompleted); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if saga.OrderID != 0 {
+		if err := s.cancelSagaOrder(saga.OrderID); err != nil {
+			return false, err
+		}
+	}
+
+	if err := advanceSaga(database.DB, saga, models.SagaCompensating, models.SagaCompensated); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// cancelSagaOrder cancels an order whose saga failed, unless it is cancelled already
+func (s *OrderServer) cancelSagaOrder(orderID uint) error {
+	var order models.Order
+	if err := database.DB.First(&order, orderID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return fmt.Errorf("failed to get order %d: %w", orderID, err)
+	}
+	if order.Status == models.StatusCancelled {
+		return nil
+	}
+
+	if _, err := s.changeOrderStatus(orderID, models.StatusCancelled, sagaActor); err != nil {
+		return fmt.Errorf("failed to cancel order %d: %w", orderID, err)
+	}
+	return nil
+}
+
+// RecoverSagas finishes order sagas that have been in flight for longer than
+// staleAfter, typically because the service crashed in the middle of them.
+// Sagas that saved their order are rolled forward by committing the stock;
+// all others are compensated. Sagas that cannot be finished yet, e.g. because
+// the menu service is down, are left for the next run.
+func (s *OrderServer) RecoverSagas(ctx context.Context, staleAfter time.Duration) error {
+	var sagas []models.OrderSaga
+	err := database.DB.
+		Where("state NOT IN ? AND updated_at < ?", models.SagaFinalStates, time.Now().Add(-staleAfter)).
+		Order("id").
+		Find(&sagas).Error
+	if err != nil {
+		return fmt.Errorf("failed to list in-flight sagas: %w", err)
+	}
+
+	var failed int
+	for i := range sagas {
+		if err := s.recoverSaga(ctx, &sagas[i]); err != nil {
+			log.Printf("order saga %d: recovery failed: %v", sagas[i].ID, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d in-flight sagas could not be recovered", failed, len(sagas))
+	}
+	return nil
+}
+
+// recoverSaga finishes a single interrupted saga
+func (s *OrderServer) recoverSaga(ctx context.Context, saga *models.OrderSaga) error {
+	if saga.State != models.SagaOrderCreated {
+		_, err := s.compensateSaga(ctx, saga, fmt.Errorf("saga interrupted in state %q", saga.State))
+		return err
+	}
+
+	_, err := s.MenuClient.CommitStock(ctx, &menuv1.CommitStockRequest{ReservationId: saga.ReservationID})
+	switch status.Code(err) {
+	case codes.OK:
+		return advanceSaga(database.DB, saga, models.SagaOrderCreated, models.SagaCompleted)
+	case codes.NotFound, codes.FailedPrecondition:
+		// The reservation is gone, so the order cannot be fulfilled
+		_, err := s.compensateSaga(ctx, saga, err)
+		return err
+	default:
+		return err
+	}
+}
+
+// RunSagaRecovery calls RecoverSagas straight away and then every interval
+// until ctx is done. Only sagas idle for a whole interval are recovered so
+// orders that are still being placed are left alone.
+func (s *OrderServer) RunSagaRecovery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RecoverSagas(ctx, interval); err != nil {
+			log.Printf("saga recovery: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
\ No newline at end of file
@@ -2,18 +2,26 @@ package grpc
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"time"
+	"unicode/utf8"
 
+	"github.com/douglasswm/student-cafe-protos/authz"
+	commonv1 "github.com/douglasswm/student-cafe-protos/gen/go/common/v1"
 	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
 	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
 	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/douglasswm/student-cafe-protos/principal"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
 	"order-service/database"
 	"order-service/models"
+	"order-service/pricing"
 )
 
 // OrderServer implements the gRPC OrderService
@@ -21,14 +29,28 @@ type OrderServer struct {
 	orderv1.UnimplementedOrderServiceServer
 	UserClient userv1.UserServiceClient
 	MenuClient menuv1.MenuServiceClient
+
+	// TaxRates are charged on every new order
+	TaxRates []pricing.TaxRate
+
+	// IdempotencyTTL is how long CreateOrder responses are kept for replays, 24 hours by default
+	IdempotencyTTL time.Duration
+
+	// Location is the cafe's time zone, which promotion windows are in; UTC when nil
+	Location *time.Location
+
+	clock    func() time.Time // Replaces time.Now in tests
+	watchers orderWatchers
 }
 
-// NewOrderServer creates a new gRPC order server
-func NewOrderServer(userServiceAddr, menuServiceAddr string) (*OrderServer, error) {
+// NewOrderServer creates a new gRPC order server, connecting to the user and
+// menu services with creds
+func NewOrderServer(userServiceAddr, menuServiceAddr string, creds credentials.TransportCredentials) (*OrderServer, error) {
 	// Connect to user service
 	userConn, err := grpc.NewClient(
 		userServiceAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
+		authz.AsService(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to user service: %w", err)
@@ -37,7 +59,8 @@ func NewOrderServer(userServiceAddr, menuServiceAddr string) (*OrderServer, erro
 	// Connect to menu service
 	menuConn, err := grpc.NewClient(
 		menuServiceAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
+		authz.AsService(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to menu service: %w", err)
@@ -49,8 +72,41 @@ func NewOrderServer(userServiceAddr, menuServiceAddr string) (*OrderServer, erro
 	}, nil
 }
 
-// CreateOrder creates a new order
+// CreateOrder creates a new order. Calls made for an authenticated caller place
+// the order for them, whatever user_id the request names. Calls carrying an
+// idempotency-key metadata value are deduplicated: retrying with the same key
+// returns the original response, and reusing the key for a different order is
+// rejected.
 func (s *OrderServer) CreateOrder(ctx context.Context, req *orderv1.CreateOrderRequest) (*orderv1.CreateOrderResponse, error) {
+	if caller, ok := principal.FromIncomingContext(ctx); ok {
+		req.UserId = caller.UserID
+	}
+
+	key := idempotencyKeyFrom(ctx)
+	if key == "" {
+		return s.createOrder(ctx, req)
+	}
+
+	claim, replay, err := s.claimIdempotencyKey(key, req)
+	if err != nil {
+		return nil, err
+	}
+	if replay != nil {
+		return replay, nil
+	}
+
+	resp, err := s.createOrder(ctx, req)
+	if err != nil {
+		releaseIdempotencyKey(claim)
+		return nil, err
+	}
+
+	completeIdempotencyKey(claim, resp)
+	return resp, nil
+}
+
+// createOrder validates and places an order
+func (s *OrderServer) createOrder(ctx context.Context, req *orderv1.CreateOrderRequest) (*orderv1.CreateOrderResponse, error) {
 	// Validate user exists via gRPC
 	_, err := s.UserClient.GetUser(ctx, &userv1.GetUserRequest{Id: req.UserId})
 	if err != nil {
@@ -60,27 +116,83 @@ func (s *OrderServer) CreateOrder(ctx context.Context, req *orderv1.CreateOrderR
 	// Create order
 	order := models.Order{
 		UserID: uint(req.UserId),
-		Status: "pending",
+		Status: models.StatusPending,
+	}
+
+	for _, item := range req.Items {
+		if item.Quantity <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "quantity for menu item %d must be positive", item.MenuItemId)
+		}
+		if item.Quantity > maxLineQuantity {
+			return nil, status.Errorf(codes.InvalidArgument, "quantity for menu item %d must be at most %d", item.MenuItemId, maxLineQuantity)
+		}
+		if utf8.RuneCountInString(item.Note) > maxNoteLength {
+			return nil, status.Errorf(codes.InvalidArgument, "note for menu item %d must be at most %d characters", item.MenuItemId, maxNoteLength)
+		}
 	}
 
-	// Validate menu items and snapshot prices via gRPC
+	// Validate all menu items in one round trip and snapshot their prices
+	menuItems, err := s.lookupMenuItems(ctx, req.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	// Items outside their availability, e.g. breakfast in the evening, cannot be ordered
 	for _, item := range req.Items {
-		menuItemResp, err := s.MenuClient.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: item.MenuItemId})
+		if menuItem := menuItems[item.MenuItemId]; menuItem.Unavailable {
+			return nil, status.Errorf(codes.FailedPrecondition, "menu item %d (%s) is not available at this time", menuItem.Id, menuItem.Name)
+		}
+	}
+
+	currency, err := orderCurrency(menuItems)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]*menuv1.StockLine, len(req.Items))
+	priced := make([]pricing.Line, len(req.Items))
+	categories := make([]uint, len(req.Items))
+	for i, item := range req.Items {
+		menuItem := menuItems[item.MenuItemId]
+		modifiers, err := selectModifiers(menuItem, item.ModifierIds, currency)
 		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "menu item %d not found: %v", item.MenuItemId, err)
+			return nil, err
 		}
 
 		orderItem := models.OrderItem{
 			MenuItemID: uint(item.MenuItemId),
 			Quantity:   int(item.Quantity),
-			Price:      menuItemResp.MenuItem.Price,
+			Price:      moneyFromProto(menuItem.Price),
+			Modifiers:  modifiers,
+			Note:       item.Note,
 		}
+
+		// Modifiers are charged on every unit of the line
+		unitPrice := orderItem.Price.MinorUnits
+		for _, modifier := range modifiers {
+			unitPrice += modifier.PriceDelta.MinorUnits
+		}
+		if unitPrice < 0 {
+			return nil, status.Errorf(codes.FailedPrecondition, "menu item %d costs less than nothing with the chosen modifiers", item.MenuItemId)
+		}
+
 		order.OrderItems = append(order.OrderItems, orderItem)
+		lines[i] = &menuv1.StockLine{MenuItemId: item.MenuItemId, Quantity: item.Quantity}
+		priced[i] = pricing.Line{UnitPrice: unitPrice, Quantity: orderItem.Quantity}
+		categories[i] = uint(menuItem.CategoryId)
 	}
 
-	// Save order to database
-	if err := database.DB.Create(&order).Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create order: %v", err)
+	discount, err := s.applyPromotions(&order, priced, categories, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Work out the totals once, here, so every client shows the same amounts
+	applyTotals(&order, currency, pricing.Compute(priced, discount, s.TaxRates))
+
+	// Reserve stock, save the order and commit the stock as one saga
+	if err := s.placeOrder(ctx, &order, lines); err != nil {
+		return nil, err
 	}
 
 	return &orderv1.CreateOrderResponse{
@@ -88,35 +200,275 @@ func (s *OrderServer) CreateOrder(ctx context.Context, req *orderv1.CreateOrderR
 	}, nil
 }
 
-// GetOrders retrieves all orders
+// orderCurrency returns the currency the menu items are priced in. An order
+// is charged in a single currency, so items priced in different ones are rejected.
+func orderCurrency(menuItems map[uint32]*menuv1.MenuItem) (string, error) {
+	currency := ""
+	for _, item := range menuItems {
+		if item.Price == nil || item.Price.CurrencyCode == "" {
+			return "", status.Errorf(codes.FailedPrecondition, "menu item %d has no price", item.Id)
+		}
+		if currency != "" && item.Price.CurrencyCode != currency {
+			return "", status.Errorf(codes.FailedPrecondition, "menu items are priced in different currencies (%s and %s)", currency, item.Price.CurrencyCode)
+		}
+		currency = item.Price.CurrencyCode
+	}
+	return currency, nil
+}
+
+// applyTotals stores computed totals, in currency, on an order and its items
+func applyTotals(order *models.Order, currency string, totals pricing.Totals) {
+	amount := func(minorUnits int64) models.Money {
+		return models.Money{MinorUnits: minorUnits, CurrencyCode: currency}
+	}
+
+	for i := range order.OrderItems {
+		order.OrderItems[i].LineTotal = amount(totals.LineTotals[i])
+	}
+
+	order.Subtotal = amount(totals.Subtotal)
+	order.Discount = amount(totals.Discount)
+	order.Tax = amount(totals.Tax)
+	order.Total = amount(totals.Total)
+
+	order.Taxes = make([]models.OrderTax, len(totals.Taxes))
+	for i, tax := range totals.Taxes {
+		order.Taxes[i] = models.OrderTax{Name: tax.Name, Rate: tax.Rate, Amount: amount(tax.Amount)}
+	}
+}
+
+// newReservationID returns a random ID for a stock reservation
+func newReservationID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// lookupMenuItems fetches every menu item referenced by an order with a single
+// BatchGetMenuItems call and fails if any of them does not exist
+func (s *OrderServer) lookupMenuItems(ctx context.Context, items []*orderv1.OrderItemRequest) (map[uint32]*menuv1.MenuItem, error) {
+	ids := make([]uint32, len(items))
+	for i, item := range items {
+		ids[i] = item.MenuItemId
+	}
+
+	resp, err := s.MenuClient.BatchGetMenuItems(ctx, &menuv1.BatchGetMenuItemsRequest{Ids: ids})
+	if err != nil {
+		return nil, menuLookupError(err)
+	}
+
+	switch len(resp.MissingIds) {
+	case 0:
+	case 1:
+		return nil, status.Errorf(codes.InvalidArgument, "menu item %d not found", resp.MissingIds[0])
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "menu items %v not found", resp.MissingIds)
+	}
+
+	byID := make(map[uint32]*menuv1.MenuItem, len(resp.MenuItems))
+	for _, item := range resp.MenuItems {
+		byID[item.Id] = item
+	}
+	return byID, nil
+}
+
+// menuLookupError maps a BatchGetMenuItems failure to the error returned by
+// CreateOrder. Problems with the request are the caller's to fix, so they are
+// passed through; anything else means the Menu Service could not be reached.
+func menuLookupError(err error) error {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound:
+		return err
+	default:
+		return status.Errorf(codes.Unavailable, "failed to look up menu items: %v", err)
+	}
+}
+
+// GetOrders lists orders matching the request filters, one page at a time.
+// Authenticated callers other than staff only see their own orders.
 func (s *OrderServer) GetOrders(ctx context.Context, req *orderv1.GetOrdersRequest) (*orderv1.GetOrdersResponse, error) {
+	if caller, ok := principal.FromIncomingContext(ctx); ok && !caller.IsStaff() {
+		req.UserId = caller.UserID
+	}
+
+	sort, err := parseOrderSort(req.OrderBy)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "page_size must not be negative")
+	}
+	if pageSize == 0 {
+		pageSize = defaultOrdersPageSize
+	}
+	if pageSize > maxOrdersPageSize {
+		pageSize = maxOrdersPageSize
+	}
+
+	query := database.DB.Model(&models.Order{})
+
+	if req.UserId != 0 {
+		query = query.Where("user_id = ?", req.UserId)
+	}
+	if req.Status != "" {
+		if !models.IsValidStatus(req.Status) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown order status %q", req.Status)
+		}
+		query = query.Where("status = ?", req.Status)
+	}
+	if req.CreatedAfter != "" {
+		after, err := time.Parse(time.RFC3339, req.CreatedAfter)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid created_after: %v", err)
+		}
+		query = query.Where("created_at >= ?", after)
+	}
+	if req.CreatedBefore != "" {
+		before, err := time.Parse(time.RFC3339, req.CreatedBefore)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid created_before: %v", err)
+		}
+		query = query.Where("created_at < ?", before)
+	}
+
+	fingerprint := ordersQueryFingerprint(req)
+	if req.PageToken != "" {
+		token, err := decodeOrdersPageToken(req.PageToken, fingerprint)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page_token: %v", err)
+		}
+		query = token.after(query, sort)
+	}
+
+	// Fetch one extra row to find out whether there is another page
 	var orders []models.Order
-	if err := database.DB.Preload("OrderItems").Find(&orders).Error; err != nil {
+	if err := sort.apply(query).Limit(pageSize + 1).Preload("OrderItems").Preload("OrderItems.Modifiers").Preload("StatusHistory").Preload("Taxes").Preload("Discounts").Find(&orders).Error; err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get orders: %v", err)
 	}
 
+	var nextPageToken string
+	if len(orders) > pageSize {
+		orders = orders[:pageSize]
+		nextPageToken = encodeOrdersPageToken(fingerprint, &orders[pageSize-1])
+	}
+
 	protoOrders := make([]*orderv1.Order, len(orders))
 	for i, order := range orders {
 		protoOrders[i] = modelToProto(&order)
 	}
 
 	return &orderv1.GetOrdersResponse{
-		Orders: protoOrders,
+		Orders:        protoOrders,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
-// GetOrder retrieves an order by ID
+// GetOrder retrieves an order by ID. Authenticated callers other than staff
+// may only get their own orders.
 func (s *OrderServer) GetOrder(ctx context.Context, req *orderv1.GetOrderRequest) (*orderv1.GetOrderResponse, error) {
 	var order models.Order
-	if err := database.DB.Preload("OrderItems").First(&order, req.Id).Error; err != nil {
+	if err := database.DB.Preload("OrderItems").Preload("OrderItems.Modifiers").Preload("StatusHistory").Preload("Taxes").Preload("Discounts").First(&order, req.Id).Error; err != nil {
 		return nil, status.Errorf(codes.NotFound, "order not found")
 	}
+	if err := checkOrderAccess(ctx, &order); err != nil {
+		return nil, err
+	}
 
 	return &orderv1.GetOrderResponse{
 		Order: modelToProto(&order),
 	}, nil
 }
 
+// checkOrderAccess rejects authenticated callers other than staff from
+// orders that are not theirs
+func checkOrderAccess(ctx context.Context, order *models.Order) error {
+	caller, ok := principal.FromIncomingContext(ctx)
+	if ok && !caller.IsStaff() && caller.UserID != uint32(order.UserID) {
+		return status.Errorf(codes.PermissionDenied, "order %d belongs to another user", order.ID)
+	}
+	return nil
+}
+
+// UpdateOrderStatus moves an order to a new status, enforcing the order
+// lifecycle, and records the authenticated caller as the one who moved it
+func (s *OrderServer) UpdateOrderStatus(ctx context.Context, req *orderv1.UpdateOrderStatusRequest) (*orderv1.UpdateOrderStatusResponse, error) {
+	if !models.IsValidStatus(req.Status) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown order status %q", req.Status)
+	}
+	// The transition is recorded as made by the caller, so it cannot be forged
+	caller, ok := principal.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+
+	protoOrder, err := s.changeOrderStatus(uint(req.Id), req.Status, caller.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderv1.UpdateOrderStatusResponse{
+		Order: protoOrder,
+	}, nil
+}
+
+// changeOrderStatus moves an order to a new status, records the transition and
+// notifies watchers. Illegal transitions fail with FailedPrecondition.
+func (s *OrderServer) changeOrderStatus(orderID uint, to, actor string) (*orderv1.Order, error) {
+	var order models.Order
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&order, orderID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return status.Errorf(codes.NotFound, "order not found")
+			}
+			return status.Errorf(codes.Internal, "failed to get order: %v", err)
+		}
+
+		from := order.Status
+		if !models.CanTransition(from, to) {
+			return status.Errorf(codes.FailedPrecondition, "cannot change order status from %q to %q", from, to)
+		}
+
+		// Only update if nobody else changed the status in the meantime
+		result := tx.Model(&models.Order{}).
+			Where("id = ? AND status = ?", order.ID, from).
+			Update("status", to)
+		if result.Error != nil {
+			return status.Errorf(codes.Internal, "failed to update order status: %v", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return status.Errorf(codes.FailedPrecondition, "order status changed concurrently, current status is no longer %q", from)
+		}
+
+		transition := models.OrderStatusTransition{
+			OrderID:    order.ID,
+			FromStatus: from,
+			ToStatus:   to,
+			Actor:      actor,
+		}
+		if err := tx.Create(&transition).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to record status transition: %v", err)
+		}
+		if err := enqueueOrderStatusChanged(tx, &order, &transition); err != nil {
+			return status.Errorf(codes.Internal, "failed to record order event: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := database.DB.Preload("OrderItems").Preload("OrderItems.Modifiers").Preload("StatusHistory").Preload("Taxes").Preload("Discounts").First(&order, order.ID).Error; err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to reload order: %v", err)
+	}
+
+	protoOrder := modelToProto(&order)
+	s.watchers.publish(protoOrder)
+
+	return protoOrder, nil
+}
+
 // modelToProto converts a GORM Order model to proto Order message
 func modelToProto(order *models.Order) *orderv1.Order {
 	protoItems := make([]*orderv1.OrderItem, len(order.OrderItems))
@@ -126,18 +478,86 @@ func modelToProto(order *models.Order) *orderv1.Order {
 			OrderId:    uint32(item.OrderID),
 			MenuItemId: uint32(item.MenuItemID),
 			Quantity:   int32(item.Quantity),
-			Price:      item.Price,
+			Price:      moneyToProto(item.Price),
 			CreatedAt:  item.CreatedAt.Format(time.RFC3339),
 			UpdatedAt:  item.UpdatedAt.Format(time.RFC3339),
+			LineTotal:  moneyToProto(item.LineTotal),
+			Note:       item.Note,
 		}
+		for _, modifier := range item.Modifiers {
+			protoItems[i].Modifiers = append(protoItems[i].Modifiers, &orderv1.OrderItemModifier{
+				ModifierId: uint32(modifier.ModifierID),
+				GroupName:  modifier.GroupName,
+				Name:       modifier.Name,
+				PriceDelta: moneyToProto(modifier.PriceDelta),
+			})
+		}
+	}
+
+	protoTaxes := make([]*orderv1.OrderTax, len(order.Taxes))
+	for i, tax := range order.Taxes {
+		protoTaxes[i] = &orderv1.OrderTax{
+			Name:   tax.Name,
+			Rate:   tax.Rate,
+			Amount: moneyToProto(tax.Amount),
+		}
+	}
+
+	protoDiscounts := make([]*orderv1.OrderDiscount, len(order.Discounts))
+	for i, discount := range order.Discounts {
+		protoDiscounts[i] = &orderv1.OrderDiscount{
+			PromotionId: uint32(discount.PromotionID),
+			Name:        discount.Name,
+			Kind:        discount.Kind,
+			Amount:      moneyToProto(discount.Amount),
+		}
+	}
+
+	protoHistory := make([]*orderv1.OrderStatusTransition, len(order.StatusHistory))
+	for i, transition := range order.StatusHistory {
+		protoHistory[i] = &orderv1.OrderStatusTransition{
+			FromStatus: transition.FromStatus,
+			ToStatus:   transition.ToStatus,
+			Actor:      transition.Actor,
+			CreatedAt:  transition.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	var userDetachedAt string
+	if order.UserDetachedAt != nil {
+		userDetachedAt = order.UserDetachedAt.Format(time.RFC3339)
 	}
 
 	return &orderv1.Order{
-		Id:         uint32(order.ID),
-		UserId:     uint32(order.UserID),
-		Status:     order.Status,
-		OrderItems: protoItems,
-		CreatedAt:  order.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:  order.UpdatedAt.Format(time.RFC3339),
+		Id:             uint32(order.ID),
+		UserId:         uint32(order.UserID),
+		Status:         order.Status,
+		OrderItems:     protoItems,
+		CreatedAt:      order.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      order.UpdatedAt.Format(time.RFC3339),
+		StatusHistory:  protoHistory,
+		Subtotal:       moneyToProto(order.Subtotal),
+		Discount:       moneyToProto(order.Discount),
+		Tax:            moneyToProto(order.Tax),
+		Total:          moneyToProto(order.Total),
+		Taxes:          protoTaxes,
+		Discounts:      protoDiscounts,
+		UserDetachedAt: userDetachedAt,
+	}
+}
+
+// moneyToProto converts a Money model to its proto message
+func moneyToProto(m models.Money) *commonv1.Money {
+	return &commonv1.Money{
+		CurrencyCode: m.CurrencyCode,
+		MinorUnits:   m.MinorUnits,
+	}
+}
+
+// moneyFromProto converts a proto Money message to its model
+func moneyFromProto(m *commonv1.Money) models.Money {
+	return models.Money{
+		MinorUnits:   m.GetMinorUnits(),
+		CurrencyCode: m.GetCurrencyCode(),
 	}
 }
\ No newline at end of file
@@ -4,18 +4,24 @@ import (
 	"context"
 	"order-service/database"
 	"order-service/models"
+	"order-service/pricing"
+	"strings"
 	"testing"
 	"time"
 
+	commonv1 "github.com/douglasswm/student-cafe-protos/gen/go/common/v1"
 	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
 	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
 	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/douglasswm/student-cafe-protos/principal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -49,6 +55,62 @@ func (m *MockUserServiceClient) GetUsers(ctx context.Context, req *userv1.GetUse
 	return args.Get(0).(*userv1.GetUsersResponse), args.Error(1)
 }
 
+func (m *MockUserServiceClient) Register(ctx context.Context, req *userv1.RegisterRequest, opts ...grpc.CallOption) (*userv1.RegisterResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.RegisterResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) Login(ctx context.Context, req *userv1.LoginRequest, opts ...grpc.CallOption) (*userv1.LoginResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.LoginResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) RefreshToken(ctx context.Context, req *userv1.RefreshTokenRequest, opts ...grpc.CallOption) (*userv1.RefreshTokenResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.RefreshTokenResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) GetJWKS(ctx context.Context, req *userv1.GetJWKSRequest, opts ...grpc.CallOption) (*userv1.GetJWKSResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.GetJWKSResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) UpdateUser(ctx context.Context, req *userv1.UpdateUserRequest, opts ...grpc.CallOption) (*userv1.UpdateUserResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.UpdateUserResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) DeleteUser(ctx context.Context, req *userv1.DeleteUserRequest, opts ...grpc.CallOption) (*userv1.DeleteUserResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.DeleteUserResponse), args.Error(1)
+}
+
+func (m *MockUserServiceClient) ListErasures(ctx context.Context, req *userv1.ListErasuresRequest, opts ...grpc.CallOption) (*userv1.ListErasuresResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userv1.ListErasuresResponse), args.Error(1)
+}
+
 // MockMenuServiceClient is a mock for MenuServiceClient
 type MockMenuServiceClient struct {
 	mock.Mock
@@ -62,6 +124,14 @@ func (m *MockMenuServiceClient) GetMenuItem(ctx context.Context, req *menuv1.Get
 	return args.Get(0).(*menuv1.GetMenuItemResponse), args.Error(1)
 }
 
+func (m *MockMenuServiceClient) BatchGetMenuItems(ctx context.Context, req *menuv1.BatchGetMenuItemsRequest, opts ...grpc.CallOption) (*menuv1.BatchGetMenuItemsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.BatchGetMenuItemsResponse), args.Error(1)
+}
+
 func (m *MockMenuServiceClient) GetMenu(ctx context.Context, req *menuv1.GetMenuRequest, opts ...grpc.CallOption) (*menuv1.GetMenuResponse, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
@@ -78,13 +148,140 @@ func (m *MockMenuServiceClient) CreateMenuItem(ctx context.Context, req *menuv1.
 	return args.Get(0).(*menuv1.CreateMenuItemResponse), args.Error(1)
 }
 
+func (m *MockMenuServiceClient) UpdateMenuItem(ctx context.Context, req *menuv1.UpdateMenuItemRequest, opts ...grpc.CallOption) (*menuv1.UpdateMenuItemResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.UpdateMenuItemResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) DeleteMenuItem(ctx context.Context, req *menuv1.DeleteMenuItemRequest, opts ...grpc.CallOption) (*menuv1.DeleteMenuItemResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.DeleteMenuItemResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) ImportMenuItems(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[menuv1.ImportMenuItemsRequest, menuv1.ImportMenuItemsResponse], error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(grpc.ClientStreamingClient[menuv1.ImportMenuItemsRequest, menuv1.ImportMenuItemsResponse]), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) WatchMenu(ctx context.Context, req *menuv1.WatchMenuRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[menuv1.WatchMenuResponse], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(grpc.ServerStreamingClient[menuv1.WatchMenuResponse]), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) GetPriceHistory(ctx context.Context, req *menuv1.GetPriceHistoryRequest, opts ...grpc.CallOption) (*menuv1.GetPriceHistoryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.GetPriceHistoryResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) SchedulePriceChange(ctx context.Context, req *menuv1.SchedulePriceChangeRequest, opts ...grpc.CallOption) (*menuv1.SchedulePriceChangeResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.SchedulePriceChangeResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) CreateCategory(ctx context.Context, req *menuv1.CreateCategoryRequest, opts ...grpc.CallOption) (*menuv1.CreateCategoryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.CreateCategoryResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) GetCategory(ctx context.Context, req *menuv1.GetCategoryRequest, opts ...grpc.CallOption) (*menuv1.GetCategoryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.GetCategoryResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) ListCategories(ctx context.Context, req *menuv1.ListCategoriesRequest, opts ...grpc.CallOption) (*menuv1.ListCategoriesResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.ListCategoriesResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) UpdateCategory(ctx context.Context, req *menuv1.UpdateCategoryRequest, opts ...grpc.CallOption) (*menuv1.UpdateCategoryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.UpdateCategoryResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) DeleteCategory(ctx context.Context, req *menuv1.DeleteCategoryRequest, opts ...grpc.CallOption) (*menuv1.DeleteCategoryResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.DeleteCategoryResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) ReserveStock(ctx context.Context, req *menuv1.ReserveStockRequest, opts ...grpc.CallOption) (*menuv1.ReserveStockResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.ReserveStockResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) ReleaseStock(ctx context.Context, req *menuv1.ReleaseStockRequest, opts ...grpc.CallOption) (*menuv1.ReleaseStockResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.ReleaseStockResponse), args.Error(1)
+}
+
+func (m *MockMenuServiceClient) CommitStock(ctx context.Context, req *menuv1.CommitStockRequest, opts ...grpc.CallOption) (*menuv1.CommitStockResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*menuv1.CommitStockResponse), args.Error(1)
+}
+
+// expectStockReserved sets up a successful reservation of the given menu item quantities
+func expectStockReserved(m *MockMenuServiceClient, lines map[uint32]int32) {
+	m.On("ReserveStock", mock.Anything, mock.MatchedBy(func(req *menuv1.ReserveStockRequest) bool {
+		if req.ReservationId == "" || len(req.Lines) != len(lines) {
+			return false
+		}
+		for _, line := range req.Lines {
+			if lines[line.MenuItemId] != line.Quantity {
+				return false
+			}
+		}
+		return true
+	})).Return(&menuv1.ReserveStockResponse{}, nil).Once()
+}
+
 // setupTestDB creates an in-memory SQLite database for testing
 func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
 	require.NoError(t, err, "Failed to open test database")
 
-	// Auto-migrate the Order and OrderItem models
-	err = db.AutoMigrate(&models.Order{}, &models.OrderItem{})
+	// Auto-migrate the order models
+	err = db.AutoMigrate(&models.Order{}, &models.OrderItem{}, &models.OrderItemModifier{}, &models.OrderStatusTransition{}, &models.OrderSaga{}, &models.OutboxEvent{}, &models.IdempotencyKey{}, &models.OrderTax{}, &models.OrderDiscount{}, &models.Promotion{}, &models.PromotionTarget{}, &models.PromotionWindow{})
 	require.NoError(t, err, "Failed to migrate test database")
 
 	return db
@@ -117,16 +314,19 @@ func TestCreateOrder_Success(t *testing.T) {
 			User: &userv1.User{Id: 1, Name: "Test User", Email: "test@example.com"},
 		}, nil)
 
-	// Mock menu item lookup
-	mockMenuClient.On("GetMenuItem", mock.Anything, &menuv1.GetMenuItemRequest{Id: 1}).
-		Return(&menuv1.GetMenuItemResponse{
-			MenuItem: &menuv1.MenuItem{Id: 1, Name: "Coffee", Price: 2.50},
-		}, nil)
+	// Mock menu item lookup, both items in a single batch call
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1, 2}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{
+				{Id: 1, Name: "Coffee", Price: priceProto(250)},
+				{Id: 2, Name: "Tea", Price: priceProto(200)},
+			},
+		}, nil).Once()
 
-	mockMenuClient.On("GetMenuItem", mock.Anything, &menuv1.GetMenuItemRequest{Id: 2}).
-		Return(&menuv1.GetMenuItemResponse{
-			MenuItem: &menuv1.MenuItem{Id: 2, Name: "Tea", Price: 2.00},
-		}, nil)
+	// Mock stock reservation and commit
+	expectStockReserved(mockMenuClient, map[uint32]int32{1: 2, 2: 1})
+	mockMenuClient.On("CommitStock", mock.Anything, mock.Anything).
+		Return(&menuv1.CommitStockResponse{}, nil).Once()
 
 	// Test
 	ctx := context.Background()
@@ -149,13 +349,58 @@ func TestCreateOrder_Success(t *testing.T) {
 	// Verify first item
 	assert.Equal(t, uint32(1), resp.Order.OrderItems[0].MenuItemId)
 	assert.Equal(t, int32(2), resp.Order.OrderItems[0].Quantity)
-	assert.InDelta(t, 2.50, resp.Order.OrderItems[0].Price, 0.001)
+	assert.Equal(t, int64(250), resp.Order.OrderItems[0].Price.MinorUnits)
 
 	// Verify second item
 	assert.Equal(t, uint32(2), resp.Order.OrderItems[1].MenuItemId)
 	assert.Equal(t, int32(1), resp.Order.OrderItems[1].Quantity)
-	assert.InDelta(t, 2.00, resp.Order.OrderItems[1].Price, 0.001)
+	assert.Equal(t, int64(200), resp.Order.OrderItems[1].Price.MinorUnits)
+
+	mockUserClient.AssertExpectations(t)
+	mockMenuClient.AssertExpectations(t)
+}
+
+// asCaller returns a context carrying caller as incoming gRPC metadata, as the
+// API gateway sends it
+func asCaller(caller principal.Principal) context.Context {
+	md, _ := metadata.FromOutgoingContext(principal.NewOutgoingContext(context.Background(), caller))
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func TestCreateOrder_AuthenticatedCaller(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}
+
+	// The order is placed for the caller, not the user named in the request
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 7}).
+		Return(&userv1.GetUserResponse{
+			User: &userv1.User{Id: 7, Name: "Caller", Email: "caller@example.com"},
+		}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, mock.Anything).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{{Id: 1, Name: "Coffee", Price: priceProto(250)}},
+		}, nil).Once()
+	expectStockReserved(mockMenuClient, map[uint32]int32{1: 1})
+	mockMenuClient.On("CommitStock", mock.Anything, mock.Anything).
+		Return(&menuv1.CommitStockResponse{}, nil).Once()
+
+	resp, err := server.CreateOrder(asCaller(principal.Principal{UserID: 7, Role: principal.RoleStudent}), &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 1}},
+	})
 
+	require.NoError(t, err)
+	assert.Equal(t, uint32(7), resp.Order.UserId)
 	mockUserClient.AssertExpectations(t)
 	mockMenuClient.AssertExpectations(t)
 }
@@ -198,6 +443,49 @@ func TestCreateOrder_InvalidUser(t *testing.T) {
 	mockUserClient.AssertExpectations(t)
 }
 
+func TestCreateOrder_MenuLookupErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{"bad request passes through", status.Errorf(codes.InvalidArgument, "at most 100 ids"), codes.InvalidArgument},
+		{"not found passes through", status.Errorf(codes.NotFound, "menu item not found"), codes.NotFound},
+		{"menu service down", status.Errorf(codes.Unavailable, "connection refused"), codes.Unavailable},
+		{"deadline exceeded", status.Errorf(codes.DeadlineExceeded, "timeout"), codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			db := setupTestDB(t)
+			defer teardownTestDB(t, db)
+			database.DB = db
+
+			mockUserClient := new(MockUserServiceClient)
+			mockMenuClient := new(MockMenuServiceClient)
+			server := &OrderServer{
+				UserClient: mockUserClient,
+				MenuClient: mockMenuClient,
+			}
+
+			mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+				Return(&userv1.GetUserResponse{User: &userv1.User{Id: 1}}, nil)
+			mockMenuClient.On("BatchGetMenuItems", mock.Anything, mock.Anything).
+				Return(nil, tt.err)
+
+			// Test
+			_, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+				UserId: 1,
+				Items:  []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 1}},
+			})
+
+			// Assert
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
 func TestCreateOrder_InvalidMenuItem(t *testing.T) {
 	// Setup
 	db := setupTestDB(t)
@@ -218,9 +506,9 @@ func TestCreateOrder_InvalidMenuItem(t *testing.T) {
 			User: &userv1.User{Id: 1, Name: "Test User"},
 		}, nil)
 
-	// Mock menu item lookup failure
-	mockMenuClient.On("GetMenuItem", mock.Anything, &menuv1.GetMenuItemRequest{Id: 999}).
-		Return(nil, status.Errorf(codes.NotFound, "menu item not found"))
+	// Mock menu item lookup reporting the item as missing
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{999}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{MissingIds: []uint32{999}}, nil)
 
 	// Test
 	ctx := context.Background()
@@ -243,7 +531,7 @@ func TestCreateOrder_InvalidMenuItem(t *testing.T) {
 	mockMenuClient.AssertExpectations(t)
 }
 
-func TestGetOrder(t *testing.T) {
+func TestCreateOrder_SeveralMissingMenuItems(t *testing.T) {
 	// Setup
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
@@ -257,61 +545,44 @@ func TestGetOrder(t *testing.T) {
 		MenuClient: mockMenuClient,
 	}
 
-	// Create a test order
-	testOrder := models.Order{
-		UserID: 1,
-		Status: "pending",
-		OrderItems: []models.OrderItem{
-			{MenuItemID: 1, Quantity: 2, Price: 2.50},
-		},
-	}
-	err := db.Create(&testOrder).Error
-	require.NoError(t, err)
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{
+			User: &userv1.User{Id: 1, Name: "Test User"},
+		}, nil)
 
-	tests := []struct {
-		name        string
-		orderID     uint32
-		wantErr     bool
-		expectedErr codes.Code
-	}{
-		{
-			name:    "get existing order",
-			orderID: uint32(testOrder.ID),
-			wantErr: false,
-		},
-		{
-			name:        "get non-existent order",
-			orderID:     9999,
-			wantErr:     true,
-			expectedErr: codes.NotFound,
+	// Every line is validated in the same call, so all missing items are reported together
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1, 7, 8}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems:  []*menuv1.MenuItem{{Id: 1, Name: "Coffee", Price: priceProto(250)}},
+			MissingIds: []uint32{7, 8},
+		}, nil).Once()
+
+	resp, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items: []*orderv1.OrderItemRequest{
+			{MenuItemId: 1, Quantity: 1},
+			{MenuItemId: 7, Quantity: 1},
+			{MenuItemId: 8, Quantity: 1},
 		},
-	}
+	})
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
-			resp, err := server.GetOrder(ctx, &orderv1.GetOrderRequest{
-				Id: tt.orderID,
-			})
+	require.Error(t, err)
+	assert.Nil(t, resp)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Contains(t, st.Message(), "menu items [7 8] not found")
 
-			if tt.wantErr {
-				require.Error(t, err)
-				st, ok := status.FromError(err)
-				require.True(t, ok)
-				assert.Equal(t, tt.expectedErr, st.Code())
-			} else {
-				require.NoError(t, err)
-				require.NotNil(t, resp)
-				assert.Equal(t, tt.orderID, resp.Order.Id)
-				assert.Equal(t, uint32(testOrder.UserID), resp.Order.UserId)
-				assert.Equal(t, testOrder.Status, resp.Order.Status)
-				assert.Len(t, resp.Order.OrderItems, 1)
-			}
-		})
-	}
+	// Nothing was saved
+	var count int64
+	db.Model(&models.Order{}).Count(&count)
+	assert.Zero(t, count)
+
+	mockUserClient.AssertExpectations(t)
+	mockMenuClient.AssertExpectations(t)
 }
 
-func TestGetOrders(t *testing.T) {
+func TestCreateOrder_QuantityOutOfRange(t *testing.T) {
 	// Setup
 	db := setupTestDB(t)
 	defer teardownTestDB(t, db)
@@ -325,54 +596,1235 @@ func TestGetOrders(t *testing.T) {
 		MenuClient: mockMenuClient,
 	}
 
-	// Test empty orders
-	t.Run("empty orders", func(t *testing.T) {
-		ctx := context.Background()
-		resp, err := server.GetOrders(ctx, &orderv1.GetOrdersRequest{})
-
-		require.NoError(t, err)
-		require.NotNil(t, resp)
-		assert.Empty(t, resp.Orders)
-	})
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{
+			User: &userv1.User{Id: 1, Name: "Test User"},
+		}, nil)
+
+	tests := []struct {
+		name     string
+		quantity int32
+		want     string
+	}{
+		{"zero", 0, "must be positive"},
+		{"negative", -1, "must be positive"},
+		{"too many", maxLineQuantity + 1, "must be at most 100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+				UserId: 1,
+				Items:  []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: tt.quantity}},
+			})
+			require.Error(t, err)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Contains(t, status.Convert(err).Message(), tt.want)
+		})
+	}
+
+	// Rejected before the menu is consulted or stock reserved
+	mockMenuClient.AssertNotCalled(t, "BatchGetMenuItems", mock.Anything, mock.Anything)
+	mockMenuClient.AssertNotCalled(t, "ReserveStock", mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_UnavailableItem(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{
+			User: &userv1.User{Id: 1, Name: "Test User"},
+		}, nil)
+
+	// The menu service flags items outside their availability windows
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1, 2}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{
+				{Id: 1, Name: "Coffee", Price: priceProto(250)},
+				{Id: 2, Name: "Pancakes", Price: priceProto(450), Unavailable: true},
+			},
+		}, nil).Once()
+
+	resp, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items: []*orderv1.OrderItemRequest{
+			{MenuItemId: 1, Quantity: 1},
+			{MenuItemId: 2, Quantity: 1},
+		},
+	})
+
+	require.Error(t, err)
+	assert.Nil(t, resp)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	assert.Contains(t, st.Message(), "menu item 2 (Pancakes) is not available")
+
+	// No stock was reserved and nothing was saved
+	mockMenuClient.AssertNotCalled(t, "ReserveStock", mock.Anything, mock.Anything)
+	var count int64
+	db.Model(&models.Order{}).Count(&count)
+	assert.Zero(t, count)
+
+	mockUserClient.AssertExpectations(t)
+	mockMenuClient.AssertExpectations(t)
+}
+
+func TestCreateOrder_OutOfStock(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{
+			User: &userv1.User{Id: 1, Name: "Test User"},
+		}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{{Id: 1, Name: "Muffin", Price: priceProto(220)}},
+		}, nil)
+	mockMenuClient.On("ReserveStock", mock.Anything, mock.Anything).
+		Return(nil, status.Errorf(codes.ResourceExhausted, "menu item 1 is out of stock"))
+	mockMenuClient.On("ReleaseStock", mock.Anything, mock.Anything).
+		Return(&menuv1.ReleaseStockResponse{}, nil)
+
+	// Test
+	resp, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 3}},
+	})
+
+	// Assert
+	require.Error(t, err)
+	assert.Nil(t, resp)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	assert.Contains(t, st.Message(), "out of stock")
+
+	// No order is saved and nothing is committed
+	var count int64
+	db.Model(&models.Order{}).Count(&count)
+	assert.Zero(t, count)
+	db.Model(&models.OutboxEvent{}).Count(&count)
+	assert.Zero(t, count)
+	mockMenuClient.AssertNotCalled(t, "CommitStock", mock.Anything, mock.Anything)
+
+	// The saga records why it was compensated
+	var saga models.OrderSaga
+	require.NoError(t, db.First(&saga).Error)
+	assert.Equal(t, models.SagaCompensated, saga.State)
+	assert.Contains(t, saga.LastError, "out of stock")
+}
+
+func TestCreateOrder_ReleasesStockWhenSaveFails(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{
+			User: &userv1.User{Id: 1, Name: "Test User"},
+		}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{{Id: 1, Name: "Muffin", Price: priceProto(220)}},
+		}, nil)
+	expectStockReserved(mockMenuClient, map[uint32]int32{1: 1})
+
+	var reservationID string
+	mockMenuClient.On("ReleaseStock", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			reservationID = args.Get(1).(*menuv1.ReleaseStockRequest).ReservationId
+		}).
+		Return(&menuv1.ReleaseStockResponse{}, nil).Once()
+
+	// Make saving the order fail
+	require.NoError(t, db.Migrator().DropTable(&models.OrderItem{}))
+
+	// Test
+	_, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 1}},
+	})
+
+	// Assert the reservation is handed back
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.NotEmpty(t, reservationID)
+	mockMenuClient.AssertExpectations(t)
+	mockMenuClient.AssertNotCalled(t, "CommitStock", mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_CommitFailureCancelsOrder(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{
+			User: &userv1.User{Id: 1, Name: "Test User"},
+		}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{{Id: 1, Name: "Muffin", Price: priceProto(220)}},
+		}, nil)
+	expectStockReserved(mockMenuClient, map[uint32]int32{1: 1})
+	mockMenuClient.On("CommitStock", mock.Anything, mock.Anything).
+		Return(nil, status.Errorf(codes.Unavailable, "menu service down"))
+	mockMenuClient.On("ReleaseStock", mock.Anything, mock.Anything).
+		Return(&menuv1.ReleaseStockResponse{}, nil).Once()
+
+	// Test
+	_, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 1}},
+	})
+
+	// Assert
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.Unavailable, st.Code())
+
+	// The saved order is cancelled by the saga
+	var order models.Order
+	require.NoError(t, db.Preload("StatusHistory").First(&order).Error)
+	assert.Equal(t, models.StatusCancelled, order.Status)
+	require.Len(t, order.StatusHistory, 1)
+	assert.Equal(t, sagaActor, order.StatusHistory[0].Actor)
+
+	var saga models.OrderSaga
+	require.NoError(t, db.First(&saga).Error)
+	assert.Equal(t, models.SagaCompensated, saga.State)
+	assert.Equal(t, order.ID, saga.OrderID)
+	assert.Contains(t, saga.LastError, "menu service down")
+
+	mockMenuClient.AssertExpectations(t)
+}
+
+func TestCreateOrder_CommitReplyLost(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{
+			User: &userv1.User{Id: 1, Name: "Test User"},
+		}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{{Id: 1, Name: "Muffin", Price: priceProto(220)}},
+		}, nil)
+	expectStockReserved(mockMenuClient, map[uint32]int32{1: 1})
+
+	// The commit is applied but its reply never arrives
+	mockMenuClient.On("CommitStock", mock.Anything, mock.Anything).
+		Return(nil, status.Errorf(codes.DeadlineExceeded, "deadline exceeded"))
+	mockMenuClient.On("ReleaseStock", mock.Anything, mock.Anything).
+		Return(nil, status.Errorf(codes.FailedPrecondition, "reservation was already committed"))
+
+	// Test
+	resp, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 1}},
+	})
+
+	// Assert the order stands
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusPending, resp.Order.Status)
+
+	var saga models.OrderSaga
+	require.NoError(t, db.First(&saga).Error)
+	assert.Equal(t, models.SagaCompleted, saga.State)
+}
+
+// newIdempotencyTestServer returns a server whose dependencies accept any order for menu item 1
+func newIdempotencyTestServer(t *testing.T) (*OrderServer, *MockMenuServiceClient) {
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	mockUserClient.On("GetUser", mock.Anything, mock.Anything).
+		Return(&userv1.GetUserResponse{
+			User: &userv1.User{Id: 1, Name: "Test User"},
+		}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, mock.Anything).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{{Id: 1, Name: "Coffee", Price: priceProto(250)}},
+		}, nil)
+	mockMenuClient.On("CommitStock", mock.Anything, mock.Anything).
+		Return(&menuv1.CommitStockResponse{}, nil)
+
+	return &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}, mockMenuClient
+}
+
+// withIdempotencyKey returns a context carrying key as incoming gRPC metadata
+func withIdempotencyKey(key string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyMetadata, key))
+}
+
+func TestCreateOrder_IdempotencyKey(t *testing.T) {
+	coffee := func(quantity int32) *orderv1.CreateOrderRequest {
+		return &orderv1.CreateOrderRequest{
+			UserId: 1,
+			Items:  []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: quantity}},
+		}
+	}
+	countOrders := func(db *gorm.DB) int64 {
+		var count int64
+		db.Model(&models.Order{}).Count(&count)
+		return count
+	}
+
+	t.Run("replay returns the original order", func(t *testing.T) {
+		db := setupTestDB(t)
+		defer teardownTestDB(t, db)
+		database.DB = db
+
+		server, mockMenuClient := newIdempotencyTestServer(t)
+		mockMenuClient.On("ReserveStock", mock.Anything, mock.Anything).
+			Return(&menuv1.ReserveStockResponse{}, nil).Once()
+
+		first, err := server.CreateOrder(withIdempotencyKey("retry-1"), coffee(2))
+		require.NoError(t, err)
+		second, err := server.CreateOrder(withIdempotencyKey("retry-1"), coffee(2))
+		require.NoError(t, err)
+
+		assert.Equal(t, first.Order.Id, second.Order.Id)
+		assert.Equal(t, first.Order.CreatedAt, second.Order.CreatedAt)
+		assert.Equal(t, int64(1), countOrders(db))
+		mockMenuClient.AssertNumberOfCalls(t, "ReserveStock", 1)
+
+		var key models.IdempotencyKey
+		require.NoError(t, db.First(&key).Error)
+		assert.Equal(t, uint(first.Order.Id), key.OrderID)
+	})
+
+	t.Run("different request under the same key is rejected", func(t *testing.T) {
+		db := setupTestDB(t)
+		defer teardownTestDB(t, db)
+		database.DB = db
+
+		server, mockMenuClient := newIdempotencyTestServer(t)
+		mockMenuClient.On("ReserveStock", mock.Anything, mock.Anything).
+			Return(&menuv1.ReserveStockResponse{}, nil)
+
+		_, err := server.CreateOrder(withIdempotencyKey("retry-2"), coffee(2))
+		require.NoError(t, err)
+		_, err = server.CreateOrder(withIdempotencyKey("retry-2"), coffee(3))
+
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		assert.Equal(t, int64(1), countOrders(db))
+	})
+
+	t.Run("failed request frees the key", func(t *testing.T) {
+		db := setupTestDB(t)
+		defer teardownTestDB(t, db)
+		database.DB = db
+
+		server, mockMenuClient := newIdempotencyTestServer(t)
+		mockMenuClient.On("ReserveStock", mock.Anything, mock.Anything).
+			Return(nil, status.Errorf(codes.Unavailable, "menu service down")).Once()
+		mockMenuClient.On("ReleaseStock", mock.Anything, mock.Anything).
+			Return(&menuv1.ReleaseStockResponse{}, nil)
+		mockMenuClient.On("ReserveStock", mock.Anything, mock.Anything).
+			Return(&menuv1.ReserveStockResponse{}, nil).Once()
+
+		_, err := server.CreateOrder(withIdempotencyKey("retry-3"), coffee(1))
+		require.Error(t, err)
+
+		resp, err := server.CreateOrder(withIdempotencyKey("retry-3"), coffee(1))
+		require.NoError(t, err)
+		assert.NotZero(t, resp.Order.Id)
+	})
+
+	t.Run("request in progress", func(t *testing.T) {
+		db := setupTestDB(t)
+		defer teardownTestDB(t, db)
+		database.DB = db
+
+		server, _ := newIdempotencyTestServer(t)
+		hash, err := createOrderHash(coffee(1))
+		require.NoError(t, err)
+		require.NoError(t, db.Create(&models.IdempotencyKey{
+			Key:         "retry-4",
+			RequestHash: hash,
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}).Error)
+
+		_, err = server.CreateOrder(withIdempotencyKey("retry-4"), coffee(1))
+
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.Aborted, st.Code())
+		assert.Zero(t, countOrders(db))
+	})
+
+	t.Run("expired key places a new order", func(t *testing.T) {
+		db := setupTestDB(t)
+		defer teardownTestDB(t, db)
+		database.DB = db
+
+		server, mockMenuClient := newIdempotencyTestServer(t)
+		mockMenuClient.On("ReserveStock", mock.Anything, mock.Anything).
+			Return(&menuv1.ReserveStockResponse{}, nil)
+
+		first, err := server.CreateOrder(withIdempotencyKey("retry-5"), coffee(1))
+		require.NoError(t, err)
+		require.NoError(t, db.Model(&models.IdempotencyKey{}).
+			Where("order_id = ?", first.Order.Id).
+			Update("expires_at", time.Now().Add(-time.Minute)).Error)
+
+		second, err := server.CreateOrder(withIdempotencyKey("retry-5"), coffee(1))
+		require.NoError(t, err)
+		assert.NotEqual(t, first.Order.Id, second.Order.Id)
+		assert.Equal(t, int64(2), countOrders(db))
+	})
+
+	t.Run("expired keys are purged", func(t *testing.T) {
+		db := setupTestDB(t)
+		defer teardownTestDB(t, db)
+		database.DB = db
+
+		server := &OrderServer{}
+		require.NoError(t, db.Create(&[]models.IdempotencyKey{
+			{Key: "old", ExpiresAt: time.Now().Add(-time.Minute)},
+			{Key: "fresh", ExpiresAt: time.Now().Add(time.Hour)},
+		}).Error)
+
+		purged, err := server.PurgeExpiredIdempotencyKeys()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+
+		var keys []models.IdempotencyKey
+		require.NoError(t, db.Unscoped().Find(&keys).Error)
+		require.Len(t, keys, 1)
+		assert.Equal(t, "fresh", keys[0].Key)
+	})
+}
+
+func TestRecoverSagas(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	mockMenuClient := new(MockMenuServiceClient)
+	server := &OrderServer{MenuClient: mockMenuClient}
+
+	placedOrder := models.Order{UserID: 1, Status: models.StatusPending}
+	require.NoError(t, db.Create(&placedOrder).Error)
+	abandonedOrder := models.Order{UserID: 1, Status: models.StatusPending}
+	require.NoError(t, db.Create(&abandonedOrder).Error)
+
+	sagas := []models.OrderSaga{
+		{ReservationID: "started", State: models.SagaStarted},
+		{ReservationID: "reserved", State: models.SagaStockReserved},
+		{ReservationID: "placed", State: models.SagaOrderCreated, OrderID: placedOrder.ID},
+		{ReservationID: "abandoned", State: models.SagaCompensating, OrderID: abandonedOrder.ID},
+		{ReservationID: "menu-down", State: models.SagaStockReserved},
+		{ReservationID: "done", State: models.SagaCompleted},
+		{ReservationID: "in-flight", State: models.SagaStarted},
+	}
+	require.NoError(t, db.Create(&sagas).Error)
+
+	// Everything but the last saga was interrupted a while ago
+	require.NoError(t, db.Model(&models.OrderSaga{}).
+		Where("reservation_id <> ?", "in-flight").
+		UpdateColumn("updated_at", time.Now().Add(-time.Hour)).Error)
+
+	released := func(id string) interface{} {
+		return mock.MatchedBy(func(req *menuv1.ReleaseStockRequest) bool { return req.ReservationId == id })
+	}
+	for _, id := range []string{"started", "reserved", "abandoned"} {
+		mockMenuClient.On("ReleaseStock", mock.Anything, released(id)).
+			Return(&menuv1.ReleaseStockResponse{}, nil).Once()
+	}
+	mockMenuClient.On("ReleaseStock", mock.Anything, released("menu-down")).
+		Return(nil, status.Errorf(codes.Unavailable, "menu service down"))
+	mockMenuClient.On("CommitStock", mock.Anything, &menuv1.CommitStockRequest{ReservationId: "placed"}).
+		Return(&menuv1.CommitStockResponse{}, nil).Once()
+
+	// Test
+	err := server.RecoverSagas(context.Background(), time.Minute)
+
+	// Assert
+	require.Error(t, err, "the saga that could not be compensated is reported")
+	assert.Contains(t, err.Error(), "1 of 5")
+
+	stateOf := func(reservationID string) string {
+		var saga models.OrderSaga
+		require.NoError(t, db.Where("reservation_id = ?", reservationID).First(&saga).Error)
+		return saga.State
+	}
+	assert.Equal(t, models.SagaCompensated, stateOf("started"))
+	assert.Equal(t, models.SagaCompensated, stateOf("reserved"))
+	assert.Equal(t, models.SagaCompleted, stateOf("placed"))
+	assert.Equal(t, models.SagaCompensated, stateOf("abandoned"))
+	assert.Equal(t, models.SagaCompensating, stateOf("menu-down"), "left for the next run")
+	assert.Equal(t, models.SagaCompleted, stateOf("done"))
+	assert.Equal(t, models.SagaStarted, stateOf("in-flight"), "recent sagas are left alone")
+
+	var placed, abandoned models.Order
+	require.NoError(t, db.First(&placed, placedOrder.ID).Error)
+	assert.Equal(t, models.StatusPending, placed.Status)
+	require.NoError(t, db.First(&abandoned, abandonedOrder.ID).Error)
+	assert.Equal(t, models.StatusCancelled, abandoned.Status)
+
+	mockMenuClient.AssertExpectations(t)
+}
+
+func TestGetOrder(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}
+
+	// Create a test order
+	testOrder := models.Order{
+		UserID: 1,
+		Status: "pending",
+		OrderItems: []models.OrderItem{
+			{MenuItemID: 1, Quantity: 2, Price: price(250)},
+		},
+	}
+	err := db.Create(&testOrder).Error
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		orderID     uint32
+		wantErr     bool
+		expectedErr codes.Code
+	}{
+		{
+			name:    "get existing order",
+			ctx:     context.Background(),
+			orderID: uint32(testOrder.ID),
+			wantErr: false,
+		},
+		{
+			name:        "get non-existent order",
+			ctx:         context.Background(),
+			orderID:     9999,
+			wantErr:     true,
+			expectedErr: codes.NotFound,
+		},
+		{
+			name:    "student gets their own order",
+			ctx:     asCaller(principal.Principal{UserID: 1, Role: principal.RoleStudent}),
+			orderID: uint32(testOrder.ID),
+			wantErr: false,
+		},
+		{
+			name:        "student gets another user's order",
+			ctx:         asCaller(principal.Principal{UserID: 2, Role: principal.RoleStudent}),
+			orderID:     uint32(testOrder.ID),
+			wantErr:     true,
+			expectedErr: codes.PermissionDenied,
+		},
+		{
+			name:    "staff get any order",
+			ctx:     asCaller(principal.Principal{UserID: 3, Role: principal.RoleStaff}),
+			orderID: uint32(testOrder.ID),
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.GetOrder(tt.ctx, &orderv1.GetOrderRequest{
+				Id: tt.orderID,
+			})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedErr, st.Code())
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+				assert.Equal(t, tt.orderID, resp.Order.Id)
+				assert.Equal(t, uint32(testOrder.UserID), resp.Order.UserId)
+				assert.Equal(t, testOrder.Status, resp.Order.Status)
+				assert.Len(t, resp.Order.OrderItems, 1)
+			}
+		})
+	}
+}
+
+func TestUpdateOrderStatus(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	server := &OrderServer{}
+
+	// Create a test order
+	testOrder := models.Order{
+		UserID: 1,
+		Status: models.StatusPending,
+		OrderItems: []models.OrderItem{
+			{MenuItemID: 1, Quantity: 1, Price: price(250)},
+		},
+	}
+	err := db.Create(&testOrder).Error
+	require.NoError(t, err)
+
+	barista := principal.Principal{UserID: 5, Role: principal.RoleStaff}
+	counter := principal.Principal{UserID: 6, Role: principal.RoleOwner}
+
+	// Steps run in order against the same order
+	tests := []struct {
+		name        string
+		orderID     uint32
+		status      string
+		caller      principal.Principal
+		wantErr     bool
+		expectedErr codes.Code
+	}{
+		{
+			name:        "unknown status",
+			orderID:     uint32(testOrder.ID),
+			status:      "eaten",
+			caller:      barista,
+			wantErr:     true,
+			expectedErr: codes.InvalidArgument,
+		},
+		{
+			name:        "anonymous caller",
+			orderID:     uint32(testOrder.ID),
+			status:      models.StatusPreparing,
+			wantErr:     true,
+			expectedErr: codes.Unauthenticated,
+		},
+		{
+			name:        "non-existent order",
+			orderID:     9999,
+			status:      models.StatusPreparing,
+			caller:      barista,
+			wantErr:     true,
+			expectedErr: codes.NotFound,
+		},
+		{
+			name:        "skip straight to collected",
+			orderID:     uint32(testOrder.ID),
+			status:      models.StatusCollected,
+			caller:      barista,
+			wantErr:     true,
+			expectedErr: codes.FailedPrecondition,
+		},
+		{
+			name:    "pending to preparing",
+			orderID: uint32(testOrder.ID),
+			status:  models.StatusPreparing,
+			caller:  barista,
+		},
+		{
+			name:    "preparing to ready",
+			orderID: uint32(testOrder.ID),
+			status:  models.StatusReady,
+			caller:  barista,
+		},
+		{
+			name:        "ready back to pending",
+			orderID:     uint32(testOrder.ID),
+			status:      models.StatusPending,
+			caller:      barista,
+			wantErr:     true,
+			expectedErr: codes.FailedPrecondition,
+		},
+		{
+			name:    "ready to collected",
+			orderID: uint32(testOrder.ID),
+			status:  models.StatusCollected,
+			caller:  counter,
+		},
+		{
+			name:        "cancel collected order",
+			orderID:     uint32(testOrder.ID),
+			status:      models.StatusCancelled,
+			caller:      counter,
+			wantErr:     true,
+			expectedErr: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.UpdateOrderStatus(asCaller(tt.caller), &orderv1.UpdateOrderStatusRequest{
+				Id:     tt.orderID,
+				Status: tt.status,
+			})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedErr, st.Code())
+			} else {
+				require.NoError(t, err)
+				require.NotNil(t, resp)
+				assert.Equal(t, tt.status, resp.Order.Status)
+				assert.Len(t, resp.Order.OrderItems, 1)
+			}
+		})
+	}
+
+	// Verify every successful transition was recorded with the caller who made it
+	resp, err := server.GetOrder(context.Background(), &orderv1.GetOrderRequest{Id: uint32(testOrder.ID)})
+	require.NoError(t, err)
+	require.Len(t, resp.Order.StatusHistory, 3)
+	assert.Equal(t, models.StatusPending, resp.Order.StatusHistory[0].FromStatus)
+	assert.Equal(t, models.StatusPreparing, resp.Order.StatusHistory[0].ToStatus)
+	assert.Equal(t, "staff 5", resp.Order.StatusHistory[0].Actor)
+	assert.Equal(t, models.StatusCollected, resp.Order.StatusHistory[2].ToStatus)
+	assert.Equal(t, "owner 6", resp.Order.StatusHistory[2].Actor)
+	assert.NotEmpty(t, resp.Order.StatusHistory[2].CreatedAt)
+}
+
+func TestOrderEvents(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{
+			User: &userv1.User{Id: 1, Name: "Test User"},
+		}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{{Id: 1, Name: "Coffee", Price: priceProto(250)}},
+		}, nil)
+	expectStockReserved(mockMenuClient, map[uint32]int32{1: 2})
+	mockMenuClient.On("CommitStock", mock.Anything, mock.Anything).
+		Return(&menuv1.CommitStockResponse{}, nil)
+
+	// Test
+	ctx := context.Background()
+	created, err := server.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: 1, Quantity: 2}},
+	})
+	require.NoError(t, err)
+
+	barista := asCaller(principal.Principal{UserID: 5, Role: principal.RoleStaff})
+	_, err = server.UpdateOrderStatus(barista, &orderv1.UpdateOrderStatusRequest{
+		Id:     created.Order.Id,
+		Status: models.StatusPreparing,
+	})
+	require.NoError(t, err)
+
+	// A failed status change records no event
+	_, err = server.UpdateOrderStatus(barista, &orderv1.UpdateOrderStatusRequest{
+		Id:     created.Order.Id,
+		Status: models.StatusCollected,
+	})
+	require.Error(t, err)
+
+	// Assert
+	var events []models.OutboxEvent
+	require.NoError(t, db.Order("id").Find(&events).Error)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, models.EventOrderCreated, events[0].Type)
+	var orderCreated orderv1.OrderCreated
+	require.NoError(t, proto.Unmarshal(events[0].Payload, &orderCreated))
+	assert.Equal(t, uint64(events[0].ID), orderCreated.EventId)
+	assert.Equal(t, created.Order.Id, orderCreated.Order.Id)
+	require.Len(t, orderCreated.Order.OrderItems, 1)
+	assert.Equal(t, int32(2), orderCreated.Order.OrderItems[0].Quantity)
+
+	assert.Equal(t, models.EventOrderStatusChanged, events[1].Type)
+	var statusChanged orderv1.OrderStatusChanged
+	require.NoError(t, proto.Unmarshal(events[1].Payload, &statusChanged))
+	assert.Equal(t, created.Order.Id, statusChanged.OrderId)
+	assert.Equal(t, uint32(1), statusChanged.UserId)
+	assert.Equal(t, models.StatusPending, statusChanged.Transition.FromStatus)
+	assert.Equal(t, models.StatusPreparing, statusChanged.Transition.ToStatus)
+	assert.Equal(t, "staff 5", statusChanged.Transition.Actor)
+}
+
+// fakeWatchOrderStream captures messages sent by WatchOrder
+type fakeWatchOrderStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *orderv1.WatchOrderResponse
+}
+
+func (f *fakeWatchOrderStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeWatchOrderStream) Send(resp *orderv1.WatchOrderResponse) error {
+	f.sent <- resp
+	return nil
+}
+
+func TestWatchOrder(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	server := &OrderServer{}
+
+	testOrder := models.Order{UserID: 1, Status: models.StatusPending}
+	err := db.Create(&testOrder).Error
+	require.NoError(t, err)
+
+	t.Run("non-existent order", func(t *testing.T) {
+		stream := &fakeWatchOrderStream{ctx: context.Background(), sent: make(chan *orderv1.WatchOrderResponse, 1)}
+		err := server.WatchOrder(&orderv1.WatchOrderRequest{Id: 9999}, stream)
+
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.NotFound, st.Code())
+	})
+
+	t.Run("streams status changes until collected", func(t *testing.T) {
+		stream := &fakeWatchOrderStream{ctx: context.Background(), sent: make(chan *orderv1.WatchOrderResponse, 4)}
+		done := make(chan error, 1)
+		go func() {
+			done <- server.WatchOrder(&orderv1.WatchOrderRequest{Id: uint32(testOrder.ID)}, stream)
+		}()
+
+		// Current state is sent first
+		first := <-stream.sent
+		assert.Equal(t, models.StatusPending, first.Order.Status)
+
+		for _, next := range []string{models.StatusPreparing, models.StatusReady, models.StatusCollected} {
+			_, err := server.UpdateOrderStatus(asCaller(principal.Principal{UserID: 5, Role: principal.RoleStaff}), &orderv1.UpdateOrderStatusRequest{
+				Id:     uint32(testOrder.ID),
+				Status: next,
+			})
+			require.NoError(t, err)
+
+			select {
+			case resp := <-stream.sent:
+				assert.Equal(t, next, resp.Order.Status)
+			case <-time.After(time.Second):
+				t.Fatalf("no update received for status %q", next)
+			}
+		}
+
+		// The stream ends once the order is final
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("WatchOrder did not return after the order was collected")
+		}
+	})
+
+	t.Run("stops when the client goes away", func(t *testing.T) {
+		other := models.Order{UserID: 1, Status: models.StatusPending}
+		require.NoError(t, db.Create(&other).Error)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := &fakeWatchOrderStream{ctx: ctx, sent: make(chan *orderv1.WatchOrderResponse, 1)}
+		done := make(chan error, 1)
+		go func() {
+			done <- server.WatchOrder(&orderv1.WatchOrderRequest{Id: uint32(other.ID)}, stream)
+		}()
+
+		<-stream.sent
+		cancel()
+
+		select {
+		case err := <-done:
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			assert.Equal(t, codes.Canceled, st.Code())
+		case <-time.After(time.Second):
+			t.Fatal("WatchOrder did not return after cancellation")
+		}
+	})
+}
+
+func TestGetOrders(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}
+
+	// Test empty orders
+	t.Run("empty orders", func(t *testing.T) {
+		ctx := context.Background()
+		resp, err := server.GetOrders(ctx, &orderv1.GetOrdersRequest{})
+
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		assert.Empty(t, resp.Orders)
+	})
 
 	// Create multiple test orders
 	testOrders := []models.Order{
 		{
-			UserID: 1,
-			Status: "pending",
-			OrderItems: []models.OrderItem{
-				{MenuItemID: 1, Quantity: 2, Price: 2.50},
-			},
+			UserID: 1,
+			Status: "pending",
+			OrderItems: []models.OrderItem{
+				{MenuItemID: 1, Quantity: 2, Price: price(250)},
+			},
+		},
+		{
+			UserID: 2,
+			Status: "completed",
+			OrderItems: []models.OrderItem{
+				{MenuItemID: 2, Quantity: 1, Price: price(300)},
+			},
+			StatusHistory: []models.OrderStatusTransition{
+				{FromStatus: "pending", ToStatus: "completed", Actor: "barista"},
+			},
+		},
+	}
+
+	for _, order := range testOrders {
+		err := db.Create(&order).Error
+		require.NoError(t, err)
+	}
+
+	// Test multiple orders
+	t.Run("multiple orders", func(t *testing.T) {
+		ctx := context.Background()
+		resp, err := server.GetOrders(ctx, &orderv1.GetOrdersRequest{})
+
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		assert.Len(t, resp.Orders, 2)
+
+		// Verify orders are returned
+		for i, order := range resp.Orders {
+			assert.Equal(t, uint32(testOrders[i].UserID), order.UserId)
+			assert.Equal(t, testOrders[i].Status, order.Status)
+			assert.Len(t, order.OrderItems, 1)
+			assert.Len(t, order.StatusHistory, len(testOrders[i].StatusHistory))
+		}
+	})
+}
+
+func TestGetOrders_Filters(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	server := &OrderServer{}
+
+	// Spread creation times out so the date filters have something to bite on
+	base := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	testOrders := []models.Order{
+		{UserID: 1, Status: models.StatusPending},
+		{UserID: 1, Status: models.StatusReady},
+		{UserID: 2, Status: models.StatusPending},
+		{UserID: 2, Status: models.StatusCollected},
+	}
+	for i := range testOrders {
+		testOrders[i].CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		require.NoError(t, db.Create(&testOrders[i]).Error)
+	}
+
+	tests := []struct {
+		name        string
+		request     *orderv1.GetOrdersRequest
+		expectedIDs []uint
+		wantErr     bool
+	}{
+		{
+			name:        "by user",
+			request:     &orderv1.GetOrdersRequest{UserId: 2},
+			expectedIDs: []uint{testOrders[2].ID, testOrders[3].ID},
+		},
+		{
+			name:        "by status",
+			request:     &orderv1.GetOrdersRequest{Status: models.StatusPending},
+			expectedIDs: []uint{testOrders[0].ID, testOrders[2].ID},
+		},
+		{
+			name:        "by user and status",
+			request:     &orderv1.GetOrdersRequest{UserId: 1, Status: models.StatusReady},
+			expectedIDs: []uint{testOrders[1].ID},
+		},
+		{
+			name: "by created_at range",
+			request: &orderv1.GetOrdersRequest{
+				CreatedAfter:  base.Add(time.Hour).Format(time.RFC3339),
+				CreatedBefore: base.Add(3 * time.Hour).Format(time.RFC3339),
+			},
+			expectedIDs: []uint{testOrders[1].ID, testOrders[2].ID},
+		},
+		{
+			name:        "newest first",
+			request:     &orderv1.GetOrdersRequest{OrderBy: "created_at desc"},
+			expectedIDs: []uint{testOrders[3].ID, testOrders[2].ID, testOrders[1].ID, testOrders[0].ID},
+		},
+		{
+			name:    "unknown status",
+			request: &orderv1.GetOrdersRequest{Status: "lost"},
+			wantErr: true,
+		},
+		{
+			name:    "bad date",
+			request: &orderv1.GetOrdersRequest{CreatedAfter: "yesterday"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown sort field",
+			request: &orderv1.GetOrdersRequest{OrderBy: "user_id"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.GetOrders(context.Background(), tt.request)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				st, ok := status.FromError(err)
+				require.True(t, ok)
+				assert.Equal(t, codes.InvalidArgument, st.Code())
+				return
+			}
+
+			require.NoError(t, err)
+			ids := make([]uint, len(resp.Orders))
+			for i, order := range resp.Orders {
+				ids[i] = uint(order.Id)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+			assert.Empty(t, resp.NextPageToken)
+		})
+	}
+}
+
+func TestGetOrders_AuthenticatedCaller(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	server := &OrderServer{}
+
+	testOrders := []models.Order{
+		{UserID: 1, Status: models.StatusPending},
+		{UserID: 2, Status: models.StatusPending},
+		{UserID: 2, Status: models.StatusReady},
+	}
+	for i := range testOrders {
+		require.NoError(t, db.Create(&testOrders[i]).Error)
+	}
+
+	tests := []struct {
+		name        string
+		caller      principal.Principal
+		request     *orderv1.GetOrdersRequest
+		expectedIDs []uint
+	}{
+		{
+			name:        "students see their own orders",
+			caller:      principal.Principal{UserID: 2, Role: principal.RoleStudent},
+			request:     &orderv1.GetOrdersRequest{},
+			expectedIDs: []uint{testOrders[1].ID, testOrders[2].ID},
 		},
 		{
-			UserID: 2,
-			Status: "completed",
-			OrderItems: []models.OrderItem{
-				{MenuItemID: 2, Quantity: 1, Price: 3.00},
-			},
+			name:        "students cannot list other users' orders",
+			caller:      principal.Principal{UserID: 2, Role: principal.RoleStudent},
+			request:     &orderv1.GetOrdersRequest{UserId: 1},
+			expectedIDs: []uint{testOrders[1].ID, testOrders[2].ID},
+		},
+		{
+			name:        "owners see every order",
+			caller:      principal.Principal{UserID: 3, Role: principal.RoleOwner},
+			request:     &orderv1.GetOrdersRequest{},
+			expectedIDs: []uint{testOrders[0].ID, testOrders[1].ID, testOrders[2].ID},
+		},
+		{
+			name:        "owners can filter by user",
+			caller:      principal.Principal{UserID: 3, Role: principal.RoleOwner},
+			request:     &orderv1.GetOrdersRequest{UserId: 1},
+			expectedIDs: []uint{testOrders[0].ID},
+		},
+		{
+			name:        "staff see every order",
+			caller:      principal.Principal{UserID: 4, Role: principal.RoleStaff},
+			request:     &orderv1.GetOrdersRequest{},
+			expectedIDs: []uint{testOrders[0].ID, testOrders[1].ID, testOrders[2].ID},
 		},
 	}
 
-	for _, order := range testOrders {
-		err := db.Create(&order).Error
-		require.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.GetOrders(asCaller(tt.caller), tt.request)
+			require.NoError(t, err)
+
+			ids := make([]uint, len(resp.Orders))
+			for i, order := range resp.Orders {
+				ids[i] = uint(order.Id)
+			}
+			assert.Equal(t, tt.expectedIDs, ids)
+		})
 	}
+}
 
-	// Test multiple orders
-	t.Run("multiple orders", func(t *testing.T) {
-		ctx := context.Background()
-		resp, err := server.GetOrders(ctx, &orderv1.GetOrdersRequest{})
+func TestGetOrders_Pagination(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	server := &OrderServer{}
 
+	// Several orders share a timestamp to exercise the ID tie-break
+	base := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	var allIDs []uint
+	for i := 0; i < 7; i++ {
+		order := models.Order{UserID: 1, Status: models.StatusPending}
+		order.CreatedAt = base.Add(time.Duration(i/2) * time.Minute)
+		require.NoError(t, db.Create(&order).Error)
+		allIDs = append(allIDs, order.ID)
+	}
+
+	for _, orderBy := range []string{"", "created_at desc", "id desc"} {
+		t.Run("order_by="+orderBy, func(t *testing.T) {
+			var seen []uint
+			token := ""
+			pages := 0
+			for {
+				resp, err := server.GetOrders(context.Background(), &orderv1.GetOrdersRequest{
+					OrderBy:   orderBy,
+					PageSize:  3,
+					PageToken: token,
+				})
+				require.NoError(t, err)
+				assert.LessOrEqual(t, len(resp.Orders), 3)
+				for _, order := range resp.Orders {
+					seen = append(seen, uint(order.Id))
+				}
+				pages++
+				token = resp.NextPageToken
+				if token == "" {
+					break
+				}
+			}
+
+			assert.Equal(t, 3, pages)
+			expected := append([]uint(nil), allIDs...)
+			if orderBy != "" {
+				for i, j := 0, len(expected)-1; i < j; i, j = i+1, j-1 {
+					expected[i], expected[j] = expected[j], expected[i]
+				}
+			}
+			assert.Equal(t, expected, seen)
+		})
+	}
+
+	t.Run("token reused with different filters", func(t *testing.T) {
+		resp, err := server.GetOrders(context.Background(), &orderv1.GetOrdersRequest{PageSize: 3})
 		require.NoError(t, err)
-		require.NotNil(t, resp)
-		assert.Len(t, resp.Orders, 2)
+		require.NotEmpty(t, resp.NextPageToken)
 
-		// Verify orders are returned
-		for i, order := range resp.Orders {
-			assert.Equal(t, uint32(testOrders[i].UserID), order.UserId)
-			assert.Equal(t, testOrders[i].Status, order.Status)
-			assert.Len(t, order.OrderItems, 1)
-		}
+		_, err = server.GetOrders(context.Background(), &orderv1.GetOrdersRequest{
+			Status:    models.StatusPending,
+			PageSize:  3,
+			PageToken: resp.NextPageToken,
+		})
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+
+	t.Run("garbage token", func(t *testing.T) {
+		_, err := server.GetOrders(context.Background(), &orderv1.GetOrdersRequest{PageToken: "not-a-token"})
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+
+	t.Run("page size is capped", func(t *testing.T) {
+		resp, err := server.GetOrders(context.Background(), &orderv1.GetOrdersRequest{PageSize: 1000})
+		require.NoError(t, err)
+		assert.Len(t, resp.Orders, len(allIDs))
 	})
 }
 
@@ -396,7 +1848,7 @@ func TestModelToProto(t *testing.T) {
 				OrderID:    1,
 				MenuItemID: 2,
 				Quantity:   3,
-				Price:      4.50,
+				Price:      price(450),
 			},
 		},
 	}
@@ -415,7 +1867,7 @@ func TestModelToProto(t *testing.T) {
 	assert.Equal(t, uint32(1), protoOrder.OrderItems[0].OrderId)
 	assert.Equal(t, uint32(2), protoOrder.OrderItems[0].MenuItemId)
 	assert.Equal(t, int32(3), protoOrder.OrderItems[0].Quantity)
-	assert.InDelta(t, 4.50, protoOrder.OrderItems[0].Price, 0.001)
+	assert.Equal(t, int64(450), protoOrder.OrderItems[0].Price.MinorUnits)
 }
 
 func TestCreateOrder_PriceSnapshot(t *testing.T) {
@@ -439,11 +1891,14 @@ func TestCreateOrder_PriceSnapshot(t *testing.T) {
 		}, nil)
 
 	// Mock menu item with specific price
-	originalPrice := 5.99
-	mockMenuClient.On("GetMenuItem", mock.Anything, &menuv1.GetMenuItemRequest{Id: 1}).
-		Return(&menuv1.GetMenuItemResponse{
-			MenuItem: &menuv1.MenuItem{Id: 1, Name: "Special", Price: originalPrice},
+	originalPrice := priceProto(599)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{{Id: 1, Name: "Special", Price: originalPrice}},
 		}, nil)
+	expectStockReserved(mockMenuClient, map[uint32]int32{1: 1})
+	mockMenuClient.On("CommitStock", mock.Anything, mock.Anything).
+		Return(&menuv1.CommitStockResponse{}, nil)
 
 	// Create order
 	ctx := context.Background()
@@ -455,14 +1910,508 @@ func TestCreateOrder_PriceSnapshot(t *testing.T) {
 	})
 
 	require.NoError(t, err)
-	assert.InDelta(t, originalPrice, resp.Order.OrderItems[0].Price, 0.001)
+	assert.True(t, proto.Equal(originalPrice, resp.Order.OrderItems[0].Price))
 
 	// Verify price is stored in database
 	var dbOrder models.Order
 	err = db.Preload("OrderItems").First(&dbOrder, resp.Order.Id).Error
 	require.NoError(t, err)
-	assert.InDelta(t, originalPrice, dbOrder.OrderItems[0].Price, 0.001)
+	assert.Equal(t, price(599), dbOrder.OrderItems[0].Price)
 
 	mockUserClient.AssertExpectations(t)
 	mockMenuClient.AssertExpectations(t)
+}
+
+func TestCreateOrder_Totals(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+		TaxRates:   []pricing.TaxRate{{Name: "GST", Rate: 0.07}},
+	}
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{
+			User: &userv1.User{Id: 1, Name: "Test User"},
+		}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1, 2}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{
+				{Id: 1, Name: "Coffee", Price: priceProto(235)},
+				{Id: 2, Name: "Muffin", Price: priceProto(110)},
+			},
+		}, nil)
+	expectStockReserved(mockMenuClient, map[uint32]int32{1: 3, 2: 1})
+	mockMenuClient.On("CommitStock", mock.Anything, mock.Anything).
+		Return(&menuv1.CommitStockResponse{}, nil)
+
+	// Test
+	resp, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items: []*orderv1.OrderItemRequest{
+			{MenuItemId: 1, Quantity: 3},
+			{MenuItemId: 2, Quantity: 1},
+		},
+	})
+	require.NoError(t, err)
+
+	// Assert: 7.05 + 1.10 = 8.15, GST 0.5705 rounds to 0.57
+	order := resp.Order
+	assert.True(t, proto.Equal(priceProto(705), order.OrderItems[0].LineTotal))
+	assert.True(t, proto.Equal(priceProto(110), order.OrderItems[1].LineTotal))
+	assert.True(t, proto.Equal(priceProto(815), order.Subtotal))
+	assert.True(t, proto.Equal(priceProto(0), order.Discount))
+	assert.True(t, proto.Equal(priceProto(57), order.Tax))
+	assert.True(t, proto.Equal(priceProto(872), order.Total))
+	require.Len(t, order.Taxes, 1)
+	assert.Equal(t, "GST", order.Taxes[0].Name)
+	assert.Equal(t, 0.07, order.Taxes[0].Rate)
+	assert.True(t, proto.Equal(priceProto(57), order.Taxes[0].Amount))
+
+	// The same amounts are read back from the database
+	got, err := server.GetOrder(context.Background(), &orderv1.GetOrderRequest{Id: order.Id})
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(order.Subtotal, got.Order.Subtotal))
+	assert.True(t, proto.Equal(order.Total, got.Order.Total))
+	assert.True(t, proto.Equal(order.Taxes[0].Amount, got.Order.Taxes[0].Amount))
+	assert.True(t, proto.Equal(order.OrderItems[0].LineTotal, got.Order.OrderItems[0].LineTotal))
+}
+
+func TestCreateOrder_Modifiers(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}
+
+	latte := &menuv1.MenuItem{
+		Id:    1,
+		Name:  "Latte",
+		Price: priceProto(400),
+		ModifierGroups: []*menuv1.ModifierGroup{
+			{Id: 1, Name: "Milk", MinSelections: 1, MaxSelections: 1, Modifiers: []*menuv1.Modifier{
+				{Id: 10, Name: "Whole milk", PriceDelta: priceProto(0)},
+				{Id: 11, Name: "Oat milk", PriceDelta: priceProto(50)},
+			}},
+			{Id: 2, Name: "Extras", MaxSelections: 2, Modifiers: []*menuv1.Modifier{
+				{Id: 20, Name: "Extra shot", PriceDelta: priceProto(80)},
+				{Id: 21, Name: "Vanilla", PriceDelta: priceProto(60)},
+				{Id: 22, Name: "Caramel", PriceDelta: priceProto(60)},
+			}},
+		},
+	}
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{
+			User: &userv1.User{Id: 1, Name: "Test User"},
+		}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{MenuItems: []*menuv1.MenuItem{latte}}, nil)
+
+	t.Run("invalid selections", func(t *testing.T) {
+		tests := []struct {
+			name string
+			item *orderv1.OrderItemRequest
+			want string
+		}{
+			{"required group left out", &orderv1.OrderItemRequest{ModifierIds: []uint32{20}}, `choose at least 1 from "Milk"`},
+			{"too many from a group", &orderv1.OrderItemRequest{ModifierIds: []uint32{10, 11}}, `choose at most 1 from "Milk"`},
+			{"modifier of another item", &orderv1.OrderItemRequest{ModifierIds: []uint32{10, 99}}, "modifier 99 is not offered"},
+			{"same modifier twice", &orderv1.OrderItemRequest{ModifierIds: []uint32{10, 20, 20}}, "modifier 20 is chosen more than once"},
+			{"note too long", &orderv1.OrderItemRequest{ModifierIds: []uint32{10}, Note: strings.Repeat("é", 201)}, "at most 200 characters"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.item.MenuItemId = 1
+				tt.item.Quantity = 1
+				_, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+					UserId: 1,
+					Items:  []*orderv1.OrderItemRequest{tt.item},
+				})
+				require.Error(t, err)
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+				assert.Contains(t, status.Convert(err).Message(), tt.want)
+			})
+		}
+
+		mockMenuClient.AssertNotCalled(t, "ReserveStock", mock.Anything, mock.Anything)
+	})
+
+	expectStockReserved(mockMenuClient, map[uint32]int32{1: 2})
+	mockMenuClient.On("CommitStock", mock.Anything, mock.Anything).
+		Return(&menuv1.CommitStockResponse{}, nil)
+
+	resp, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items: []*orderv1.OrderItemRequest{
+			{MenuItemId: 1, Quantity: 2, ModifierIds: []uint32{11, 20}, Note: "extra hot"},
+		},
+	})
+	require.NoError(t, err)
+
+	// (4.00 + 0.50 + 0.80) * 2
+	item := resp.Order.OrderItems[0]
+	assert.True(t, proto.Equal(priceProto(400), item.Price))
+	assert.True(t, proto.Equal(priceProto(1060), item.LineTotal))
+	assert.True(t, proto.Equal(priceProto(1060), resp.Order.Total))
+	assert.Equal(t, "extra hot", item.Note)
+	require.Len(t, item.Modifiers, 2)
+	assert.Equal(t, uint32(11), item.Modifiers[0].ModifierId)
+	assert.Equal(t, "Milk", item.Modifiers[0].GroupName)
+	assert.Equal(t, "Oat milk", item.Modifiers[0].Name)
+	assert.True(t, proto.Equal(priceProto(50), item.Modifiers[0].PriceDelta))
+
+	// Later menu price changes do not affect the snapshot
+	latte.ModifierGroups[0].Modifiers[1].PriceDelta = priceProto(90)
+	got, err := server.GetOrder(context.Background(), &orderv1.GetOrderRequest{Id: resp.Order.Id})
+	require.NoError(t, err)
+	require.Len(t, got.Order.OrderItems[0].Modifiers, 2)
+	assert.True(t, proto.Equal(priceProto(50), got.Order.OrderItems[0].Modifiers[0].PriceDelta))
+	assert.Equal(t, "Extra shot", got.Order.OrderItems[0].Modifiers[1].Name)
+	assert.Equal(t, "extra hot", got.Order.OrderItems[0].Note)
+}
+
+func TestCreateOrder_MixedCurrencies(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+	}
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{
+			User: &userv1.User{Id: 1, Name: "Test User"},
+		}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1, 2}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{
+				{Id: 1, Name: "Coffee", Price: priceProto(235)},
+				{Id: 2, Name: "Croissant", Price: &commonv1.Money{CurrencyCode: "EUR", MinorUnits: 180}},
+			},
+		}, nil)
+
+	// Test
+	_, err := server.CreateOrder(context.Background(), &orderv1.CreateOrderRequest{
+		UserId: 1,
+		Items: []*orderv1.OrderItemRequest{
+			{MenuItemId: 1, Quantity: 1},
+			{MenuItemId: 2, Quantity: 1},
+		},
+	})
+
+	// Assert: nothing is reserved for an order that cannot be charged
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+	mockMenuClient.AssertNotCalled(t, "ReserveStock", mock.Anything, mock.Anything)
+}
+
+func TestPromotions(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	server := &OrderServer{}
+	ctx := context.Background()
+
+	created, err := server.CreatePromotion(ctx, &orderv1.CreatePromotionRequest{Promotion: &orderv1.Promotion{
+		Name:        "Pastry happy hour",
+		Kind:        "percentage",
+		PercentOff:  20,
+		CategoryIds: []uint32{2},
+		Windows:     []*orderv1.PromotionWindow{{Weekday: "friday", StartTime: "15:00", EndTime: "24:00"}},
+		EndsAt:      "2024-12-31T16:00:00Z",
+	}})
+	require.NoError(t, err)
+	assert.NotZero(t, created.Promotion.Id)
+	assert.Equal(t, []uint32{2}, created.Promotion.CategoryIds)
+	assert.Equal(t, "15:00", created.Promotion.Windows[0].StartTime)
+
+	_, err = server.CreatePromotion(ctx, &orderv1.CreatePromotionRequest{Promotion: &orderv1.Promotion{
+		Name:            "Coffee and a cookie",
+		Kind:            "bogo",
+		BuyQuantity:     2,
+		FreeQuantity:    1,
+		CategoryIds:     []uint32{1},
+		FreeMenuItemIds: []uint32{30},
+		Priority:        5,
+	}})
+	require.NoError(t, err)
+
+	list, err := server.ListPromotions(ctx, &orderv1.ListPromotionsRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Promotions, 2)
+	assert.Equal(t, "Coffee and a cookie", list.Promotions[0].Name)
+	assert.Equal(t, []uint32{30}, list.Promotions[0].FreeMenuItemIds)
+	assert.Equal(t, "2024-12-31T16:00:00Z", list.Promotions[1].EndsAt)
+
+	t.Run("invalid rules are rejected", func(t *testing.T) {
+		for name, promotion := range map[string]*orderv1.Promotion{
+			"unknown kind":        {Name: "x", Kind: "half price"},
+			"missing name":        {Kind: "percentage", PercentOff: 10},
+			"percent over 100":    {Name: "x", Kind: "percentage", PercentOff: 120},
+			"fields of a kind":    {Name: "x", Kind: "percentage", PercentOff: 10, BuyQuantity: 1},
+			"no amount":           {Name: "x", Kind: "fixed"},
+			"bad currency":        {Name: "x", Kind: "fixed", AmountOff: &commonv1.Money{MinorUnits: 50, CurrencyCode: "usd"}},
+			"nothing to buy":      {Name: "x", Kind: "bogo", FreeQuantity: 1},
+			"bad window":          {Name: "x", Kind: "percentage", PercentOff: 10, Windows: []*orderv1.PromotionWindow{{Weekday: "friday", StartTime: "18:00", EndTime: "15:00"}}},
+			"ends before it runs": {Name: "x", Kind: "percentage", PercentOff: 10, StartsAt: "2024-06-01T00:00:00Z", EndsAt: "2024-05-01T00:00:00Z"},
+		} {
+			_, err := server.CreatePromotion(ctx, &orderv1.CreatePromotionRequest{Promotion: promotion})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), name)
+		}
+	})
+
+	t.Run("deleting", func(t *testing.T) {
+		_, err := server.DeletePromotion(ctx, &orderv1.DeletePromotionRequest{Id: created.Promotion.Id})
+		require.NoError(t, err)
+
+		list, err := server.ListPromotions(ctx, &orderv1.ListPromotionsRequest{})
+		require.NoError(t, err)
+		assert.Len(t, list.Promotions, 1)
+
+		_, err = server.DeletePromotion(ctx, &orderv1.DeletePromotionRequest{Id: created.Promotion.Id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestCreateOrder_Promotions(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	mockUserClient := new(MockUserServiceClient)
+	mockMenuClient := new(MockMenuServiceClient)
+
+	// Friday 16:00 in the cafe, 08:00 UTC
+	cafe := time.FixedZone("SGT", 8*60*60)
+	now := time.Date(2024, 3, 8, 8, 0, 0, 0, time.UTC)
+	server := &OrderServer{
+		UserClient: mockUserClient,
+		MenuClient: mockMenuClient,
+		TaxRates:   []pricing.TaxRate{{Name: "GST", Rate: 0.09}},
+		Location:   cafe,
+		clock:      func() time.Time { return now },
+	}
+	ctx := context.Background()
+
+	for _, promotion := range []*orderv1.Promotion{
+		{
+			Name: "Pastry happy hour", Kind: "percentage", PercentOff: 20, CategoryIds: []uint32{2},
+			Windows: []*orderv1.PromotionWindow{{Weekday: "friday", StartTime: "15:00", EndTime: "24:00"}},
+		},
+		{
+			Name: "Coffee and a cookie", Kind: "bogo", BuyQuantity: 2, FreeQuantity: 1,
+			CategoryIds: []uint32{1}, FreeMenuItemIds: []uint32{3},
+		},
+		{Name: "Euro off", Kind: "fixed", AmountOff: &commonv1.Money{MinorUnits: 100, CurrencyCode: "EUR"}},
+	} {
+		_, err := server.CreatePromotion(ctx, &orderv1.CreatePromotionRequest{Promotion: promotion})
+		require.NoError(t, err)
+	}
+
+	mockUserClient.On("GetUser", mock.Anything, &userv1.GetUserRequest{Id: 1}).
+		Return(&userv1.GetUserResponse{
+			User: &userv1.User{Id: 1, Name: "Test User"},
+		}, nil)
+	mockMenuClient.On("BatchGetMenuItems", mock.Anything, &menuv1.BatchGetMenuItemsRequest{Ids: []uint32{1, 2, 3}}).
+		Return(&menuv1.BatchGetMenuItemsResponse{
+			MenuItems: []*menuv1.MenuItem{
+				{Id: 1, Name: "Coffee", Price: priceProto(300), CategoryId: 1},
+				{Id: 2, Name: "Croissant", Price: priceProto(325), CategoryId: 2},
+				{Id: 3, Name: "Cookie", Price: priceProto(150)},
+			},
+		}, nil)
+	expectStockReserved(mockMenuClient, map[uint32]int32{1: 2, 2: 2, 3: 1})
+	mockMenuClient.On("CommitStock", mock.Anything, mock.Anything).
+		Return(&menuv1.CommitStockResponse{}, nil)
+
+	order := func() *orderv1.Order {
+		resp, err := server.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId: 1,
+			Items: []*orderv1.OrderItemRequest{
+				{MenuItemId: 1, Quantity: 2},
+				{MenuItemId: 2, Quantity: 2},
+				{MenuItemId: 3, Quantity: 1},
+			},
+		})
+		require.NoError(t, err)
+		return resp.Order
+	}
+
+	// 6.00 + 6.50 + 1.50 = 14.00, less 1.30 off the croissants and the free cookie.
+	// The fixed promotion is in euros, so it does not apply.
+	placed := order()
+	assert.True(t, proto.Equal(priceProto(1400), placed.Subtotal))
+	assert.True(t, proto.Equal(priceProto(280), placed.Discount))
+	assert.True(t, proto.Equal(priceProto(101), placed.Tax)) // 9% of 11.20
+	require.Len(t, placed.Discounts, 2)
+	assert.Equal(t, "Pastry happy hour", placed.Discounts[0].Name)
+	assert.Equal(t, "percentage", placed.Discounts[0].Kind)
+	assert.True(t, proto.Equal(priceProto(130), placed.Discounts[0].Amount))
+	assert.Equal(t, "Coffee and a cookie", placed.Discounts[1].Name)
+	assert.True(t, proto.Equal(priceProto(150), placed.Discounts[1].Amount))
+
+	// The discounts are stored with the order
+	got, err := server.GetOrder(ctx, &orderv1.GetOrderRequest{Id: placed.Id})
+	require.NoError(t, err)
+	require.Len(t, got.Order.Discounts, 2)
+	assert.NotZero(t, got.Order.Discounts[0].PromotionId)
+	assert.True(t, proto.Equal(placed.Total, got.Order.Total))
+
+	// Outside the happy hour only the cookie is free
+	now = now.Add(-2 * time.Hour)
+	expectStockReserved(mockMenuClient, map[uint32]int32{1: 2, 2: 2, 3: 1})
+	assert.True(t, proto.Equal(priceProto(150), order().Discount))
+}
+
+func TestDetachUserOrders(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	detachedAt := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	server := &OrderServer{clock: func() time.Time { return detachedAt }}
+
+	mine := models.Order{UserID: 7, Status: models.StatusCollected, Total: price(500),
+		OrderItems: []models.OrderItem{{MenuItemID: 1, Quantity: 2, Price: price(250), Note: "for Alice, no sugar"}}}
+	theirs := models.Order{UserID: 8, Status: models.StatusPending, Total: price(250)}
+	require.NoError(t, db.Create(&mine).Error)
+	require.NoError(t, db.Create(&theirs).Error)
+	require.NoError(t, db.Create(&models.OrderSaga{UserID: 7, ReservationID: "res-1", OrderID: mine.ID, State: models.SagaCompleted}).Error)
+	require.NoError(t, db.Create(&models.IdempotencyKey{Key: "key-1", OrderID: mine.ID, ExpiresAt: detachedAt.Add(time.Hour)}).Error)
+
+	// The order's creation was relayed already, its status change is still pending
+	require.NoError(t, enqueueOrderCreated(db, &mine))
+	require.NoError(t, db.Model(&models.OutboxEvent{}).Where("type = ?", models.EventOrderCreated).Update("published_at", detachedAt).Error)
+	require.NoError(t, enqueueOrderStatusChanged(db, &mine, &models.OrderStatusTransition{FromStatus: models.StatusReady, ToStatus: models.StatusCollected, Actor: "staff 5"}))
+	require.NoError(t, enqueueOrderCreated(db, &theirs))
+
+	// Test
+	ctx := context.Background()
+	resp, err := server.DetachUserOrders(ctx, &orderv1.DetachUserOrdersRequest{UserId: 7})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.OrdersDetached)
+
+	// Assert: the order is kept for accounting without pointing at the user
+	got, err := server.GetOrder(ctx, &orderv1.GetOrderRequest{Id: uint32(mine.ID)})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), got.Order.UserId)
+	assert.Equal(t, "2026-03-02T09:00:00Z", got.Order.UserDetachedAt)
+	assert.Equal(t, int64(500), got.Order.Total.MinorUnits)
+	require.Len(t, got.Order.OrderItems, 1)
+	assert.Empty(t, got.Order.OrderItems[0].Note)
+
+	var saga models.OrderSaga
+	require.NoError(t, db.Where("reservation_id = ?", "res-1").First(&saga).Error)
+	assert.Equal(t, uint(0), saga.UserID)
+
+	var keys int64
+	require.NoError(t, db.Unscoped().Model(&models.IdempotencyKey{}).Count(&keys).Error)
+	assert.Equal(t, int64(0), keys)
+
+	var events []models.OutboxEvent
+	require.NoError(t, db.Where("type = ?", models.EventOrdersUserDetached).Find(&events).Error)
+	require.Len(t, events, 1)
+	var detached orderv1.OrdersUserDetached
+	require.NoError(t, proto.Unmarshal(events[0].Payload, &detached))
+	assert.Equal(t, uint32(7), detached.UserId)
+	assert.Equal(t, []uint32{uint32(mine.ID)}, detached.OrderIds)
+
+	// Published events for the order are gone, pending ones no longer name the user
+	var created []models.OutboxEvent
+	require.NoError(t, db.Unscoped().Where("type = ?", models.EventOrderCreated).Find(&created).Error)
+	require.Len(t, created, 1)
+	assert.Equal(t, theirs.ID, created[0].AggregateID)
+
+	var changed []models.OutboxEvent
+	require.NoError(t, db.Where("type = ?", models.EventOrderStatusChanged).Find(&changed).Error)
+	require.Len(t, changed, 1)
+	assert.Nil(t, changed[0].PublishedAt)
+	var statusChanged orderv1.OrderStatusChanged
+	require.NoError(t, proto.Unmarshal(changed[0].Payload, &statusChanged))
+	assert.Equal(t, uint32(mine.ID), statusChanged.OrderId)
+	assert.Equal(t, uint32(0), statusChanged.UserId)
+	assert.Equal(t, models.StatusCollected, statusChanged.Transition.ToStatus)
+
+	payload, err := proto.Marshal(&orderv1.OrderCreated{Order: &orderv1.Order{Id: 9, UserId: 7,
+		OrderItems: []*orderv1.OrderItem{{MenuItemId: 1, Note: "for Alice, no sugar"}}}})
+	require.NoError(t, err)
+	payload, err = scrubOrderEvent(models.EventOrderCreated, payload)
+	require.NoError(t, err)
+	var orderCreated orderv1.OrderCreated
+	require.NoError(t, proto.Unmarshal(payload, &orderCreated))
+	assert.Equal(t, uint32(0), orderCreated.Order.UserId)
+	assert.Empty(t, orderCreated.Order.OrderItems[0].Note)
+	assert.Equal(t, uint32(1), orderCreated.Order.OrderItems[0].MenuItemId)
+
+	var other models.Order
+	require.NoError(t, db.First(&other, theirs.ID).Error)
+	assert.Equal(t, uint(8), other.UserID)
+	assert.Nil(t, other.UserDetachedAt)
+
+	// Detaching again is harmless and publishes nothing more
+	resp, err = server.DetachUserOrders(ctx, &orderv1.DetachUserOrdersRequest{UserId: 7})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), resp.OrdersDetached)
+	var count int64
+	require.NoError(t, db.Model(&models.OutboxEvent{}).Where("type = ?", models.EventOrdersUserDetached).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	_, err = server.DetachUserOrders(ctx, &orderv1.DetachUserOrdersRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPolicy(t *testing.T) {
+	// Every method has a rule, so none is left uncallable
+	for _, method := range orderv1.OrderService_ServiceDesc.Methods {
+		assert.Contains(t, Policy, "/"+orderv1.OrderService_ServiceDesc.ServiceName+"/"+method.MethodName)
+	}
+	for _, stream := range orderv1.OrderService_ServiceDesc.Streams {
+		assert.Contains(t, Policy, "/"+orderv1.OrderService_ServiceDesc.ServiceName+"/"+stream.StreamName)
+	}
+
+	student := asCaller(principal.Principal{UserID: 1, Role: principal.RoleStudent})
+	staff := asCaller(principal.Principal{UserID: 2, Role: principal.RoleStaff})
+	assert.NoError(t, Policy.Authorize(student, orderv1.OrderService_CreateOrder_FullMethodName))
+	assert.Equal(t, codes.Unauthenticated, status.Code(Policy.Authorize(context.Background(), orderv1.OrderService_GetOrders_FullMethodName)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(Policy.Authorize(student, orderv1.OrderService_UpdateOrderStatus_FullMethodName)))
+	assert.NoError(t, Policy.Authorize(staff, orderv1.OrderService_UpdateOrderStatus_FullMethodName))
+	assert.Equal(t, codes.PermissionDenied, status.Code(Policy.Authorize(staff, orderv1.OrderService_CreatePromotion_FullMethodName)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(Policy.Authorize(student, orderv1.OrderService_DetachUserOrders_FullMethodName)))
+}
+
+// price returns a USD amount in cents, for order models
+func price(cents int64) models.Money {
+	return models.Money{MinorUnits: cents, CurrencyCode: "USD"}
+}
+
+// priceProto returns a USD amount in cents, for menu items and responses
+func priceProto(cents int64) *commonv1.Money {
+	return &commonv1.Money{CurrencyCode: "USD", MinorUnits: cents}
 }
\ No newline at end of file
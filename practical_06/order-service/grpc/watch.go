@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"sync"
+
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"order-service/database"
+	"order-service/models"
+)
+
+// orderWatchers fans order updates out to WatchOrder subscribers.
+// The zero value is ready to use.
+type orderWatchers struct {
+	mu   sync.Mutex
+	subs map[uint32]map[chan *orderv1.Order]struct{}
+}
+
+// subscribe registers interest in an order and returns the update channel
+// together with a function that must be called to unsubscribe
+func (w *orderWatchers) subscribe(orderID uint32) (<-chan *orderv1.Order, func()) {
+	// Only the latest snapshot matters, so a single slot is enough
+	ch := make(chan *orderv1.Order, 1)
+
+	w.mu.Lock()
+	if w.subs == nil {
+		w.subs = make(map[uint32]map[chan *orderv1.Order]struct{})
+	}
+	if w.subs[orderID] == nil {
+		w.subs[orderID] = make(map[chan *orderv1.Order]struct{})
+	}
+	w.subs[orderID][ch] = struct{}{}
+	w.mu.Unlock()
+
+	return ch, func() {
+		w.mu.Lock()
+		delete(w.subs[orderID], ch)
+		if len(w.subs[orderID]) == 0 {
+			delete(w.subs, orderID)
+		}
+		w.mu.Unlock()
+	}
+}
+
+// publish delivers an order snapshot to every subscriber of that order.
+// Slow subscribers never block the publisher: a pending snapshot they have
+// not read yet is replaced by the newer one.
+func (w *orderWatchers) publish(order *orderv1.Order) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for ch := range w.subs[order.Id] {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- order
+	}
+}
+
+// WatchOrder streams the current state of an order, then every status change until the order is final.
+// Authenticated callers other than staff may only watch their own orders.
+func (s *OrderServer) WatchOrder(req *orderv1.WatchOrderRequest, stream orderv1.OrderService_WatchOrderServer) error {
+	ctx := stream.Context()
+
+	// Subscribe before reading the order so no change can slip in between
+	updates, unsubscribe := s.watchers.subscribe(req.Id)
+	defer unsubscribe()
+
+	var order models.Order
+	if err := database.DB.Preload("OrderItems").Preload("OrderItems.Modifiers").Preload("StatusHistory").Preload("Taxes").Preload("Discounts").First(&order, req.Id).Error; err != nil {
+		return status.Errorf(codes.NotFound, "order not found")
+	}
+	if err := checkOrderAccess(ctx, &order); err != nil {
+		return err
+	}
+
+	current := modelToProto(&order)
+	if err := stream.Send(&orderv1.WatchOrderResponse{Order: current}); err != nil {
+		return err
+	}
+
+	for !models.IsFinalStatus(current.Status) {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case next := <-updates:
+			// Skip snapshots we have already sent
+			if len(next.StatusHistory) <= len(current.StatusHistory) {
+				continue
+			}
+			current = next
+			if err := stream.Send(&orderv1.WatchOrderResponse{Order: current}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
\ No newline at end of file
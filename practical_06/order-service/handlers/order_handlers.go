@@ -59,7 +59,10 @@ func CreateOrder(w http.ResponseWriter, r *http.Request) {
 		orderItem := models.OrderItem{
 			MenuItemID: item.MenuItemID,
 			Quantity:   item.Quantity,
-			Price:      menuItemResp.MenuItem.Price,
+			Price: models.Money{
+				MinorUnits:   menuItemResp.MenuItem.Price.GetMinorUnits(),
+				CurrencyCode: menuItemResp.MenuItem.Price.GetCurrencyCode(),
+			},
 		}
 		order.OrderItems = append(order.OrderItems, orderItem)
 	}
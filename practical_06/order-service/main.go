@@ -1,17 +1,28 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 	"os"
+	"time"
+	_ "time/tzdata" // Time zone database for images without one
 	"order-service/database"
 	grpcserver "order-service/grpc"
+	"order-service/models"
+	"order-service/outbox"
+	"order-service/pricing"
 
+	"github.com/douglasswm/student-cafe-protos/authz"
 	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	"github.com/douglasswm/student-cafe-protos/mtls"
 	"google.golang.org/grpc"
 )
 
+// sagaRecoveryInterval is how often in-flight order sagas are checked for recovery
+const sagaRecoveryInterval = 30 * time.Second
+
 func main() {
 	// Connect to dedicated order database
 	dsn := os.Getenv("DATABASE_URL")
@@ -19,6 +30,11 @@ func main() {
 		dsn = "host=localhost user=postgres password=postgres dbname=order_db port=5432 sslmode=disable"
 	}
 
+	// Currency that amounts stored before they had one are migrated to, as an ISO 4217 code
+	if currency := os.Getenv("CURRENCY"); currency != "" {
+		models.DefaultCurrency = currency
+	}
+
 	if err := database.Connect(dsn); err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -46,14 +62,69 @@ func main() {
 		menuServiceAddr = "menu-service:9092"
 	}
 
+	// The gateway and the other services are only talked to over mutual TLS
+	tlsConfig, err := mtls.FromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure TLS: %v", err)
+	}
+	serverCreds, err := tlsConfig.ServerCredentials()
+	if err != nil {
+		log.Fatalf("Failed to load TLS credentials: %v", err)
+	}
+	clientCreds, err := tlsConfig.ClientCredentials()
+	if err != nil {
+		log.Fatalf("Failed to load TLS credentials: %v", err)
+	}
+
 	// Create order gRPC server with clients to other services
-	orderServer, err := grpcserver.NewOrderServer(userServiceAddr, menuServiceAddr)
+	orderServer, err := grpcserver.NewOrderServer(userServiceAddr, menuServiceAddr, clientCreds)
 	if err != nil {
 		log.Fatalf("Failed to create gRPC order server: %v", err)
 	}
 
-	// Create and register gRPC server
-	s := grpc.NewServer()
+	// Taxes charged on every order, e.g. TAX_RATES="GST=0.09"
+	orderServer.TaxRates, err = pricing.ParseTaxRates(os.Getenv("TAX_RATES"))
+	if err != nil {
+		log.Fatalf("Invalid TAX_RATES: %v", err)
+	}
+
+	// Time zone of the cafe, which promotion windows are in
+	if tz := os.Getenv("CAFE_TIMEZONE"); tz != "" {
+		orderServer.Location, err = time.LoadLocation(tz)
+		if err != nil {
+			log.Fatalf("Invalid CAFE_TIMEZONE %q: %v", tz, err)
+		}
+	}
+
+	// How long CreateOrder responses are kept for Idempotency-Key replays
+	if ttl := os.Getenv("IDEMPOTENCY_KEY_TTL"); ttl != "" {
+		orderServer.IdempotencyTTL, err = time.ParseDuration(ttl)
+		if err != nil {
+			log.Fatalf("Invalid IDEMPOTENCY_KEY_TTL %q: %v", ttl, err)
+		}
+	}
+	go orderServer.RunIdempotencyKeyPurge(context.Background(), time.Hour)
+
+	// Finish order sagas interrupted by a crash, and keep retrying failed compensations
+	go orderServer.RunSagaRecovery(context.Background(), sagaRecoveryInterval)
+
+	// Publish order events from the outbox, to NATS when it is configured
+	var publisher outbox.Publisher
+	if natsURL := os.Getenv("NATS_URL"); natsURL != "" {
+		natsPublisher, err := outbox.NewNATSPublisher(natsURL)
+		if err != nil {
+			log.Fatalf("Failed to create event publisher: %v", err)
+		}
+		defer natsPublisher.Close()
+		publisher = natsPublisher
+	} else {
+		log.Println("NATS_URL not set, order events are only published in memory, keeping the latest 1000")
+		publisher = outbox.NewMemoryPublisher()
+	}
+	go outbox.NewRelay(publisher).Run(context.Background())
+
+	// Create and register gRPC server, authorizing calls by the caller's role
+	s := grpc.NewServer(append(authz.ServerOptions(grpcserver.Policy), grpc.Creds(serverCreds))...)
 	orderv1.RegisterOrderServiceServer(s, orderServer)
 
 	log.Printf("Order service (gRPC only) starting on :%s", grpcPort)
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IdempotencyKey remembers the outcome of a CreateOrder call made with an
+// Idempotency-Key, so a retried request gets the original response back
+// instead of placing a second order.
+type IdempotencyKey struct {
+	gorm.Model
+	Key         string    `json:"key" gorm:"uniqueIndex"`
+	RequestHash string    `json:"request_hash"` // Fingerprint of the request the key was first used with
+	Response    []byte    `json:"response"`     // Protobuf encoded response, empty while the request is in progress
+	OrderID     uint      `json:"order_id"`     // Order placed by the request
+	ExpiresAt   time.Time `json:"expires_at" gorm:"index"`
+}
\ No newline at end of file
@@ -0,0 +1,11 @@
+package models
+
+// DefaultCurrency is the ISO 4217 currency of amounts migrated from before
+// amounts had one. New orders take the currency of their menu items.
+var DefaultCurrency = "USD"
+
+// Money is an amount in the minor units of a currency, e.g. cents
+type Money struct {
+	MinorUnits   int64  `json:"minor_units"`
+	CurrencyCode string `json:"currency_code" gorm:"size:3"`
+}
\ No newline at end of file
@@ -1,18 +1,113 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Order statuses. An order starts as pending and moves forward through
+// preparing and ready until it is collected, or is cancelled on the way.
+const (
+	StatusPending   = "pending"
+	StatusPreparing = "preparing"
+	StatusReady     = "ready"
+	StatusCollected = "collected"
+	StatusCancelled = "cancelled"
+)
+
+// statusTransitions lists the statuses each status may move to
+var statusTransitions = map[string][]string{
+	StatusPending:   {StatusPreparing, StatusCancelled},
+	StatusPreparing: {StatusReady, StatusCancelled},
+	StatusReady:     {StatusCollected, StatusCancelled},
+	StatusCollected: {},
+	StatusCancelled: {},
+}
+
+// IsValidStatus reports whether status is a known order status
+func IsValidStatus(status string) bool {
+	_, ok := statusTransitions[status]
+	return ok
+}
+
+// CanTransition reports whether an order may move from one status to another
+func CanTransition(from, to string) bool {
+	for _, next := range statusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinalStatus reports whether no further transitions are possible from status
+func IsFinalStatus(status string) bool {
+	next, ok := statusTransitions[status]
+	return ok && len(next) == 0
+}
 
 type Order struct {
 	gorm.Model
-	UserID     uint        `json:"user_id"`
-	Status     string      `json:"status"` // "pending", "completed"
-	OrderItems []OrderItem `json:"order_items" gorm:"foreignKey:OrderID"`
+	UserID        uint                    `json:"user_id" gorm:"index"`
+	Status        string                  `json:"status" gorm:"index"` // see Status* constants
+	OrderItems    []OrderItem             `json:"order_items" gorm:"foreignKey:OrderID"`
+	StatusHistory []OrderStatusTransition `json:"status_history" gorm:"foreignKey:OrderID"`
+
+	// Amounts computed when the order is placed: Total = Subtotal - Discount + Tax
+	Subtotal Money      `json:"subtotal" gorm:"embedded;embeddedPrefix:subtotal_"`
+	Discount Money      `json:"discount" gorm:"embedded;embeddedPrefix:discount_"`
+	Tax      Money      `json:"tax" gorm:"embedded;embeddedPrefix:tax_"`
+	Total    Money      `json:"total" gorm:"embedded;embeddedPrefix:total_"`
+	Taxes    []OrderTax `json:"taxes" gorm:"foreignKey:OrderID"`
+
+	// Discounts from promotions, adding up to Discount
+	Discounts []OrderDiscount `json:"discounts" gorm:"foreignKey:OrderID"`
+
+	StockReservationID string `json:"-"` // Reservation holding the menu stock for this order
+
+	// Set when the user who placed the order was deleted. The order is kept for
+	// accounting, but UserID is cleared so it no longer points at a person.
+	UserDetachedAt *time.Time `json:"user_detached_at"`
 }
 
 type OrderItem struct {
 	gorm.Model
-	OrderID    uint    `json:"order_id"`
-	MenuItemID uint    `json:"menu_item_id"`
-	Quantity   int     `json:"quantity"`
-	Price      float64 `json:"price"` // Snapshot price at order time
+	OrderID    uint  `json:"order_id"`
+	MenuItemID uint  `json:"menu_item_id"`
+	Quantity   int   `json:"quantity"`
+	Price      Money `json:"price" gorm:"embedded;embeddedPrefix:price_"`           // Snapshot price at order time
+	LineTotal  Money `json:"line_total" gorm:"embedded;embeddedPrefix:line_total_"` // (Price + modifier deltas) * Quantity
+
+	Modifiers []OrderItemModifier `json:"modifiers" gorm:"foreignKey:OrderItemID"`
+	Note      string              `json:"note"` // Free-text instructions for the line
+}
+
+// OrderItemModifier is a modifier chosen for an order line. Like the item's
+// price, its name and price are copied from the menu when the order is placed.
+type OrderItemModifier struct {
+	gorm.Model
+	OrderItemID uint   `json:"order_item_id" gorm:"index"`
+	ModifierID  uint   `json:"modifier_id"`
+	GroupName   string `json:"group_name"`
+	Name        string `json:"name"`
+	PriceDelta  Money  `json:"price_delta" gorm:"embedded;embeddedPrefix:price_delta_"`
+}
+
+// OrderTax is one tax charged on an order, kept so receipts can itemise it
+type OrderTax struct {
+	gorm.Model
+	OrderID uint    `json:"order_id" gorm:"index"`
+	Name    string  `json:"name"`
+	Rate    float64 `json:"rate"`
+	Amount  Money   `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+}
+
+// OrderStatusTransition is an audit record of a status change
+type OrderStatusTransition struct {
+	gorm.Model
+	OrderID    uint   `json:"order_id" gorm:"index"`
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Actor      string `json:"actor"`
 }
\ No newline at end of file
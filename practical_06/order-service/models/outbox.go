@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Order event types, also used as the subject events are published on
+const (
+	EventOrderCreated       = "orders.created"
+	EventOrderStatusChanged = "orders.status_changed"
+	EventOrdersUserDetached = "orders.user_detached"
+)
+
+// DeletedOnPublish are the event types that name a deleted user. The outbox
+// deletes them as soon as they are published instead of keeping them.
+var DeletedOnPublish = map[string]bool{
+	EventOrdersUserDetached: true,
+}
+
+// OutboxEvent is a domain event waiting to be published. Events are written in
+// the same transaction as the change they describe and published afterwards
+// by the outbox relay, so no event is lost and none is sent for a rolled back change.
+type OutboxEvent struct {
+	gorm.Model
+	Type        string     `json:"type"` // see Event* constants
+	AggregateID uint       `json:"aggregate_id" gorm:"index"`
+	Payload     []byte     `json:"payload"` // Protobuf encoded event message
+	PublishedAt *time.Time `json:"published_at" gorm:"index"`
+	Attempts    int        `json:"attempts"`
+	LastError   string     `json:"last_error"`
+}
\ No newline at end of file
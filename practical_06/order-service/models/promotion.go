@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Promotion is a discount rule applied to every order placed while it is
+// active. See package promotions for how rules are evaluated.
+type Promotion struct {
+	gorm.Model
+	Name         string `json:"name"`
+	Kind         string `json:"kind"` // see promotions.Kind* constants
+	PercentOff   int    `json:"percent_off"`
+	AmountOff    Money  `json:"amount_off" gorm:"embedded;embeddedPrefix:amount_off_"`
+	BuyQuantity  int    `json:"buy_quantity"`
+	FreeQuantity int    `json:"free_quantity"`
+
+	Targets []PromotionTarget `json:"targets" gorm:"foreignKey:PromotionID"`
+	Windows []PromotionWindow `json:"windows" gorm:"foreignKey:PromotionID"`
+
+	StartsAt *time.Time `json:"starts_at"`
+	EndsAt   *time.Time `json:"ends_at"`
+
+	Exclusive bool `json:"exclusive"`
+	Priority  int  `json:"priority"`
+}
+
+// PromotionTarget selects the order lines of a menu item, or of a category, that
+// a promotion applies to. Free targets select the units a bogo rule gives free.
+type PromotionTarget struct {
+	gorm.Model
+	PromotionID uint `json:"promotion_id" gorm:"index"`
+	Free        bool `json:"free"`
+	MenuItemID  uint `json:"menu_item_id"` // Set for a menu item target
+	CategoryID  uint `json:"category_id"`  // Set for a category target
+}
+
+// PromotionWindow is a weekly time range during which a promotion applies, in
+// the cafe's time zone. Promotions without windows apply at any time.
+type PromotionWindow struct {
+	gorm.Model
+	PromotionID uint         `json:"promotion_id" gorm:"index"`
+	Weekday     time.Weekday `json:"weekday"`      // 0 is Sunday
+	StartMinute int          `json:"start_minute"` // Minutes after midnight, inclusive
+	EndMinute   int          `json:"end_minute"`   // Minutes after midnight, exclusive
+}
+
+// OrderDiscount is a discount a promotion gave an order. The promotion's name
+// and kind are copied so receipts stay correct after it changes or is deleted.
+type OrderDiscount struct {
+	gorm.Model
+	OrderID     uint   `json:"order_id" gorm:"index"`
+	PromotionID uint   `json:"promotion_id"`
+	Name        string `json:"name"`
+	Kind        string `json:"kind"`
+	Amount      Money  `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+}
\ No newline at end of file
@@ -0,0 +1,39 @@
+package models
+
+import "gorm.io/gorm"
+
+// Order saga states. A saga moves forward through the steps of placing an
+// order until it is completed. When a step fails it switches to compensating,
+// undoes the steps already taken and ends up compensated.
+const (
+	SagaStarted       = "started"
+	SagaStockReserved = "stock_reserved"
+	SagaOrderCreated  = "order_created"
+	SagaCompleted     = "completed"
+	SagaCompensating  = "compensating"
+	SagaCompensated   = "compensated"
+)
+
+// SagaFinalStates lists the states a saga never leaves
+var SagaFinalStates = []string{SagaCompleted, SagaCompensated}
+
+// IsFinalSagaState reports whether a saga in this state is finished
+func IsFinalSagaState(state string) bool {
+	for _, final := range SagaFinalStates {
+		if state == final {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderSaga is the persisted progress of placing one order across services.
+// It is written before each step so an interrupted saga can be finished on restart.
+type OrderSaga struct {
+	gorm.Model
+	UserID        uint   `json:"user_id"`
+	ReservationID string `json:"reservation_id" gorm:"uniqueIndex"` // Stock reservation in the menu service
+	OrderID       uint   `json:"order_id" gorm:"index"`             // Set once the order has been saved
+	State         string `json:"state" gorm:"index"`                // see Saga* constants
+	LastError     string `json:"last_error"`                        // Why the saga was compensated
+}
\ No newline at end of file
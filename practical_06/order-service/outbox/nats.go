@@ -0,0 +1,72 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// StreamName is the JetStream stream that stores order events
+const StreamName = "ORDERS"
+
+// StreamSubjects are the subjects stored in StreamName
+var StreamSubjects = []string{"orders.>"}
+
+// NATSPublisher publishes outbox messages to a NATS JetStream stream, using
+// the event type as subject. The outbox event ID is sent in the Nats-Msg-Id
+// header so the stream drops redeliveries.
+type NATSPublisher struct {
+	conn *nats.Conn
+	js   jetstream.JetStream
+}
+
+// NewNATSPublisher connects to the NATS server at url and creates or updates
+// the stream events are stored in. The server must have JetStream enabled.
+func NewNATSPublisher(url string) (*NATSPublisher, error) {
+	conn, err := nats.Connect(url,
+		nats.Name("order-service"),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to use JetStream: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     StreamName,
+		Subjects: StreamSubjects,
+		Storage:  jetstream.FileStorage,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create stream %s: %w", StreamName, err)
+	}
+	return &NATSPublisher{conn: conn, js: js}, nil
+}
+
+// Publish stores the message in the stream and waits for its acknowledgement,
+// so it only returns nil once the message is stored, even when no consumer is
+// connected. ctx must carry a deadline.
+func (p *NATSPublisher) Publish(ctx context.Context, msg Message) error {
+	m := nats.NewMsg(msg.Subject)
+	m.Header.Set(nats.MsgIdHdr, strconv.FormatUint(msg.ID, 10))
+	m.Data = msg.Data
+
+	_, err := p.js.PublishMsg(ctx, m)
+	return err
+}
+
+// Close flushes pending messages and closes the connection
+func (p *NATSPublisher) Close() error {
+	return p.conn.Drain()
+}
\ No newline at end of file
@@ -0,0 +1,80 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+)
+
+// Message is an outbox event handed to a Publisher
+type Message struct {
+	ID      uint64 // Outbox event ID, the same for every redelivery
+	Subject string // Event type, e.g. "orders.created"
+	Data    []byte // Protobuf encoded event
+}
+
+// Publisher delivers outbox messages to a message broker. Publish must only
+// return nil once the broker has accepted the message; the relay retries
+// every message until it does.
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// defaultMemoryLimit is how many messages a MemoryPublisher keeps by default
+const defaultMemoryLimit = 1000
+
+// MemoryPublisher is an in-process Publisher, useful for tests and local
+// development. Subscribers are called synchronously from Publish. Only the
+// latest Limit messages are kept, so it can run for as long as the service.
+type MemoryPublisher struct {
+	Limit int // Messages kept, defaults to 1000
+
+	mu          sync.Mutex
+	messages    []Message
+	subscribers map[string][]func(Message)
+}
+
+// NewMemoryPublisher creates an empty in-memory publisher
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{
+		Limit:       defaultMemoryLimit,
+		subscribers: make(map[string][]func(Message)),
+	}
+}
+
+// Publish records the message, dropping the oldest one past the limit, and
+// passes it to the subject's subscribers
+func (p *MemoryPublisher) Publish(ctx context.Context, msg Message) error {
+	p.mu.Lock()
+	p.messages = append(p.messages, msg)
+	if over := len(p.messages) - p.limit(); over > 0 {
+		p.messages = p.messages[over:]
+	}
+	handlers := append([]func(Message){}, p.subscribers[msg.Subject]...)
+	p.mu.Unlock()
+
+	for _, handle := range handlers {
+		handle(msg)
+	}
+	return nil
+}
+
+// Subscribe registers a handler for every message published on subject
+func (p *MemoryPublisher) Subscribe(subject string, handler func(Message)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.subscribers[subject] = append(p.subscribers[subject], handler)
+}
+
+// Messages returns the messages kept, oldest first
+func (p *MemoryPublisher) Messages() []Message {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Message(nil), p.messages...)
+}
+
+func (p *MemoryPublisher) limit() int {
+	if p.Limit <= 0 {
+		return defaultMemoryLimit
+	}
+	return p.Limit
+}
\ No newline at end of file
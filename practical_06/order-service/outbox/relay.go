@@ -0,0 +1,151 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"order-service/database"
+	"order-service/models"
+)
+
+const (
+	defaultBatchSize = 100
+	defaultInterval  = time.Second
+	publishTimeout   = 5 * time.Second
+)
+
+// Enqueue records an event in the outbox as part of tx, so it is only
+// published if tx commits. build is given the event's ID and time and
+// returns the message to publish.
+func Enqueue(tx *gorm.DB, eventType string, aggregateID uint, build func(eventID uint64, occurredAt time.Time) proto.Message) error {
+	event := models.OutboxEvent{
+		Type:        eventType,
+		AggregateID: aggregateID,
+	}
+	if err := tx.Create(&event).Error; err != nil {
+		return err
+	}
+
+	payload, err := proto.Marshal(build(uint64(event.ID), event.CreatedAt))
+	if err != nil {
+		return err
+	}
+	return tx.Model(&event).Update("payload", payload).Error
+}
+
+// Relay publishes outbox events in the order they were written. Relays take
+// turns, so several of them can run without reordering events. An event is
+// only marked as published once the publisher has accepted it, so delivery
+// is at least once: after a crash or a failed update the event is sent again.
+// Events of the types in models.DeletedOnPublish are deleted at that point.
+type Relay struct {
+	Publisher Publisher
+	BatchSize int           // Events published per transaction, defaults to 100
+	Interval  time.Duration // How often to look for new events, defaults to one second
+}
+
+// NewRelay creates a relay with the default batch size and interval
+func NewRelay(publisher Publisher) *Relay {
+	return &Relay{
+		Publisher: publisher,
+		BatchSize: defaultBatchSize,
+		Interval:  defaultInterval,
+	}
+}
+
+// Run publishes pending events until ctx is done
+func (r *Relay) Run(ctx context.Context) {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		published, err := r.PublishPending(ctx)
+		if err != nil {
+			log.Printf("outbox relay: %v", err)
+		}
+
+		// Keep going straight away while there is a backlog
+		if err == nil && published == r.batchSize() {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PublishPending publishes the oldest unpublished events, up to one batch,
+// and returns how many were published. It stops at the first event the
+// publisher rejects so later events do not overtake it.
+func (r *Relay) PublishPending(ctx context.Context) (int, error) {
+	var published int
+	var publishErr error
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		// Other relays wait for the lock instead of skipping to later events,
+		// which could then be published before the ones locked here
+		var events []models.OutboxEvent
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("published_at IS NULL").
+			Order("id").
+			Limit(r.batchSize()).
+			Find(&events).Error; err != nil {
+			return err
+		}
+
+		for i := range events {
+			event := &events[i]
+			if publishErr = r.publish(ctx, event); publishErr != nil {
+				return tx.Model(event).Updates(map[string]interface{}{
+					"attempts":   gorm.Expr("attempts + 1"),
+					"last_error": publishErr.Error(),
+				}).Error
+			}
+
+			if models.DeletedOnPublish[event.Type] {
+				if err := tx.Unscoped().Delete(event).Error; err != nil {
+					return err
+				}
+			} else if err := tx.Model(event).Update("published_at", time.Now()).Error; err != nil {
+				return err
+			}
+			published++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return published, publishErr
+}
+
+// publish hands a single event to the publisher
+func (r *Relay) publish(ctx context.Context, event *models.OutboxEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+
+	return r.Publisher.Publish(ctx, Message{
+		ID:      uint64(event.ID),
+		Subject: event.Type,
+		Data:    event.Payload,
+	})
+}
+
+func (r *Relay) batchSize() int {
+	if r.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return r.BatchSize
+}
\ No newline at end of file
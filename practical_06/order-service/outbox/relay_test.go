@@ -0,0 +1,218 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"order-service/database"
+	"order-service/models"
+)
+
+// flakyPublisher rejects the first failures messages, then behaves like a MemoryPublisher
+type flakyPublisher struct {
+	*MemoryPublisher
+	failures int
+}
+
+func (p *flakyPublisher) Publish(ctx context.Context, msg Message) error {
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("broker unavailable")
+	}
+	return p.MemoryPublisher.Publish(ctx, msg)
+}
+
+// setupTestDB creates an in-memory SQLite database for testing
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
+	require.NoError(t, err, "Failed to open test database")
+
+	err = db.AutoMigrate(&models.OutboxEvent{})
+	require.NoError(t, err, "Failed to migrate test database")
+
+	database.DB = db
+	t.Cleanup(func() {
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		sqlDB.Close()
+	})
+	return db
+}
+
+// enqueueCreated writes an OrderCreated event for the given order ID
+func enqueueCreated(t *testing.T, db *gorm.DB, orderID uint32) {
+	err := Enqueue(db, models.EventOrderCreated, uint(orderID), func(eventID uint64, occurredAt time.Time) proto.Message {
+		return &orderv1.OrderCreated{
+			EventId:    eventID,
+			OccurredAt: occurredAt.Format(time.RFC3339),
+			Order:      &orderv1.Order{Id: orderID},
+		}
+	})
+	require.NoError(t, err)
+}
+
+func TestEnqueue(t *testing.T) {
+	db := setupTestDB(t)
+
+	enqueueCreated(t, db, 7)
+
+	var event models.OutboxEvent
+	require.NoError(t, db.First(&event).Error)
+	assert.Equal(t, models.EventOrderCreated, event.Type)
+	assert.Equal(t, uint(7), event.AggregateID)
+	assert.Nil(t, event.PublishedAt)
+
+	// The payload carries the event's own ID
+	var created orderv1.OrderCreated
+	require.NoError(t, proto.Unmarshal(event.Payload, &created))
+	assert.Equal(t, uint64(event.ID), created.EventId)
+	assert.Equal(t, uint32(7), created.Order.Id)
+}
+
+func TestEnqueue_RolledBackWithTransaction(t *testing.T) {
+	db := setupTestDB(t)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		enqueueCreated(t, tx, 1)
+		return errors.New("order could not be saved")
+	})
+	require.Error(t, err)
+
+	var count int64
+	db.Model(&models.OutboxEvent{}).Count(&count)
+	assert.Zero(t, count)
+}
+
+func TestRelay_PublishPending(t *testing.T) {
+	db := setupTestDB(t)
+	for id := uint32(1); id <= 3; id++ {
+		enqueueCreated(t, db, id)
+	}
+
+	publisher := NewMemoryPublisher()
+	relay := NewRelay(publisher)
+	relay.BatchSize = 2
+
+	// The first batch is limited by the batch size
+	published, err := relay.PublishPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+
+	published, err = relay.PublishPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+
+	// Nothing is left to publish
+	published, err = relay.PublishPending(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, published)
+
+	// Messages are published in the order they were written
+	messages := publisher.Messages()
+	require.Len(t, messages, 3)
+	for i, msg := range messages {
+		assert.Equal(t, uint64(i+1), msg.ID)
+		assert.Equal(t, models.EventOrderCreated, msg.Subject)
+
+		var created orderv1.OrderCreated
+		require.NoError(t, proto.Unmarshal(msg.Data, &created))
+		assert.Equal(t, uint32(i+1), created.Order.Id)
+	}
+
+	var unpublished int64
+	db.Model(&models.OutboxEvent{}).Where("published_at IS NULL").Count(&unpublished)
+	assert.Zero(t, unpublished)
+}
+
+func TestRelay_RetriesRejectedEvents(t *testing.T) {
+	db := setupTestDB(t)
+	enqueueCreated(t, db, 1)
+	enqueueCreated(t, db, 2)
+
+	publisher := &flakyPublisher{MemoryPublisher: NewMemoryPublisher(), failures: 1}
+	relay := NewRelay(publisher)
+
+	// The first event is rejected and the second is held back to keep the order
+	published, err := relay.PublishPending(context.Background())
+	require.Error(t, err)
+	assert.Zero(t, published)
+	assert.Empty(t, publisher.Messages())
+
+	var event models.OutboxEvent
+	require.NoError(t, db.First(&event, 1).Error)
+	assert.Nil(t, event.PublishedAt)
+	assert.Equal(t, 1, event.Attempts)
+	assert.Equal(t, "broker unavailable", event.LastError)
+
+	// Both go out once the broker is back
+	published, err = relay.PublishPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+
+	messages := publisher.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, uint64(1), messages[0].ID)
+	assert.Equal(t, uint64(2), messages[1].ID)
+}
+
+func TestRelay_DeletesEventsNamingDeletedUsers(t *testing.T) {
+	db := setupTestDB(t)
+	enqueueCreated(t, db, 1)
+	err := Enqueue(db, models.EventOrdersUserDetached, 7, func(eventID uint64, occurredAt time.Time) proto.Message {
+		return &orderv1.OrdersUserDetached{EventId: eventID, UserId: 7, OrderIds: []uint32{1}}
+	})
+	require.NoError(t, err)
+
+	publisher := NewMemoryPublisher()
+	published, err := NewRelay(publisher).PublishPending(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+	require.Len(t, publisher.Messages(), 2)
+
+	// Only the order's event is kept as published
+	var events []models.OutboxEvent
+	require.NoError(t, db.Unscoped().Find(&events).Error)
+	require.Len(t, events, 1)
+	assert.Equal(t, models.EventOrderCreated, events[0].Type)
+	assert.NotNil(t, events[0].PublishedAt)
+}
+
+func TestMemoryPublisher_Subscribe(t *testing.T) {
+	publisher := NewMemoryPublisher()
+
+	var received []uint64
+	publisher.Subscribe(models.EventOrderStatusChanged, func(msg Message) {
+		received = append(received, msg.ID)
+	})
+
+	ctx := context.Background()
+	require.NoError(t, publisher.Publish(ctx, Message{ID: 1, Subject: models.EventOrderCreated}))
+	require.NoError(t, publisher.Publish(ctx, Message{ID: 2, Subject: models.EventOrderStatusChanged}))
+
+	assert.Equal(t, []uint64{2}, received)
+	assert.Len(t, publisher.Messages(), 2)
+}
+
+func TestMemoryPublisher_Limit(t *testing.T) {
+	publisher := NewMemoryPublisher()
+	publisher.Limit = 2
+
+	ctx := context.Background()
+	for id := uint64(1); id <= 5; id++ {
+		require.NoError(t, publisher.Publish(ctx, Message{ID: id, Subject: models.EventOrderCreated}))
+	}
+
+	// Only the latest messages are kept
+	messages := publisher.Messages()
+	require.Len(t, messages, 2)
+	assert.Equal(t, uint64(4), messages[0].ID)
+	assert.Equal(t, uint64(5), messages[1].ID)
+}
\ No newline at end of file
@@ -0,0 +1,104 @@
+// Package pricing computes order totals. Amounts are whole minor units of a
+// single currency, e.g. cents, and taxes are rounded half away from zero, so
+// every client sees the same numbers.
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TaxRate is a tax charged on the discounted subtotal of every order
+type TaxRate struct {
+	Name string
+	Rate float64 // e.g. 0.07 for 7%
+}
+
+// Line is an order line to be priced
+type Line struct {
+	UnitPrice int64 // Minor units
+	Quantity  int
+}
+
+// TaxAmount is the tax charged for one TaxRate
+type TaxAmount struct {
+	Name   string
+	Rate   float64
+	Amount int64
+}
+
+// Totals are the computed amounts of an order. Total = Subtotal - Discount + Tax.
+type Totals struct {
+	LineTotals []int64 // Same order as the priced lines
+	Subtotal   int64
+	Discount   int64
+	Tax        int64
+	Taxes      []TaxAmount
+	Total      int64
+}
+
+// Compute prices an order. The discount is capped at the subtotal, and taxes
+// are charged on what is left after the discount.
+func Compute(lines []Line, discount int64, rates []TaxRate) Totals {
+	totals := Totals{LineTotals: make([]int64, len(lines))}
+
+	var subtotal int64
+	for i, line := range lines {
+		lineTotal := line.UnitPrice * int64(line.Quantity)
+		totals.LineTotals[i] = lineTotal
+		subtotal += lineTotal
+	}
+
+	if discount < 0 {
+		discount = 0
+	}
+	if discount > subtotal {
+		discount = subtotal
+	}
+
+	taxable := subtotal - discount
+	var tax int64
+	for _, rate := range rates {
+		amount := int64(math.Round(float64(taxable) * rate.Rate))
+		totals.Taxes = append(totals.Taxes, TaxAmount{
+			Name:   rate.Name,
+			Rate:   rate.Rate,
+			Amount: amount,
+		})
+		tax += amount
+	}
+
+	totals.Subtotal = subtotal
+	totals.Discount = discount
+	totals.Tax = tax
+	totals.Total = taxable + tax
+	return totals
+}
+
+// ParseTaxRates parses a comma separated list of NAME=RATE pairs,
+// e.g. "GST=0.09,Service charge=0.10". An empty string means no tax.
+func ParseTaxRates(s string) ([]TaxRate, error) {
+	var rates []TaxRate
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid tax rate %q, expected NAME=RATE", part)
+		}
+
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid rate for tax %q: must be between 0 and 1", name)
+		}
+
+		rates = append(rates, TaxRate{Name: name, Rate: rate})
+	}
+	return rates, nil
+}
\ No newline at end of file
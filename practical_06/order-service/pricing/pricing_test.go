@@ -0,0 +1,91 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []Line
+		discount int64
+		rates    []TaxRate
+		want     Totals
+	}{
+		{
+			name:  "no tax",
+			lines: []Line{{UnitPrice: 250, Quantity: 2}, {UnitPrice: 200, Quantity: 1}},
+			want: Totals{
+				LineTotals: []int64{500, 200},
+				Subtotal:   700,
+				Total:      700,
+			},
+		},
+		{
+			name:  "tax is rounded half away from zero",
+			lines: []Line{{UnitPrice: 150, Quantity: 1}},
+			rates: []TaxRate{{Name: "GST", Rate: 0.07}},
+			want: Totals{
+				LineTotals: []int64{150},
+				Subtotal:   150,
+				Tax:        11, // 10.5 cents
+				Taxes:      []TaxAmount{{Name: "GST", Rate: 0.07, Amount: 11}},
+				Total:      161,
+			},
+		},
+		{
+			name:     "taxes apply after the discount",
+			lines:    []Line{{UnitPrice: 400, Quantity: 3}},
+			discount: 200,
+			rates:    []TaxRate{{Name: "GST", Rate: 0.09}, {Name: "Service", Rate: 0.10}},
+			want: Totals{
+				LineTotals: []int64{1200},
+				Subtotal:   1200,
+				Discount:   200,
+				Tax:        190,
+				Taxes: []TaxAmount{
+					{Name: "GST", Rate: 0.09, Amount: 90},
+					{Name: "Service", Rate: 0.10, Amount: 100},
+				},
+				Total: 1190,
+			},
+		},
+		{
+			name:     "discount is capped at the subtotal",
+			lines:    []Line{{UnitPrice: 300, Quantity: 1}},
+			discount: 500,
+			rates:    []TaxRate{{Name: "GST", Rate: 0.09}},
+			want: Totals{
+				LineTotals: []int64{300},
+				Subtotal:   300,
+				Discount:   300,
+				Taxes:      []TaxAmount{{Name: "GST", Rate: 0.09, Amount: 0}},
+				Total:      0,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Compute(tt.lines, tt.discount, tt.rates))
+		})
+	}
+}
+
+func TestParseTaxRates(t *testing.T) {
+	rates, err := ParseTaxRates("GST=0.09, Service charge = 0.10")
+	require.NoError(t, err)
+	assert.Equal(t, []TaxRate{{Name: "GST", Rate: 0.09}, {Name: "Service charge", Rate: 0.10}}, rates)
+
+	rates, err = ParseTaxRates("")
+	require.NoError(t, err)
+	assert.Empty(t, rates)
+
+	for _, invalid := range []string{"GST", "=0.09", "GST=abc", "GST=-0.1", "GST=9"} {
+		_, err := ParseTaxRates(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
\ No newline at end of file
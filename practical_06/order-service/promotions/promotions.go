@@ -0,0 +1,344 @@
+// Package promotions works out the discounts promotion rules give an order.
+// Like package pricing, amounts are whole minor units of a single currency;
+// percentages are rounded half away from zero for each unit.
+package promotions
+
+import (
+	"sort"
+	"time"
+)
+
+// Rule kinds
+const (
+	KindPercentage = "percentage"
+	KindFixed      = "fixed"
+	KindBOGO       = "bogo"
+)
+
+// MinutesPerDay is the end of the last possible window
+const MinutesPerDay = 24 * 60
+
+// Line is an order line to find discounts for
+type Line struct {
+	MenuItemID uint
+	CategoryID uint  // 0 when uncategorised
+	UnitPrice  int64 // Including modifiers
+	Quantity   int
+}
+
+// Target selects order lines by menu item or category. The zero Target
+// selects every line.
+type Target struct {
+	MenuItemIDs []uint
+	CategoryIDs []uint
+}
+
+// IsZero reports whether t selects every line
+func (t Target) IsZero() bool {
+	return len(t.MenuItemIDs) == 0 && len(t.CategoryIDs) == 0
+}
+
+// Matches reports whether t selects line
+func (t Target) Matches(line Line) bool {
+	if t.IsZero() {
+		return true
+	}
+	for _, id := range t.MenuItemIDs {
+		if id == line.MenuItemID {
+			return true
+		}
+	}
+	if line.CategoryID != 0 {
+		for _, id := range t.CategoryIDs {
+			if id == line.CategoryID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Window is a weekly time range, in the cafe's time zone
+type Window struct {
+	Weekday     time.Weekday // 0 is Sunday
+	StartMinute int          // Minutes after midnight, inclusive
+	EndMinute   int          // Minutes after midnight, exclusive; at most MinutesPerDay
+}
+
+// Contains reports whether t, in the cafe's time zone, falls within the window
+func (w Window) Contains(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	return t.Weekday() == w.Weekday && minute >= w.StartMinute && minute < w.EndMinute
+}
+
+// Rule is a promotion rule
+type Rule struct {
+	ID   uint
+	Name string
+	Kind string // see Kind* constants
+
+	PercentOff   int   // KindPercentage: 1 to 100
+	AmountOff    int64 // KindFixed: off each targeted unit
+	BuyQuantity  int   // KindBOGO: units to buy for each set
+	FreeQuantity int   // KindBOGO: units free with each set
+
+	// Target selects the units the rule discounts or, for KindBOGO, the units
+	// to buy. FreeTarget selects the units a KindBOGO rule gives free; nil
+	// means they are drawn from Target.
+	Target     Target
+	FreeTarget *Target
+
+	Windows  []Window   // Empty means at any time
+	StartsAt *time.Time // Optional
+	EndsAt   *time.Time // Optional, exclusive
+
+	Exclusive bool // Never combined with other rules
+	Priority  int  // Higher priorities are applied first
+}
+
+// ActiveAt reports whether the rule applies at t, in the cafe's time zone
+func (r *Rule) ActiveAt(t time.Time) bool {
+	if r.StartsAt != nil && t.Before(*r.StartsAt) {
+		return false
+	}
+	if r.EndsAt != nil && !t.Before(*r.EndsAt) {
+		return false
+	}
+	if len(r.Windows) == 0 {
+		return true
+	}
+	for _, window := range r.Windows {
+		if window.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Discount is the discount one rule gives an order
+type Discount struct {
+	Rule   *Rule
+	Amount int64
+}
+
+// Apply works out the discounts the rules active at t give an order. Rules
+// are applied in priority order. Stackable rules combine, each discounting
+// what earlier rules left of a unit's price, so no unit goes below zero. An
+// exclusive rule is never combined: the best one is used alone when it gives
+// more than the stackable rules together. Rules that give nothing are left out.
+func Apply(rules []Rule, lines []Line, t time.Time) []Discount {
+	var stackable, exclusive []*Rule
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.ActiveAt(t) {
+			continue
+		}
+		if rule.Exclusive {
+			exclusive = append(exclusive, rule)
+		} else {
+			stackable = append(stackable, rule)
+		}
+	}
+	byPriority(stackable)
+	byPriority(exclusive)
+
+	best := applyAll(stackable, lines)
+	bestTotal := total(best)
+	for _, rule := range exclusive {
+		discounts := applyAll([]*Rule{rule}, lines)
+		if t := total(discounts); t > bestTotal {
+			best, bestTotal = discounts, t
+		}
+	}
+	return best
+}
+
+// byPriority sorts rules by priority, highest first, then by ID
+func byPriority(rules []*Rule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority > rules[j].Priority
+		}
+		return rules[i].ID < rules[j].ID
+	})
+}
+
+// lot is a number of units of an order line with the same price left. A line
+// starts as a single lot and is split when a rule discounts only some of its
+// units, so the work never grows with the quantity ordered.
+type lot struct {
+	line      Line
+	count     int64
+	remaining int64 // Price left of each unit
+}
+
+// applyAll applies rules in turn to the lots of lines
+func applyAll(rules []*Rule, lines []Line) []Discount {
+	var lots []*lot
+	for _, line := range lines {
+		if line.Quantity > 0 {
+			lots = append(lots, &lot{line: line, count: int64(line.Quantity), remaining: line.UnitPrice})
+		}
+	}
+
+	var discounts []Discount
+	for _, rule := range rules {
+		var amount int64
+		if amount, lots = applyRule(rule, lots); amount > 0 {
+			discounts = append(discounts, Discount{Rule: rule, Amount: amount})
+		}
+	}
+	return discounts
+}
+
+// applyRule takes a rule's discount off the remaining prices of lots. It
+// returns the amount taken off and the lots, split where only some units of a
+// lot were discounted.
+func applyRule(rule *Rule, lots []*lot) (int64, []*lot) {
+	var amount int64
+	take := func(l *lot, off int64) {
+		if off > l.remaining {
+			off = l.remaining
+		}
+		if off > 0 {
+			l.remaining -= off
+			amount += off * l.count
+		}
+	}
+
+	switch rule.Kind {
+	case KindPercentage:
+		for _, l := range lots {
+			if rule.Target.Matches(l.line) {
+				take(l, (l.remaining*int64(rule.PercentOff)+50)/100)
+			}
+		}
+	case KindFixed:
+		for _, l := range lots {
+			if rule.Target.Matches(l.line) {
+				take(l, rule.AmountOff)
+			}
+		}
+	case KindBOGO:
+		var free []*lot
+		lots, free = splitFree(rule, lots)
+		for _, l := range free {
+			take(l, l.remaining)
+		}
+	}
+	return amount, lots
+}
+
+// splitFree picks the units a KindBOGO rule gives free. It returns the lots,
+// split so each is either wholly free or not, and the free ones. Each set takes
+// the cheapest free units left and the most expensive units left to buy, so a
+// unit counts towards only one set. Runs of sets drawn from the same two lots
+// are counted at once, so the work grows with the lots, not the units.
+func splitFree(rule *Rule, lots []*lot) ([]*lot, []*lot) {
+	if rule.BuyQuantity <= 0 || rule.FreeQuantity <= 0 {
+		return lots, nil
+	}
+	freeTarget := rule.Target
+	if rule.FreeTarget != nil {
+		freeTarget = *rule.FreeTarget
+	}
+
+	var buy, free []*lot
+	for _, l := range lots {
+		if rule.Target.Matches(l.line) {
+			buy = append(buy, l)
+		}
+		if freeTarget.Matches(l.line) {
+			free = append(free, l)
+		}
+	}
+	sort.SliceStable(buy, func(i, j int) bool { return buy[i].remaining > buy[j].remaining })
+	sort.SliceStable(free, func(i, j int) bool { return free[i].remaining < free[j].remaining })
+
+	buyQuantity, freeQuantity := int64(rule.BuyQuantity), int64(rule.FreeQuantity)
+	used := make(map[*lot]int64)  // Units counted towards a set
+	given := make(map[*lot]int64) // Units given free
+	for {
+		f, b := firstLeft(free, used), firstLeft(buy, used)
+		if f == nil || b == nil {
+			break
+		}
+
+		var sets int64
+		if f == b {
+			sets = (f.count - used[f]) / (freeQuantity + buyQuantity)
+		} else {
+			sets = min((f.count-used[f])/freeQuantity, (b.count-used[b])/buyQuantity)
+		}
+		if sets > 0 {
+			used[f] += sets * freeQuantity
+			given[f] += sets * freeQuantity
+			used[b] += sets * buyQuantity
+			continue
+		}
+
+		// A single set spanning several lots, after which one of them is used up
+		set := takeUnits(free, freeQuantity, used)
+		if set == nil || takeUnits(buy, buyQuantity, used) == nil {
+			break
+		}
+		for l, n := range set {
+			given[l] += n
+		}
+	}
+
+	var result, freed []*lot
+	for _, l := range lots {
+		result = append(result, l)
+		n := given[l]
+		if n == 0 {
+			continue
+		}
+		if n < l.count {
+			// Only some units of the lot are free
+			result = append(result, &lot{line: l.line, count: l.count - n, remaining: l.remaining})
+			l.count = n
+		}
+		freed = append(freed, l)
+	}
+	return result, freed
+}
+
+// firstLeft returns the first of lots with units not used yet, or nil
+func firstLeft(lots []*lot, used map[*lot]int64) *lot {
+	for _, l := range lots {
+		if used[l] < l.count {
+			return l
+		}
+	}
+	return nil
+}
+
+// takeUnits marks the first n units of lots not used yet as used and returns how
+// many it took from each lot, or nil if there are fewer
+func takeUnits(lots []*lot, n int64, used map[*lot]int64) map[*lot]int64 {
+	taken := make(map[*lot]int64)
+	for _, l := range lots {
+		if n == 0 {
+			break
+		}
+		if k := min(n, l.count-used[l]); k > 0 {
+			used[l] += k
+			taken[l] = k
+			n -= k
+		}
+	}
+	if n > 0 {
+		return nil
+	}
+	return taken
+}
+
+// total adds up the amounts of discounts
+func total(discounts []Discount) int64 {
+	var sum int64
+	for _, discount := range discounts {
+		sum += discount.Amount
+	}
+	return sum
+}
\ No newline at end of file
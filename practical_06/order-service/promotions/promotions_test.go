@@ -0,0 +1,152 @@
+package promotions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	coffees  = 1
+	pastries = 2
+	cookie   = 30
+)
+
+// amounts returns the rule ID and amount of each discount
+func amounts(discounts []Discount) map[uint]int64 {
+	result := make(map[uint]int64)
+	for _, discount := range discounts {
+		result[discount.Rule.ID] = discount.Amount
+	}
+	return result
+}
+
+func TestApply(t *testing.T) {
+	// Monday 15:30
+	monday := time.Date(2024, 3, 4, 15, 30, 0, 0, time.UTC)
+
+	latte := Line{MenuItemID: 10, CategoryID: coffees, UnitPrice: 400, Quantity: 1}
+	mocha := Line{MenuItemID: 11, CategoryID: coffees, UnitPrice: 450, Quantity: 1}
+	croissant := Line{MenuItemID: 20, CategoryID: pastries, UnitPrice: 325, Quantity: 2}
+	cookies := Line{MenuItemID: cookie, UnitPrice: 200, Quantity: 1}
+
+	afternoon := []Window{{Weekday: time.Monday, StartMinute: 15 * 60, EndMinute: MinutesPerDay}}
+	pastryDeal := Rule{ID: 1, Kind: KindPercentage, PercentOff: 20, Target: Target{CategoryIDs: []uint{pastries}}, Windows: afternoon}
+	coffeeCookie := Rule{
+		ID: 2, Kind: KindBOGO, BuyQuantity: 2, FreeQuantity: 1,
+		Target: Target{CategoryIDs: []uint{coffees}}, FreeTarget: &Target{MenuItemIDs: []uint{cookie}},
+	}
+
+	tests := []struct {
+		name  string
+		rules []Rule
+		lines []Line
+		at    time.Time
+		want  map[uint]int64
+	}{
+		{
+			name:  "percentage off targeted lines, rounded per unit",
+			rules: []Rule{pastryDeal},
+			lines: []Line{latte, croissant},
+			at:    monday,
+			want:  map[uint]int64{1: 130}, // 65 off each croissant
+		},
+		{
+			name:  "outside the window",
+			rules: []Rule{pastryDeal},
+			lines: []Line{croissant},
+			at:    monday.Add(-time.Hour),
+			want:  map[uint]int64{},
+		},
+		{
+			name:  "outside the dates",
+			rules: []Rule{{ID: 1, Kind: KindPercentage, PercentOff: 10, EndsAt: &monday}},
+			lines: []Line{latte},
+			at:    monday,
+			want:  map[uint]int64{},
+		},
+		{
+			name:  "fixed amount off each unit, never below zero",
+			rules: []Rule{{ID: 1, Kind: KindFixed, AmountOff: 250, Target: Target{MenuItemIDs: []uint{20, 30}}}},
+			lines: []Line{latte, croissant, cookies},
+			at:    monday,
+			want:  map[uint]int64{1: 700},
+		},
+		{
+			name:  "buy two coffees get a cookie free",
+			rules: []Rule{coffeeCookie},
+			lines: []Line{latte, mocha, cookies},
+			at:    monday,
+			want:  map[uint]int64{2: 200},
+		},
+		{
+			name:  "not enough to buy",
+			rules: []Rule{coffeeCookie},
+			lines: []Line{latte, cookies},
+			at:    monday,
+			want:  map[uint]int64{},
+		},
+		{
+			name:  "buy one get one free gives the cheapest units",
+			rules: []Rule{{ID: 1, Kind: KindBOGO, BuyQuantity: 1, FreeQuantity: 1, Target: Target{CategoryIDs: []uint{coffees}}}},
+			lines: []Line{latte, mocha, {MenuItemID: 12, CategoryID: coffees, UnitPrice: 300, Quantity: 1}},
+			at:    monday,
+			want:  map[uint]int64{1: 300}, // The third coffee has nothing to pair with
+		},
+		{
+			name: "later rules discount the units of a line left to pay for",
+			rules: []Rule{
+				{ID: 1, Kind: KindBOGO, BuyQuantity: 1, FreeQuantity: 1, Priority: 1},
+				{ID: 2, Kind: KindPercentage, PercentOff: 50},
+			},
+			lines: []Line{{MenuItemID: 10, CategoryID: coffees, UnitPrice: 400, Quantity: 3}},
+			at:    monday,
+			want:  map[uint]int64{1: 400, 2: 400},
+		},
+		{
+			name:  "sets are counted, not the units",
+			rules: []Rule{{ID: 1, Kind: KindBOGO, BuyQuantity: 2, FreeQuantity: 1}},
+			lines: []Line{{MenuItemID: 10, UnitPrice: 100, Quantity: 1_000_000_000}},
+			at:    monday,
+			want:  map[uint]int64{1: 33_333_333_300},
+		},
+		{
+			name: "stackable rules discount what is left",
+			rules: []Rule{
+				{ID: 1, Kind: KindPercentage, PercentOff: 50, Priority: 1},
+				{ID: 2, Kind: KindFixed, AmountOff: 300},
+			},
+			lines: []Line{latte},
+			at:    monday,
+			want:  map[uint]int64{1: 200, 2: 200},
+		},
+		{
+			name: "a better exclusive rule is used alone",
+			rules: []Rule{
+				pastryDeal,
+				{ID: 3, Kind: KindPercentage, PercentOff: 30, Exclusive: true},
+			},
+			lines: []Line{latte, croissant},
+			at:    monday,
+			want:  map[uint]int64{3: 316}, // 120 + 97.5 rounded up for each croissant
+		},
+		{
+			name: "a worse exclusive rule is ignored",
+			rules: []Rule{
+				pastryDeal,
+				coffeeCookie,
+				{ID: 3, Kind: KindFixed, AmountOff: 100, Exclusive: true, Target: Target{CategoryIDs: []uint{coffees}}},
+			},
+			lines: []Line{latte, mocha, croissant, cookies},
+			at:    monday,
+			want:  map[uint]int64{1: 130, 2: 200},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, amounts(Apply(tt.rules, tt.lines, tt.at)))
+		})
+	}
+}
\ No newline at end of file
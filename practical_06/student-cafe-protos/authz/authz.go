@@ -0,0 +1,100 @@
+// Package authz authorizes gRPC calls by the caller's role. Each service
+// declares a Policy naming who may call each of its methods, and installs it
+// with ServerOptions; methods missing from the policy cannot be called at all.
+package authz
+
+import (
+	"context"
+
+	"github.com/douglasswm/student-cafe-protos/principal"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Rule says who may call a method
+type Rule struct {
+	public bool
+	roles  map[string]bool
+}
+
+// Public allows anyone to call a method, including anonymous callers
+func Public() Rule {
+	return Rule{public: true}
+}
+
+// Allow allows callers with any of roles to call a method
+func Allow(roles ...string) Rule {
+	allowed := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		allowed[role] = true
+	}
+	return Rule{roles: allowed}
+}
+
+// Users are the roles of every signed in user
+var Users = []string{principal.RoleOwner, principal.RoleStaff, principal.RoleStudent}
+
+// Policy maps full gRPC method names, such as "/menu.v1.MenuService/GetMenu",
+// to who may call them
+type Policy map[string]Rule
+
+// Authorize checks that the caller of an incoming call may call method.
+// Anonymous callers of protected methods get Unauthenticated; callers without
+// an allowed role, and calls to methods missing from the policy, get
+// PermissionDenied.
+func (p Policy) Authorize(ctx context.Context, method string) error {
+	rule, ok := p[method]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "%s is not allowed by the authorization policy", method)
+	}
+	if rule.public {
+		return nil
+	}
+
+	caller, ok := principal.FromIncomingContext(ctx)
+	if !ok {
+		return status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+	if !rule.roles[caller.Role] {
+		return status.Errorf(codes.PermissionDenied, "role %q may not call %s", caller.Role, method)
+	}
+	return nil
+}
+
+// UnaryServerInterceptor rejects unary calls the policy does not allow
+func (p Policy) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := p.Authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects streaming calls the policy does not allow
+func (p Policy) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := p.Authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// ServerOptions installs the policy's interceptors on a gRPC server
+func ServerOptions(p Policy) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(p.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(p.StreamServerInterceptor()),
+	}
+}
+
+// AsService makes every unary call on a client connection carry
+// principal.Service as the caller, replacing any caller on the context, for
+// services calling each other on their own behalf
+func AsService() grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(principal.NewOutgoingContext(ctx, principal.Service), method, req, reply, cc, opts...)
+	})
+}
\ No newline at end of file
@@ -0,0 +1,101 @@
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/douglasswm/student-cafe-protos/principal"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testPolicy = Policy{
+	"/test.v1.TestService/Read":    Public(),
+	"/test.v1.TestService/Order":   Allow(Users...),
+	"/test.v1.TestService/Manage":  Allow(principal.RoleOwner),
+	"/test.v1.TestService/Reserve": Allow(principal.RoleService),
+}
+
+// incoming returns the context a service receives for a call made as p
+func incoming(p principal.Principal) context.Context {
+	md, _ := metadata.FromOutgoingContext(principal.NewOutgoingContext(context.Background(), p))
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func TestAuthorize(t *testing.T) {
+	owner := principal.Principal{UserID: 1, Role: principal.RoleOwner}
+	staff := principal.Principal{UserID: 2, Role: principal.RoleStaff}
+	student := principal.Principal{UserID: 3, Role: principal.RoleStudent}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   codes.Code
+	}{
+		{"anonymous public", context.Background(), "/test.v1.TestService/Read", codes.OK},
+		{"anonymous protected", context.Background(), "/test.v1.TestService/Order", codes.Unauthenticated},
+		{"student order", incoming(student), "/test.v1.TestService/Order", codes.OK},
+		{"staff order", incoming(staff), "/test.v1.TestService/Order", codes.OK},
+		{"owner manage", incoming(owner), "/test.v1.TestService/Manage", codes.OK},
+		{"staff manage", incoming(staff), "/test.v1.TestService/Manage", codes.PermissionDenied},
+		{"student manage", incoming(student), "/test.v1.TestService/Manage", codes.PermissionDenied},
+		{"service reserve", incoming(principal.Service), "/test.v1.TestService/Reserve", codes.OK},
+		{"service order", incoming(principal.Service), "/test.v1.TestService/Order", codes.PermissionDenied},
+		{"owner reserve", incoming(owner), "/test.v1.TestService/Reserve", codes.PermissionDenied},
+		{"unknown method", incoming(owner), "/test.v1.TestService/Unknown", codes.PermissionDenied},
+		{"unknown role", incoming(principal.Principal{UserID: 4, Role: "admin"}), "/test.v1.TestService/Order", codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testPolicy.Authorize(tt.ctx, tt.method)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("Authorize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := testPolicy.UnaryServerInterceptor()
+	called := false
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return "ok", nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.TestService/Manage"}
+	student := principal.Principal{UserID: 3, Role: principal.RoleStudent}
+	if _, err := interceptor(incoming(student), nil, info, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("student call: got %v, want PermissionDenied", err)
+	}
+	if called {
+		t.Error("handler called for a denied call")
+	}
+
+	owner := principal.Principal{UserID: 1, Role: principal.RoleOwner}
+	resp, err := interceptor(incoming(owner), nil, info, handler)
+	if err != nil || resp != "ok" {
+		t.Errorf("owner call: got %v, %v", resp, err)
+	}
+}
+
+func TestServicePrincipal(t *testing.T) {
+	// A service calling on behalf of itself replaces the user it is serving
+	user := principal.NewOutgoingContext(context.Background(), principal.Principal{UserID: 3, Role: principal.RoleStudent})
+	md, _ := metadata.FromOutgoingContext(principal.NewOutgoingContext(user, principal.Service))
+
+	caller, ok := principal.FromIncomingContext(metadata.NewIncomingContext(context.Background(), md))
+	if !ok || caller != principal.Service {
+		t.Errorf("FromIncomingContext() = %+v, %v, want the service principal", caller, ok)
+	}
+
+	// Users cannot claim to be a service
+	forged := metadata.Pairs(principal.UserIDMetadata, "3", principal.RoleMetadata, principal.RoleService)
+	if _, ok := principal.FromIncomingContext(metadata.NewIncomingContext(context.Background(), forged)); ok {
+		t.Error("a user ID with the service role was accepted")
+	}
+}
\ No newline at end of file
@@ -7,8 +7,10 @@
 package menuv1
 
 import (
+	v1 "github.com/douglasswm/student-cafe-protos/gen/go/common/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,32 +25,2355 @@ const (
 
 // MenuItem message definition
 type MenuItem struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	CreatedAt   string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Units left to sell; unset when stock is not tracked for the item
+	Stock *int32    `protobuf:"varint,7,opt,name=stock,proto3,oneof" json:"stock,omitempty"`
+	Price *v1.Money `protobuf:"bytes,8,opt,name=price,proto3" json:"price,omitempty"`
+	// Set once the item has been deleted
+	DeletedAt string `protobuf:"bytes,9,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// Category the item is listed under, 0 when it is uncategorised
+	CategoryId uint32 `protobuf:"varint,10,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// Allergens the item contains. Codes: celery, crustaceans, eggs, fish, gluten, lupin, milk,
+	// molluscs, mustard, peanuts, sesame, soy, sulphites and tree_nuts.
+	Allergens []string `protobuf:"bytes,11,rep,name=allergens,proto3" json:"allergens,omitempty"`
+	// Diets the item suits. Codes: vegetarian, vegan, halal, kosher and gluten_free.
+	DietaryTags []string `protobuf:"bytes,12,rep,name=dietary_tags,json=dietaryTags,proto3" json:"dietary_tags,omitempty"`
+	// Energy in kcal per serving; unset when it is not known
+	Calories *int32 `protobuf:"varint,13,opt,name=calories,proto3,oneof" json:"calories,omitempty"`
+	// When the item can be ordered, in the cafe's time zone. Empty means at any time.
+	Availability []*AvailabilityWindow `protobuf:"bytes,14,rep,name=availability,proto3" json:"availability,omitempty"`
+	// Set when the current time is outside the item's availability, so it cannot be ordered now.
+	// Stock is not taken into account.
+	Unavailable bool `protobuf:"varint,15,opt,name=unavailable,proto3" json:"unavailable,omitempty"`
+	// Choices offered when ordering the item, e.g. Milk or Extras, in the order they are shown
+	ModifierGroups []*ModifierGroup `protobuf:"bytes,16,rep,name=modifier_groups,json=modifierGroups,proto3" json:"modifier_groups,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MenuItem) Reset() {
+	*x = MenuItem{}
+	mi := &file_menu_v1_menu_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuItem) ProtoMessage() {}
+
+func (x *MenuItem) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuItem.ProtoReflect.Descriptor instead.
+func (*MenuItem) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MenuItem) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MenuItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MenuItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *MenuItem) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *MenuItem) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *MenuItem) GetStock() int32 {
+	if x != nil && x.Stock != nil {
+		return *x.Stock
+	}
+	return 0
+}
+
+func (x *MenuItem) GetPrice() *v1.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *MenuItem) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+func (x *MenuItem) GetCategoryId() uint32 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *MenuItem) GetAllergens() []string {
+	if x != nil {
+		return x.Allergens
+	}
+	return nil
+}
+
+func (x *MenuItem) GetDietaryTags() []string {
+	if x != nil {
+		return x.DietaryTags
+	}
+	return nil
+}
+
+func (x *MenuItem) GetCalories() int32 {
+	if x != nil && x.Calories != nil {
+		return *x.Calories
+	}
+	return 0
+}
+
+func (x *MenuItem) GetAvailability() []*AvailabilityWindow {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+func (x *MenuItem) GetUnavailable() bool {
+	if x != nil {
+		return x.Unavailable
+	}
+	return false
+}
+
+func (x *MenuItem) GetModifierGroups() []*ModifierGroup {
+	if x != nil {
+		return x.ModifierGroups
+	}
+	return nil
+}
+
+// ModifierGroup is a set of options to choose from when ordering a menu item, e.g. Milk
+type ModifierGroup struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Fewest modifiers that must be chosen; the group is required when this is above 0
+	MinSelections int32 `protobuf:"varint,3,opt,name=min_selections,json=minSelections,proto3" json:"min_selections,omitempty"`
+	// Most modifiers that may be chosen, 0 for no limit
+	MaxSelections int32       `protobuf:"varint,4,opt,name=max_selections,json=maxSelections,proto3" json:"max_selections,omitempty"`
+	Modifiers     []*Modifier `protobuf:"bytes,5,rep,name=modifiers,proto3" json:"modifiers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModifierGroup) Reset() {
+	*x = ModifierGroup{}
+	mi := &file_menu_v1_menu_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModifierGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifierGroup) ProtoMessage() {}
+
+func (x *ModifierGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifierGroup.ProtoReflect.Descriptor instead.
+func (*ModifierGroup) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ModifierGroup) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ModifierGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ModifierGroup) GetMinSelections() int32 {
+	if x != nil {
+		return x.MinSelections
+	}
+	return 0
+}
+
+func (x *ModifierGroup) GetMaxSelections() int32 {
+	if x != nil {
+		return x.MaxSelections
+	}
+	return 0
+}
+
+func (x *ModifierGroup) GetModifiers() []*Modifier {
+	if x != nil {
+		return x.Modifiers
+	}
+	return nil
+}
+
+// Modifier is a single option of a modifier group, e.g. Oat milk or No onions
+type Modifier struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Added to the item's price for each unit ordered; may be zero or negative
+	PriceDelta    *v1.Money `protobuf:"bytes,3,opt,name=price_delta,json=priceDelta,proto3" json:"price_delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Modifier) Reset() {
+	*x = Modifier{}
+	mi := &file_menu_v1_menu_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Modifier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Modifier) ProtoMessage() {}
+
+func (x *Modifier) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Modifier.ProtoReflect.Descriptor instead.
+func (*Modifier) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Modifier) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Modifier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Modifier) GetPriceDelta() *v1.Money {
+	if x != nil {
+		return x.PriceDelta
+	}
+	return nil
+}
+
+// AvailabilityWindow is a weekly time range during which a menu item can be ordered,
+// e.g. breakfast from 07:00 to 11:00 on Mondays
+type AvailabilityWindow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Day of the week in lower case, e.g. "monday"
+	Weekday string `protobuf:"bytes,1,opt,name=weekday,proto3" json:"weekday,omitempty"`
+	// Start of the window, inclusive, as "HH:MM" in the cafe's time zone
+	StartTime string `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// End of the window, exclusive, as "HH:MM"; "24:00" is the end of the day.
+	// Windows do not cross midnight; use one window per day instead.
+	EndTime       string `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AvailabilityWindow) Reset() {
+	*x = AvailabilityWindow{}
+	mi := &file_menu_v1_menu_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AvailabilityWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AvailabilityWindow) ProtoMessage() {}
+
+func (x *AvailabilityWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AvailabilityWindow.ProtoReflect.Descriptor instead.
+func (*AvailabilityWindow) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AvailabilityWindow) GetWeekday() string {
+	if x != nil {
+		return x.Weekday
+	}
+	return ""
+}
+
+func (x *AvailabilityWindow) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *AvailabilityWindow) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+// Category groups menu items, e.g. Drinks or Breakfast
+type Category struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Categories are shown in ascending position, ties broken by id
+	Position      int32  `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	CreatedAt     string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_menu_v1_menu_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Category) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Category) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *Category) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Category) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+// MenuSection is a category with the items listed under it
+type MenuSection struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset for the section of uncategorised items, which comes last
+	Category      *Category   `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	MenuItems     []*MenuItem `protobuf:"bytes,2,rep,name=menu_items,json=menuItems,proto3" json:"menu_items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuSection) Reset() {
+	*x = MenuSection{}
+	mi := &file_menu_v1_menu_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuSection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuSection) ProtoMessage() {}
+
+func (x *MenuSection) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuSection.ProtoReflect.Descriptor instead.
+func (*MenuSection) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *MenuSection) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *MenuSection) GetMenuItems() []*MenuItem {
+	if x != nil {
+		return x.MenuItems
+	}
+	return nil
+}
+
+// Get menu item request
+type GetMenuItemRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Also find deleted items, e.g. to show what a past order contained
+	IncludeDeleted bool `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetMenuItemRequest) Reset() {
+	*x = GetMenuItemRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuItemRequest) ProtoMessage() {}
+
+func (x *GetMenuItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMenuItemRequest.ProtoReflect.Descriptor instead.
+func (*GetMenuItemRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetMenuItemRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetMenuItemRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+// Get menu item response
+type GetMenuItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMenuItemResponse) Reset() {
+	*x = GetMenuItemResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuItemResponse) ProtoMessage() {}
+
+func (x *GetMenuItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMenuItemResponse.ProtoReflect.Descriptor instead.
+func (*GetMenuItemResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetMenuItemResponse) GetMenuItem() *MenuItem {
+	if x != nil {
+		return x.MenuItem
+	}
+	return nil
+}
+
+// Batch get menu items request
+type BatchGetMenuItemsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Ids   []uint32               `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	// Also find deleted items; otherwise they are reported as missing
+	IncludeDeleted bool `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BatchGetMenuItemsRequest) Reset() {
+	*x = BatchGetMenuItemsRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetMenuItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetMenuItemsRequest) ProtoMessage() {}
+
+func (x *BatchGetMenuItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetMenuItemsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetMenuItemsRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BatchGetMenuItemsRequest) GetIds() []uint32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BatchGetMenuItemsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+// Batch get menu items response
+type BatchGetMenuItemsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Items that were found, in the order they were first requested
+	MenuItems []*MenuItem `protobuf:"bytes,1,rep,name=menu_items,json=menuItems,proto3" json:"menu_items,omitempty"`
+	// Requested IDs that do not exist
+	MissingIds    []uint32 `protobuf:"varint,2,rep,packed,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetMenuItemsResponse) Reset() {
+	*x = BatchGetMenuItemsResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetMenuItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetMenuItemsResponse) ProtoMessage() {}
+
+func (x *BatchGetMenuItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetMenuItemsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetMenuItemsResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BatchGetMenuItemsResponse) GetMenuItems() []*MenuItem {
+	if x != nil {
+		return x.MenuItems
+	}
+	return nil
+}
+
+func (x *BatchGetMenuItemsResponse) GetMissingIds() []uint32 {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
+// Get menu request. All filters are optional and combined with AND.
+type GetMenuRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Return the items in sections, one per category in menu order, instead of as a flat list.
+	// Cannot be combined with pagination.
+	GroupByCategory bool `protobuf:"varint,1,opt,name=group_by_category,json=groupByCategory,proto3" json:"group_by_category,omitempty"`
+	// Free-text search over item names and descriptions
+	Query string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	// Only items priced at or above this amount; the currency must be empty or the menu's currency
+	MinPrice *v1.Money `protobuf:"bytes,3,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	// Only items priced at or below this amount; the currency must be empty or the menu's currency
+	MaxPrice *v1.Money `protobuf:"bytes,4,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	// Only items listed under this category (0 means any category)
+	CategoryId uint32 `protobuf:"varint,5,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// Only items that can be ordered now, i.e. that do not track stock or have stock left
+	AvailableOnly bool `protobuf:"varint,6,opt,name=available_only,json=availableOnly,proto3" json:"available_only,omitempty"`
+	// Sort order: "id" (default), "name", "price" or "created_at", optionally followed by " desc"
+	OrderBy string `protobuf:"bytes,7,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// Maximum number of items to return, capped at 100. 0 returns every matching item.
+	PageSize int32 `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous response's next_page_token; the other fields must not change between pages
+	PageToken string `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Only items that contain none of these allergens
+	ExcludeAllergens []string `protobuf:"bytes,10,rep,name=exclude_allergens,json=excludeAllergens,proto3" json:"exclude_allergens,omitempty"`
+	// Only items that carry all of these dietary tags
+	DietaryTags []string `protobuf:"bytes,11,rep,name=dietary_tags,json=dietaryTags,proto3" json:"dietary_tags,omitempty"`
+	// Only items known to have at most this many kcal
+	MaxCalories *int32 `protobuf:"varint,12,opt,name=max_calories,json=maxCalories,proto3,oneof" json:"max_calories,omitempty"`
+	// Only items whose availability includes the current time, i.e. that can be ordered now if in stock
+	AvailableNow  bool `protobuf:"varint,13,opt,name=available_now,json=availableNow,proto3" json:"available_now,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMenuRequest) Reset() {
+	*x = GetMenuRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuRequest) ProtoMessage() {}
+
+func (x *GetMenuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMenuRequest.ProtoReflect.Descriptor instead.
+func (*GetMenuRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetMenuRequest) GetGroupByCategory() bool {
+	if x != nil {
+		return x.GroupByCategory
+	}
+	return false
+}
+
+func (x *GetMenuRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *GetMenuRequest) GetMinPrice() *v1.Money {
+	if x != nil {
+		return x.MinPrice
+	}
+	return nil
+}
+
+func (x *GetMenuRequest) GetMaxPrice() *v1.Money {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return nil
+}
+
+func (x *GetMenuRequest) GetCategoryId() uint32 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *GetMenuRequest) GetAvailableOnly() bool {
+	if x != nil {
+		return x.AvailableOnly
+	}
+	return false
+}
+
+func (x *GetMenuRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *GetMenuRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetMenuRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetMenuRequest) GetExcludeAllergens() []string {
+	if x != nil {
+		return x.ExcludeAllergens
+	}
+	return nil
+}
+
+func (x *GetMenuRequest) GetDietaryTags() []string {
+	if x != nil {
+		return x.DietaryTags
+	}
+	return nil
+}
+
+func (x *GetMenuRequest) GetMaxCalories() int32 {
+	if x != nil && x.MaxCalories != nil {
+		return *x.MaxCalories
+	}
+	return 0
+}
+
+func (x *GetMenuRequest) GetAvailableNow() bool {
+	if x != nil {
+		return x.AvailableNow
+	}
+	return false
+}
+
+// Get menu response
+type GetMenuResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Matching items, unless they were grouped by category
+	MenuItems []*MenuItem `protobuf:"bytes,1,rep,name=menu_items,json=menuItems,proto3" json:"menu_items,omitempty"`
+	// Matching items grouped by category, when requested. Empty categories are
+	// included unless the request filtered the menu.
+	Sections []*MenuSection `protobuf:"bytes,2,rep,name=sections,proto3" json:"sections,omitempty"`
+	// Token for the next page, empty when there are no more items
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Menu revision this response reflects. Pass it to WatchMenu to follow the changes made after it.
+	Revision      uint64 `protobuf:"varint,4,opt,name=revision,proto3" json:"revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMenuResponse) Reset() {
+	*x = GetMenuResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMenuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMenuResponse) ProtoMessage() {}
+
+func (x *GetMenuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMenuResponse.ProtoReflect.Descriptor instead.
+func (*GetMenuResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetMenuResponse) GetMenuItems() []*MenuItem {
+	if x != nil {
+		return x.MenuItems
+	}
+	return nil
+}
+
+func (x *GetMenuResponse) GetSections() []*MenuSection {
+	if x != nil {
+		return x.Sections
+	}
+	return nil
+}
+
+func (x *GetMenuResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetMenuResponse) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+// Create menu item request
+type CreateMenuItemRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Initial stock; leave unset to not track stock for the item
+	Stock *int32 `protobuf:"varint,4,opt,name=stock,proto3,oneof" json:"stock,omitempty"`
+	// Required; the currency defaults to the menu's currency when left empty
+	Price *v1.Money `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	// Category to list the item under, 0 for none
+	CategoryId uint32 `protobuf:"varint,6,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// Allergen codes, see MenuItem.allergens. Unknown codes are rejected.
+	Allergens []string `protobuf:"bytes,7,rep,name=allergens,proto3" json:"allergens,omitempty"`
+	// Dietary tag codes, see MenuItem.dietary_tags. Unknown codes are rejected.
+	DietaryTags []string `protobuf:"bytes,8,rep,name=dietary_tags,json=dietaryTags,proto3" json:"dietary_tags,omitempty"`
+	// Energy in kcal per serving; leave unset when it is not known
+	Calories *int32 `protobuf:"varint,9,opt,name=calories,proto3,oneof" json:"calories,omitempty"`
+	// When the item can be ordered; leave empty for at any time
+	Availability []*AvailabilityWindow `protobuf:"bytes,10,rep,name=availability,proto3" json:"availability,omitempty"`
+	// Choices offered when ordering the item. IDs are assigned by the service.
+	ModifierGroups []*ModifierGroup `protobuf:"bytes,11,rep,name=modifier_groups,json=modifierGroups,proto3" json:"modifier_groups,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateMenuItemRequest) Reset() {
+	*x = CreateMenuItemRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMenuItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMenuItemRequest) ProtoMessage() {}
+
+func (x *CreateMenuItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMenuItemRequest.ProtoReflect.Descriptor instead.
+func (*CreateMenuItemRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateMenuItemRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateMenuItemRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateMenuItemRequest) GetStock() int32 {
+	if x != nil && x.Stock != nil {
+		return *x.Stock
+	}
+	return 0
+}
+
+func (x *CreateMenuItemRequest) GetPrice() *v1.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *CreateMenuItemRequest) GetCategoryId() uint32 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *CreateMenuItemRequest) GetAllergens() []string {
+	if x != nil {
+		return x.Allergens
+	}
+	return nil
+}
+
+func (x *CreateMenuItemRequest) GetDietaryTags() []string {
+	if x != nil {
+		return x.DietaryTags
+	}
+	return nil
+}
+
+func (x *CreateMenuItemRequest) GetCalories() int32 {
+	if x != nil && x.Calories != nil {
+		return *x.Calories
+	}
+	return 0
+}
+
+func (x *CreateMenuItemRequest) GetAvailability() []*AvailabilityWindow {
+	if x != nil {
+		return x.Availability
+	}
+	return nil
+}
+
+func (x *CreateMenuItemRequest) GetModifierGroups() []*ModifierGroup {
+	if x != nil {
+		return x.ModifierGroups
+	}
+	return nil
+}
+
+// Create menu item response
+type CreateMenuItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateMenuItemResponse) Reset() {
+	*x = CreateMenuItemResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMenuItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMenuItemResponse) ProtoMessage() {}
+
+func (x *CreateMenuItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMenuItemResponse.ProtoReflect.Descriptor instead.
+func (*CreateMenuItemResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateMenuItemResponse) GetMenuItem() *MenuItem {
+	if x != nil {
+		return x.MenuItem
+	}
+	return nil
+}
+
+// Update menu item request
+type UpdateMenuItemRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The item to update, identified by id, holding the new values of the fields in update_mask
+	MenuItem *MenuItem `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	// Fields to update: name, description, price, stock, category_id, allergens, dietary_tags,
+	// calories, availability and modifier_groups. Updating stock to unset stops tracking it, updating
+	// category_id to 0 leaves the item uncategorised, updating calories to unset clears it, and
+	// availability and modifier_groups are replaced as a whole.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMenuItemRequest) Reset() {
+	*x = UpdateMenuItemRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMenuItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMenuItemRequest) ProtoMessage() {}
+
+func (x *UpdateMenuItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMenuItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMenuItemRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateMenuItemRequest) GetMenuItem() *MenuItem {
+	if x != nil {
+		return x.MenuItem
+	}
+	return nil
+}
+
+func (x *UpdateMenuItemRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+// Update menu item response
+type UpdateMenuItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateMenuItemResponse) Reset() {
+	*x = UpdateMenuItemResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateMenuItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMenuItemResponse) ProtoMessage() {}
+
+func (x *UpdateMenuItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMenuItemResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMenuItemResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateMenuItemResponse) GetMenuItem() *MenuItem {
+	if x != nil {
+		return x.MenuItem
+	}
+	return nil
+}
+
+// Delete menu item request
+type DeleteMenuItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMenuItemRequest) Reset() {
+	*x = DeleteMenuItemRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMenuItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMenuItemRequest) ProtoMessage() {}
+
+func (x *DeleteMenuItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMenuItemRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMenuItemRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteMenuItemRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Delete menu item response
+type DeleteMenuItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMenuItemResponse) Reset() {
+	*x = DeleteMenuItemResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMenuItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMenuItemResponse) ProtoMessage() {}
+
+func (x *DeleteMenuItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMenuItemResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMenuItemResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{17}
+}
+
+// PriceChange is an entry in a menu item's price history
+type PriceChange struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	MenuItemId uint32                 `protobuf:"varint,2,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
+	Price      *v1.Money              `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	// When the price applies from, in RFC 3339 format
+	EffectiveFrom string `protobuf:"bytes,4,opt,name=effective_from,json=effectiveFrom,proto3" json:"effective_from,omitempty"`
+	CreatedAt     string `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceChange) Reset() {
+	*x = PriceChange{}
+	mi := &file_menu_v1_menu_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChange) ProtoMessage() {}
+
+func (x *PriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChange.ProtoReflect.Descriptor instead.
+func (*PriceChange) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PriceChange) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PriceChange) GetMenuItemId() uint32 {
+	if x != nil {
+		return x.MenuItemId
+	}
+	return 0
+}
+
+func (x *PriceChange) GetPrice() *v1.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *PriceChange) GetEffectiveFrom() string {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return ""
+}
+
+func (x *PriceChange) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Get price history request
+type GetPriceHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Deleted items have a history too, e.g. to explain a past order
+	MenuItemId    uint32 `protobuf:"varint,1,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryRequest) Reset() {
+	*x = GetPriceHistoryRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryRequest) ProtoMessage() {}
+
+func (x *GetPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetPriceHistoryRequest) GetMenuItemId() uint32 {
+	if x != nil {
+		return x.MenuItemId
+	}
+	return 0
+}
+
+// Get price history response
+type GetPriceHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Oldest first by effective_from, including scheduled changes. Of two
+	// changes effective at the same time, the later one applies.
+	PriceChanges  []*PriceChange `protobuf:"bytes,1,rep,name=price_changes,json=priceChanges,proto3" json:"price_changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPriceHistoryResponse) Reset() {
+	*x = GetPriceHistoryResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPriceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryResponse) ProtoMessage() {}
+
+func (x *GetPriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetPriceHistoryResponse) GetPriceChanges() []*PriceChange {
+	if x != nil {
+		return x.PriceChanges
+	}
+	return nil
+}
+
+// Schedule price change request
+type SchedulePriceChangeRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	MenuItemId uint32                 `protobuf:"varint,1,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
+	Price      *v1.Money              `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	// When the new price applies from, in RFC 3339 format. Must be in the future.
+	EffectiveFrom string `protobuf:"bytes,3,opt,name=effective_from,json=effectiveFrom,proto3" json:"effective_from,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchedulePriceChangeRequest) Reset() {
+	*x = SchedulePriceChangeRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulePriceChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulePriceChangeRequest) ProtoMessage() {}
+
+func (x *SchedulePriceChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulePriceChangeRequest.ProtoReflect.Descriptor instead.
+func (*SchedulePriceChangeRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SchedulePriceChangeRequest) GetMenuItemId() uint32 {
+	if x != nil {
+		return x.MenuItemId
+	}
+	return 0
+}
+
+func (x *SchedulePriceChangeRequest) GetPrice() *v1.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *SchedulePriceChangeRequest) GetEffectiveFrom() string {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return ""
+}
+
+// Schedule price change response
+type SchedulePriceChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceChange   *PriceChange           `protobuf:"bytes,1,opt,name=price_change,json=priceChange,proto3" json:"price_change,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchedulePriceChangeResponse) Reset() {
+	*x = SchedulePriceChangeResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchedulePriceChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulePriceChangeResponse) ProtoMessage() {}
+
+func (x *SchedulePriceChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulePriceChangeResponse.ProtoReflect.Descriptor instead.
+func (*SchedulePriceChangeResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SchedulePriceChangeResponse) GetPriceChange() *PriceChange {
+	if x != nil {
+		return x.PriceChange
+	}
+	return nil
+}
+
+// Watch menu request
+type WatchMenuRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Replay the changes made after this revision before streaming new ones.
+	// When unset, only changes made after the call starts are streamed.
+	SinceRevision *uint64 `protobuf:"varint,1,opt,name=since_revision,json=sinceRevision,proto3,oneof" json:"since_revision,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMenuRequest) Reset() {
+	*x = WatchMenuRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMenuRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMenuRequest) ProtoMessage() {}
+
+func (x *WatchMenuRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMenuRequest.ProtoReflect.Descriptor instead.
+func (*WatchMenuRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WatchMenuRequest) GetSinceRevision() uint64 {
+	if x != nil && x.SinceRevision != nil {
+		return *x.SinceRevision
+	}
+	return 0
+}
+
+// Watch menu response, sent once per change
+type WatchMenuResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Event         *MenuEvent             `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMenuResponse) Reset() {
+	*x = WatchMenuResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMenuResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMenuResponse) ProtoMessage() {}
+
+func (x *WatchMenuResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMenuResponse.ProtoReflect.Descriptor instead.
+func (*WatchMenuResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *WatchMenuResponse) GetEvent() *MenuEvent {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+// MenuEvent describes one change to a menu item
+type MenuEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Increases with every change to the menu; resume a watch from here
+	Revision uint64 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	// "created", "updated", "deleted" or "sold_out"
+	Type       string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	MenuItemId uint32 `protobuf:"varint,3,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
+	// The item as it was right after the change
+	MenuItem      *MenuItem `protobuf:"bytes,4,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	CreatedAt     string    `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MenuEvent) Reset() {
+	*x = MenuEvent{}
+	mi := &file_menu_v1_menu_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MenuEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MenuEvent) ProtoMessage() {}
+
+func (x *MenuEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MenuEvent.ProtoReflect.Descriptor instead.
+func (*MenuEvent) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *MenuEvent) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *MenuEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MenuEvent) GetMenuItemId() uint32 {
+	if x != nil {
+		return x.MenuItemId
+	}
+	return 0
+}
+
+func (x *MenuEvent) GetMenuItem() *MenuItem {
+	if x != nil {
+		return x.MenuItem
+	}
+	return nil
+}
+
+func (x *MenuEvent) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// Import menu items request. The stream may start with options, followed by one message per row.
+type ImportMenuItemsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ImportMenuItemsRequest_Options
+	//	*ImportMenuItemsRequest_MenuItem
+	Payload       isImportMenuItemsRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportMenuItemsRequest) Reset() {
+	*x = ImportMenuItemsRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportMenuItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportMenuItemsRequest) ProtoMessage() {}
+
+func (x *ImportMenuItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportMenuItemsRequest.ProtoReflect.Descriptor instead.
+func (*ImportMenuItemsRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ImportMenuItemsRequest) GetPayload() isImportMenuItemsRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportMenuItemsRequest) GetOptions() *ImportOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportMenuItemsRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportMenuItemsRequest) GetMenuItem() *CreateMenuItemRequest {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportMenuItemsRequest_MenuItem); ok {
+			return x.MenuItem
+		}
+	}
+	return nil
+}
+
+type isImportMenuItemsRequest_Payload interface {
+	isImportMenuItemsRequest_Payload()
+}
+
+type ImportMenuItemsRequest_Options struct {
+	// Only allowed as the first message
+	Options *ImportOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportMenuItemsRequest_MenuItem struct {
+	// A row, validated like CreateMenuItemRequest. Its name is required.
+	MenuItem *CreateMenuItemRequest `protobuf:"bytes,2,opt,name=menu_item,json=menuItem,proto3,oneof"`
+}
+
+func (*ImportMenuItemsRequest_Options) isImportMenuItemsRequest_Payload() {}
+
+func (*ImportMenuItemsRequest_MenuItem) isImportMenuItemsRequest_Payload() {}
+
+// ImportOptions control how an import is applied
+type ImportOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Validate every row and report what would happen without saving anything
+	DryRun bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Rows named like an existing item replace all of that item's fields instead of creating a new item
+	UpsertByName  bool `protobuf:"varint,2,opt,name=upsert_by_name,json=upsertByName,proto3" json:"upsert_by_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_menu_v1_menu_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportOptions) ProtoMessage() {}
+
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ImportOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportOptions) GetUpsertByName() bool {
+	if x != nil {
+		return x.UpsertByName
+	}
+	return false
+}
+
+// ImportRowResult reports what happened to one row of an import
+type ImportRowResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Position of the row in the stream, starting at 1
+	Row  int32  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// "created", "updated" or "failed"
+	Action string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	// Why the row failed
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// Item the row was saved as; 0 for failed rows, and for created rows that were not committed
+	MenuItemId    uint32 `protobuf:"varint,5,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowResult) Reset() {
+	*x = ImportRowResult{}
+	mi := &file_menu_v1_menu_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowResult) ProtoMessage() {}
+
+func (x *ImportRowResult) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowResult.ProtoReflect.Descriptor instead.
+func (*ImportRowResult) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ImportRowResult) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportRowResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ImportRowResult) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ImportRowResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ImportRowResult) GetMenuItemId() uint32 {
+	if x != nil {
+		return x.MenuItemId
+	}
+	return 0
+}
+
+// Import menu items response
+type ImportMenuItemsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per row, in stream order
+	Results []*ImportRowResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Created int32              `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Updated int32              `protobuf:"varint,3,opt,name=updated,proto3" json:"updated,omitempty"`
+	Failed  int32              `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	// Whether the rows were saved: false for a dry run, or when any row failed
+	Committed     bool `protobuf:"varint,5,opt,name=committed,proto3" json:"committed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportMenuItemsResponse) Reset() {
+	*x = ImportMenuItemsResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportMenuItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportMenuItemsResponse) ProtoMessage() {}
+
+func (x *ImportMenuItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportMenuItemsResponse.ProtoReflect.Descriptor instead.
+func (*ImportMenuItemsResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ImportMenuItemsResponse) GetResults() []*ImportRowResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ImportMenuItemsResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportMenuItemsResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportMenuItemsResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *ImportMenuItemsResponse) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+// StockLine is a quantity of a single menu item
+type StockLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MenuItemId    uint32                 `protobuf:"varint,1,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockLine) Reset() {
+	*x = StockLine{}
+	mi := &file_menu_v1_menu_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockLine) ProtoMessage() {}
+
+func (x *StockLine) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockLine.ProtoReflect.Descriptor instead.
+func (*StockLine) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *StockLine) GetMenuItemId() uint32 {
+	if x != nil {
+		return x.MenuItemId
+	}
+	return 0
+}
+
+func (x *StockLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// Reserve stock request
+type ReserveStockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Caller-chosen ID used to release or commit the reservation later.
+	// Reserving again with the same ID is a no-op.
+	ReservationId string       `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	Lines         []*StockLine `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockRequest) Reset() {
+	*x = ReserveStockRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockRequest) ProtoMessage() {}
+
+func (x *ReserveStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockRequest.ProtoReflect.Descriptor instead.
+func (*ReserveStockRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ReserveStockRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ReserveStockRequest) GetLines() []*StockLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+// Reserve stock response
+type ReserveStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveStockResponse) Reset() {
+	*x = ReserveStockResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveStockResponse) ProtoMessage() {}
+
+func (x *ReserveStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveStockResponse.ProtoReflect.Descriptor instead.
+func (*ReserveStockResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{32}
+}
+
+// Release stock request
+type ReleaseStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseStockRequest) Reset() {
+	*x = ReleaseStockRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseStockRequest) ProtoMessage() {}
+
+func (x *ReleaseStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseStockRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseStockRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ReleaseStockRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+// Release stock response
+type ReleaseStockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseStockResponse) Reset() {
+	*x = ReleaseStockResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseStockResponse) ProtoMessage() {}
+
+func (x *ReleaseStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseStockResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseStockResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{34}
+}
+
+// Commit stock request
+type CommitStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitStockRequest) Reset() {
+	*x = CommitStockRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitStockRequest) ProtoMessage() {}
+
+func (x *CommitStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitStockRequest.ProtoReflect.Descriptor instead.
+func (*CommitStockRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CommitStockRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+// Commit stock response
+type CommitStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MenuItem) Reset() {
-	*x = MenuItem{}
-	mi := &file_menu_v1_menu_proto_msgTypes[0]
+func (x *CommitStockResponse) Reset() {
+	*x = CommitStockResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MenuItem) String() string {
+func (x *CommitStockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MenuItem) ProtoMessage() {}
+func (*CommitStockResponse) ProtoMessage() {}
 
-func (x *MenuItem) ProtoReflect() protoreflect.Message {
-	mi := &file_menu_v1_menu_proto_msgTypes[0]
+func (x *CommitStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -59,76 +2384,141 @@ func (x *MenuItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MenuItem.ProtoReflect.Descriptor instead.
-func (*MenuItem) Descriptor() ([]byte, []int) {
-	return file_menu_v1_menu_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use CommitStockResponse.ProtoReflect.Descriptor instead.
+func (*CommitStockResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *MenuItem) GetId() uint32 {
+// Create category request
+type CreateCategoryRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Position in the menu; leave unset to add the category after the existing ones
+	Position      *int32 `protobuf:"varint,3,opt,name=position,proto3,oneof" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryRequest) Reset() {
+	*x = CreateCategoryRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryRequest) ProtoMessage() {}
+
+func (x *CreateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[37]
 	if x != nil {
-		return x.Id
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *MenuItem) GetName() string {
+// Deprecated: Use CreateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CreateCategoryRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *MenuItem) GetDescription() string {
+func (x *CreateCategoryRequest) GetDescription() string {
 	if x != nil {
 		return x.Description
 	}
 	return ""
 }
 
-func (x *MenuItem) GetPrice() float64 {
-	if x != nil {
-		return x.Price
+func (x *CreateCategoryRequest) GetPosition() int32 {
+	if x != nil && x.Position != nil {
+		return *x.Position
 	}
 	return 0
 }
 
-func (x *MenuItem) GetCreatedAt() string {
+// Create category response
+type CreateCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCategoryResponse) Reset() {
+	*x = CreateCategoryResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCategoryResponse) ProtoMessage() {}
+
+func (x *CreateCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[38]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *MenuItem) GetUpdatedAt() string {
+// Deprecated: Use CreateCategoryResponse.ProtoReflect.Descriptor instead.
+func (*CreateCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CreateCategoryResponse) GetCategory() *Category {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Category
 	}
-	return ""
+	return nil
 }
 
-// Get menu item request
-type GetMenuItemRequest struct {
+// Get category request
+type GetCategoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMenuItemRequest) Reset() {
-	*x = GetMenuItemRequest{}
-	mi := &file_menu_v1_menu_proto_msgTypes[1]
+func (x *GetCategoryRequest) Reset() {
+	*x = GetCategoryRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMenuItemRequest) String() string {
+func (x *GetCategoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMenuItemRequest) ProtoMessage() {}
+func (*GetCategoryRequest) ProtoMessage() {}
 
-func (x *GetMenuItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_menu_v1_menu_proto_msgTypes[1]
+func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -139,41 +2529,41 @@ func (x *GetMenuItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMenuItemRequest.ProtoReflect.Descriptor instead.
-func (*GetMenuItemRequest) Descriptor() ([]byte, []int) {
-	return file_menu_v1_menu_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use GetCategoryRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *GetMenuItemRequest) GetId() uint32 {
+func (x *GetCategoryRequest) GetId() uint32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-// Get menu item response
-type GetMenuItemResponse struct {
+// Get category response
+type GetCategoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMenuItemResponse) Reset() {
-	*x = GetMenuItemResponse{}
-	mi := &file_menu_v1_menu_proto_msgTypes[2]
+func (x *GetCategoryResponse) Reset() {
+	*x = GetCategoryResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMenuItemResponse) String() string {
+func (x *GetCategoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMenuItemResponse) ProtoMessage() {}
+func (*GetCategoryResponse) ProtoMessage() {}
 
-func (x *GetMenuItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_menu_v1_menu_proto_msgTypes[2]
+func (x *GetCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -184,40 +2574,40 @@ func (x *GetMenuItemResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMenuItemResponse.ProtoReflect.Descriptor instead.
-func (*GetMenuItemResponse) Descriptor() ([]byte, []int) {
-	return file_menu_v1_menu_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use GetCategoryResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *GetMenuItemResponse) GetMenuItem() *MenuItem {
+func (x *GetCategoryResponse) GetCategory() *Category {
 	if x != nil {
-		return x.MenuItem
+		return x.Category
 	}
 	return nil
 }
 
-// Get menu request (empty for now, can add filtering later)
-type GetMenuRequest struct {
+// List categories request
+type ListCategoriesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMenuRequest) Reset() {
-	*x = GetMenuRequest{}
-	mi := &file_menu_v1_menu_proto_msgTypes[3]
+func (x *ListCategoriesRequest) Reset() {
+	*x = ListCategoriesRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMenuRequest) String() string {
+func (x *ListCategoriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMenuRequest) ProtoMessage() {}
+func (*ListCategoriesRequest) ProtoMessage() {}
 
-func (x *GetMenuRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_menu_v1_menu_proto_msgTypes[3]
+func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -228,34 +2618,34 @@ func (x *GetMenuRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMenuRequest.ProtoReflect.Descriptor instead.
-func (*GetMenuRequest) Descriptor() ([]byte, []int) {
-	return file_menu_v1_menu_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{41}
 }
 
-// Get menu response
-type GetMenuResponse struct {
+// List categories response
+type ListCategoriesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MenuItems     []*MenuItem            `protobuf:"bytes,1,rep,name=menu_items,json=menuItems,proto3" json:"menu_items,omitempty"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMenuResponse) Reset() {
-	*x = GetMenuResponse{}
-	mi := &file_menu_v1_menu_proto_msgTypes[4]
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMenuResponse) String() string {
+func (x *ListCategoriesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMenuResponse) ProtoMessage() {}
+func (*ListCategoriesResponse) ProtoMessage() {}
 
-func (x *GetMenuResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_menu_v1_menu_proto_msgTypes[4]
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -266,43 +2656,44 @@ func (x *GetMenuResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMenuResponse.ProtoReflect.Descriptor instead.
-func (*GetMenuResponse) Descriptor() ([]byte, []int) {
-	return file_menu_v1_menu_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *GetMenuResponse) GetMenuItems() []*MenuItem {
+func (x *ListCategoriesResponse) GetCategories() []*Category {
 	if x != nil {
-		return x.MenuItems
+		return x.Categories
 	}
 	return nil
 }
 
-// Create menu item request
-type CreateMenuItemRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+// Update category request
+type UpdateCategoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The category to update, identified by id, holding the new values of the fields in update_mask
+	Category *Category `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	// Fields to update: name, description and position
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateMenuItemRequest) Reset() {
-	*x = CreateMenuItemRequest{}
-	mi := &file_menu_v1_menu_proto_msgTypes[5]
+func (x *UpdateCategoryRequest) Reset() {
+	*x = UpdateCategoryRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateMenuItemRequest) String() string {
+func (x *UpdateCategoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateMenuItemRequest) ProtoMessage() {}
+func (*UpdateCategoryRequest) ProtoMessage() {}
 
-func (x *CreateMenuItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_menu_v1_menu_proto_msgTypes[5]
+func (x *UpdateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -313,55 +2704,93 @@ func (x *CreateMenuItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateMenuItemRequest.ProtoReflect.Descriptor instead.
-func (*CreateMenuItemRequest) Descriptor() ([]byte, []int) {
-	return file_menu_v1_menu_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use UpdateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *CreateMenuItemRequest) GetName() string {
+func (x *UpdateCategoryRequest) GetCategory() *Category {
 	if x != nil {
-		return x.Name
+		return x.Category
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateMenuItemRequest) GetDescription() string {
+func (x *UpdateCategoryRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
 	if x != nil {
-		return x.Description
+		return x.UpdateMask
 	}
-	return ""
+	return nil
+}
+
+// Update category response
+type UpdateCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCategoryResponse) Reset() {
+	*x = UpdateCategoryResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateMenuItemRequest) GetPrice() float64 {
+func (x *UpdateCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCategoryResponse) ProtoMessage() {}
+
+func (x *UpdateCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[44]
 	if x != nil {
-		return x.Price
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-// Create menu item response
-type CreateMenuItemResponse struct {
+// Deprecated: Use UpdateCategoryResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *UpdateCategoryResponse) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+// Delete category request
+type DeleteCategoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MenuItem      *MenuItem              `protobuf:"bytes,1,opt,name=menu_item,json=menuItem,proto3" json:"menu_item,omitempty"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateMenuItemResponse) Reset() {
-	*x = CreateMenuItemResponse{}
-	mi := &file_menu_v1_menu_proto_msgTypes[6]
+func (x *DeleteCategoryRequest) Reset() {
+	*x = DeleteCategoryRequest{}
+	mi := &file_menu_v1_menu_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateMenuItemResponse) String() string {
+func (x *DeleteCategoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateMenuItemResponse) ProtoMessage() {}
+func (*DeleteCategoryRequest) ProtoMessage() {}
 
-func (x *CreateMenuItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_menu_v1_menu_proto_msgTypes[6]
+func (x *DeleteCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -372,50 +2801,286 @@ func (x *CreateMenuItemResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateMenuItemResponse.ProtoReflect.Descriptor instead.
-func (*CreateMenuItemResponse) Descriptor() ([]byte, []int) {
-	return file_menu_v1_menu_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use DeleteCategoryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *CreateMenuItemResponse) GetMenuItem() *MenuItem {
+func (x *DeleteCategoryRequest) GetId() uint32 {
 	if x != nil {
-		return x.MenuItem
+		return x.Id
 	}
-	return nil
+	return 0
+}
+
+// Delete category response
+type DeleteCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCategoryResponse) Reset() {
+	*x = DeleteCategoryResponse{}
+	mi := &file_menu_v1_menu_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCategoryResponse) ProtoMessage() {}
+
+func (x *DeleteCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_menu_v1_menu_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCategoryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_menu_v1_menu_proto_rawDescGZIP(), []int{46}
 }
 
 var File_menu_v1_menu_proto protoreflect.FileDescriptor
 
 const file_menu_v1_menu_proto_rawDesc = "" +
 	"\n" +
-	"\x12menu/v1/menu.proto\x12\amenu.v1\"\xa4\x01\n" +
+	"\x12menu/v1/menu.proto\x12\amenu.v1\x1a\x15common/v1/money.proto\x1a google/protobuf/field_mask.proto\"\xb4\x04\n" +
 	"\bMenuItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x1d\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAt\x12\x19\n" +
+	"\x05stock\x18\a \x01(\x05H\x00R\x05stock\x88\x01\x01\x12&\n" +
+	"\x05price\x18\b \x01(\v2\x10.common.v1.MoneyR\x05price\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\t \x01(\tR\tdeletedAt\x12\x1f\n" +
+	"\vcategory_id\x18\n" +
+	" \x01(\rR\n" +
+	"categoryId\x12\x1c\n" +
+	"\tallergens\x18\v \x03(\tR\tallergens\x12!\n" +
+	"\fdietary_tags\x18\f \x03(\tR\vdietaryTags\x12\x1f\n" +
+	"\bcalories\x18\r \x01(\x05H\x01R\bcalories\x88\x01\x01\x12?\n" +
+	"\favailability\x18\x0e \x03(\v2\x1b.menu.v1.AvailabilityWindowR\favailability\x12 \n" +
+	"\vunavailable\x18\x0f \x01(\bR\vunavailable\x12?\n" +
+	"\x0fmodifier_groups\x18\x10 \x03(\v2\x16.menu.v1.ModifierGroupR\x0emodifierGroupsB\b\n" +
+	"\x06_stockB\v\n" +
+	"\t_caloriesJ\x04\b\x04\x10\x05\"\xb2\x01\n" +
+	"\rModifierGroup\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\x0emin_selections\x18\x03 \x01(\x05R\rminSelections\x12%\n" +
+	"\x0emax_selections\x18\x04 \x01(\x05R\rmaxSelections\x12/\n" +
+	"\tmodifiers\x18\x05 \x03(\v2\x11.menu.v1.ModifierR\tmodifiers\"a\n" +
+	"\bModifier\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x121\n" +
+	"\vprice_delta\x18\x03 \x01(\v2\x10.common.v1.MoneyR\n" +
+	"priceDelta\"h\n" +
+	"\x12AvailabilityWindow\x12\x18\n" +
+	"\aweekday\x18\x01 \x01(\tR\aweekday\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\tR\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x03 \x01(\tR\aendTime\"\xaa\x01\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bposition\x18\x04 \x01(\x05R\bposition\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\tR\tupdatedAt\"$\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAt\"n\n" +
+	"\vMenuSection\x12-\n" +
+	"\bcategory\x18\x01 \x01(\v2\x11.menu.v1.CategoryR\bcategory\x120\n" +
+	"\n" +
+	"menu_items\x18\x02 \x03(\v2\x11.menu.v1.MenuItemR\tmenuItems\"M\n" +
 	"\x12GetMenuItemRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\rR\x02id\"E\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12'\n" +
+	"\x0finclude_deleted\x18\x02 \x01(\bR\x0eincludeDeleted\"E\n" +
 	"\x13GetMenuItemResponse\x12.\n" +
-	"\tmenu_item\x18\x01 \x01(\v2\x11.menu.v1.MenuItemR\bmenuItem\"\x10\n" +
-	"\x0eGetMenuRequest\"C\n" +
+	"\tmenu_item\x18\x01 \x01(\v2\x11.menu.v1.MenuItemR\bmenuItem\"U\n" +
+	"\x18BatchGetMenuItemsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\rR\x03ids\x12'\n" +
+	"\x0finclude_deleted\x18\x02 \x01(\bR\x0eincludeDeleted\"n\n" +
+	"\x19BatchGetMenuItemsResponse\x120\n" +
+	"\n" +
+	"menu_items\x18\x01 \x03(\v2\x11.menu.v1.MenuItemR\tmenuItems\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\rR\n" +
+	"missingIds\"\xfd\x03\n" +
+	"\x0eGetMenuRequest\x12*\n" +
+	"\x11group_by_category\x18\x01 \x01(\bR\x0fgroupByCategory\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12-\n" +
+	"\tmin_price\x18\x03 \x01(\v2\x10.common.v1.MoneyR\bminPrice\x12-\n" +
+	"\tmax_price\x18\x04 \x01(\v2\x10.common.v1.MoneyR\bmaxPrice\x12\x1f\n" +
+	"\vcategory_id\x18\x05 \x01(\rR\n" +
+	"categoryId\x12%\n" +
+	"\x0eavailable_only\x18\x06 \x01(\bR\ravailableOnly\x12\x19\n" +
+	"\border_by\x18\a \x01(\tR\aorderBy\x12\x1b\n" +
+	"\tpage_size\x18\b \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tR\tpageToken\x12+\n" +
+	"\x11exclude_allergens\x18\n" +
+	" \x03(\tR\x10excludeAllergens\x12!\n" +
+	"\fdietary_tags\x18\v \x03(\tR\vdietaryTags\x12&\n" +
+	"\fmax_calories\x18\f \x01(\x05H\x00R\vmaxCalories\x88\x01\x01\x12#\n" +
+	"\ravailable_now\x18\r \x01(\bR\favailableNowB\x0f\n" +
+	"\r_max_calories\"\xb9\x01\n" +
 	"\x0fGetMenuResponse\x120\n" +
 	"\n" +
-	"menu_items\x18\x01 \x03(\v2\x11.menu.v1.MenuItemR\tmenuItems\"c\n" +
+	"menu_items\x18\x01 \x03(\v2\x11.menu.v1.MenuItemR\tmenuItems\x120\n" +
+	"\bsections\x18\x02 \x03(\v2\x14.menu.v1.MenuSectionR\bsections\x12&\n" +
+	"\x0fnext_page_token\x18\x03 \x01(\tR\rnextPageToken\x12\x1a\n" +
+	"\brevision\x18\x04 \x01(\x04R\brevision\"\xb2\x03\n" +
 	"\x15CreateMenuItemRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\"H\n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x19\n" +
+	"\x05stock\x18\x04 \x01(\x05H\x00R\x05stock\x88\x01\x01\x12&\n" +
+	"\x05price\x18\x05 \x01(\v2\x10.common.v1.MoneyR\x05price\x12\x1f\n" +
+	"\vcategory_id\x18\x06 \x01(\rR\n" +
+	"categoryId\x12\x1c\n" +
+	"\tallergens\x18\a \x03(\tR\tallergens\x12!\n" +
+	"\fdietary_tags\x18\b \x03(\tR\vdietaryTags\x12\x1f\n" +
+	"\bcalories\x18\t \x01(\x05H\x01R\bcalories\x88\x01\x01\x12?\n" +
+	"\favailability\x18\n" +
+	" \x03(\v2\x1b.menu.v1.AvailabilityWindowR\favailability\x12?\n" +
+	"\x0fmodifier_groups\x18\v \x03(\v2\x16.menu.v1.ModifierGroupR\x0emodifierGroupsB\b\n" +
+	"\x06_stockB\v\n" +
+	"\t_caloriesJ\x04\b\x03\x10\x04\"H\n" +
 	"\x16CreateMenuItemResponse\x12.\n" +
-	"\tmenu_item\x18\x01 \x01(\v2\x11.menu.v1.MenuItemR\bmenuItem2\xe8\x01\n" +
+	"\tmenu_item\x18\x01 \x01(\v2\x11.menu.v1.MenuItemR\bmenuItem\"\x84\x01\n" +
+	"\x15UpdateMenuItemRequest\x12.\n" +
+	"\tmenu_item\x18\x01 \x01(\v2\x11.menu.v1.MenuItemR\bmenuItem\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"H\n" +
+	"\x16UpdateMenuItemResponse\x12.\n" +
+	"\tmenu_item\x18\x01 \x01(\v2\x11.menu.v1.MenuItemR\bmenuItem\"'\n" +
+	"\x15DeleteMenuItemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\x18\n" +
+	"\x16DeleteMenuItemResponse\"\xad\x01\n" +
+	"\vPriceChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12 \n" +
+	"\fmenu_item_id\x18\x02 \x01(\rR\n" +
+	"menuItemId\x12&\n" +
+	"\x05price\x18\x03 \x01(\v2\x10.common.v1.MoneyR\x05price\x12%\n" +
+	"\x0eeffective_from\x18\x04 \x01(\tR\reffectiveFrom\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\":\n" +
+	"\x16GetPriceHistoryRequest\x12 \n" +
+	"\fmenu_item_id\x18\x01 \x01(\rR\n" +
+	"menuItemId\"T\n" +
+	"\x17GetPriceHistoryResponse\x129\n" +
+	"\rprice_changes\x18\x01 \x03(\v2\x14.menu.v1.PriceChangeR\fpriceChanges\"\x8d\x01\n" +
+	"\x1aSchedulePriceChangeRequest\x12 \n" +
+	"\fmenu_item_id\x18\x01 \x01(\rR\n" +
+	"menuItemId\x12&\n" +
+	"\x05price\x18\x02 \x01(\v2\x10.common.v1.MoneyR\x05price\x12%\n" +
+	"\x0eeffective_from\x18\x03 \x01(\tR\reffectiveFrom\"V\n" +
+	"\x1bSchedulePriceChangeResponse\x127\n" +
+	"\fprice_change\x18\x01 \x01(\v2\x14.menu.v1.PriceChangeR\vpriceChange\"Q\n" +
+	"\x10WatchMenuRequest\x12*\n" +
+	"\x0esince_revision\x18\x01 \x01(\x04H\x00R\rsinceRevision\x88\x01\x01B\x11\n" +
+	"\x0f_since_revision\"=\n" +
+	"\x11WatchMenuResponse\x12(\n" +
+	"\x05event\x18\x01 \x01(\v2\x12.menu.v1.MenuEventR\x05event\"\xac\x01\n" +
+	"\tMenuEvent\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x04R\brevision\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12 \n" +
+	"\fmenu_item_id\x18\x03 \x01(\rR\n" +
+	"menuItemId\x12.\n" +
+	"\tmenu_item\x18\x04 \x01(\v2\x11.menu.v1.MenuItemR\bmenuItem\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\"\x96\x01\n" +
+	"\x16ImportMenuItemsRequest\x122\n" +
+	"\aoptions\x18\x01 \x01(\v2\x16.menu.v1.ImportOptionsH\x00R\aoptions\x12=\n" +
+	"\tmenu_item\x18\x02 \x01(\v2\x1e.menu.v1.CreateMenuItemRequestH\x00R\bmenuItemB\t\n" +
+	"\apayload\"N\n" +
+	"\rImportOptions\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12$\n" +
+	"\x0eupsert_by_name\x18\x02 \x01(\bR\fupsertByName\"\x87\x01\n" +
+	"\x0fImportRowResult\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x05R\x03row\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12 \n" +
+	"\fmenu_item_id\x18\x05 \x01(\rR\n" +
+	"menuItemId\"\xb7\x01\n" +
+	"\x17ImportMenuItemsResponse\x122\n" +
+	"\aresults\x18\x01 \x03(\v2\x18.menu.v1.ImportRowResultR\aresults\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated\x12\x18\n" +
+	"\aupdated\x18\x03 \x01(\x05R\aupdated\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x05R\x06failed\x12\x1c\n" +
+	"\tcommitted\x18\x05 \x01(\bR\tcommitted\"I\n" +
+	"\tStockLine\x12 \n" +
+	"\fmenu_item_id\x18\x01 \x01(\rR\n" +
+	"menuItemId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"f\n" +
+	"\x13ReserveStockRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12(\n" +
+	"\x05lines\x18\x02 \x03(\v2\x12.menu.v1.StockLineR\x05lines\"\x16\n" +
+	"\x14ReserveStockResponse\"<\n" +
+	"\x13ReleaseStockRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"\x16\n" +
+	"\x14ReleaseStockResponse\";\n" +
+	"\x12CommitStockRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"\x15\n" +
+	"\x13CommitStockResponse\"{\n" +
+	"\x15CreateCategoryRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1f\n" +
+	"\bposition\x18\x03 \x01(\x05H\x00R\bposition\x88\x01\x01B\v\n" +
+	"\t_position\"G\n" +
+	"\x16CreateCategoryResponse\x12-\n" +
+	"\bcategory\x18\x01 \x01(\v2\x11.menu.v1.CategoryR\bcategory\"$\n" +
+	"\x12GetCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"D\n" +
+	"\x13GetCategoryResponse\x12-\n" +
+	"\bcategory\x18\x01 \x01(\v2\x11.menu.v1.CategoryR\bcategory\"\x17\n" +
+	"\x15ListCategoriesRequest\"K\n" +
+	"\x16ListCategoriesResponse\x121\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x11.menu.v1.CategoryR\n" +
+	"categories\"\x83\x01\n" +
+	"\x15UpdateCategoryRequest\x12-\n" +
+	"\bcategory\x18\x01 \x01(\v2\x11.menu.v1.CategoryR\bcategory\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"G\n" +
+	"\x16UpdateCategoryResponse\x12-\n" +
+	"\bcategory\x18\x01 \x01(\v2\x11.menu.v1.CategoryR\bcategory\"'\n" +
+	"\x15DeleteCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\x18\n" +
+	"\x16DeleteCategoryResponse2\xba\v\n" +
 	"\vMenuService\x12H\n" +
-	"\vGetMenuItem\x12\x1b.menu.v1.GetMenuItemRequest\x1a\x1c.menu.v1.GetMenuItemResponse\x12<\n" +
+	"\vGetMenuItem\x12\x1b.menu.v1.GetMenuItemRequest\x1a\x1c.menu.v1.GetMenuItemResponse\x12Z\n" +
+	"\x11BatchGetMenuItems\x12!.menu.v1.BatchGetMenuItemsRequest\x1a\".menu.v1.BatchGetMenuItemsResponse\x12<\n" +
 	"\aGetMenu\x12\x17.menu.v1.GetMenuRequest\x1a\x18.menu.v1.GetMenuResponse\x12Q\n" +
-	"\x0eCreateMenuItem\x12\x1e.menu.v1.CreateMenuItemRequest\x1a\x1f.menu.v1.CreateMenuItemResponseBAZ?github.com/douglasswm/student-cafe-protos/gen/go/menu/v1;menuv1b\x06proto3"
+	"\x0eCreateMenuItem\x12\x1e.menu.v1.CreateMenuItemRequest\x1a\x1f.menu.v1.CreateMenuItemResponse\x12Q\n" +
+	"\x0eUpdateMenuItem\x12\x1e.menu.v1.UpdateMenuItemRequest\x1a\x1f.menu.v1.UpdateMenuItemResponse\x12Q\n" +
+	"\x0eDeleteMenuItem\x12\x1e.menu.v1.DeleteMenuItemRequest\x1a\x1f.menu.v1.DeleteMenuItemResponse\x12V\n" +
+	"\x0fImportMenuItems\x12\x1f.menu.v1.ImportMenuItemsRequest\x1a .menu.v1.ImportMenuItemsResponse(\x01\x12T\n" +
+	"\x0fGetPriceHistory\x12\x1f.menu.v1.GetPriceHistoryRequest\x1a .menu.v1.GetPriceHistoryResponse\x12`\n" +
+	"\x13SchedulePriceChange\x12#.menu.v1.SchedulePriceChangeRequest\x1a$.menu.v1.SchedulePriceChangeResponse\x12D\n" +
+	"\tWatchMenu\x12\x19.menu.v1.WatchMenuRequest\x1a\x1a.menu.v1.WatchMenuResponse0\x01\x12Q\n" +
+	"\x0eCreateCategory\x12\x1e.menu.v1.CreateCategoryRequest\x1a\x1f.menu.v1.CreateCategoryResponse\x12H\n" +
+	"\vGetCategory\x12\x1b.menu.v1.GetCategoryRequest\x1a\x1c.menu.v1.GetCategoryResponse\x12Q\n" +
+	"\x0eListCategories\x12\x1e.menu.v1.ListCategoriesRequest\x1a\x1f.menu.v1.ListCategoriesResponse\x12Q\n" +
+	"\x0eUpdateCategory\x12\x1e.menu.v1.UpdateCategoryRequest\x1a\x1f.menu.v1.UpdateCategoryResponse\x12Q\n" +
+	"\x0eDeleteCategory\x12\x1e.menu.v1.DeleteCategoryRequest\x1a\x1f.menu.v1.DeleteCategoryResponse\x12K\n" +
+	"\fReserveStock\x12\x1c.menu.v1.ReserveStockRequest\x1a\x1d.menu.v1.ReserveStockResponse\x12K\n" +
+	"\fReleaseStock\x12\x1c.menu.v1.ReleaseStockRequest\x1a\x1d.menu.v1.ReleaseStockResponse\x12H\n" +
+	"\vCommitStock\x12\x1b.menu.v1.CommitStockRequest\x1a\x1c.menu.v1.CommitStockResponseBAZ?github.com/douglasswm/student-cafe-protos/gen/go/menu/v1;menuv1b\x06proto3"
 
 var (
 	file_menu_v1_menu_proto_rawDescOnce sync.Once
@@ -429,31 +3094,136 @@ func file_menu_v1_menu_proto_rawDescGZIP() []byte {
 	return file_menu_v1_menu_proto_rawDescData
 }
 
-var file_menu_v1_menu_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_menu_v1_menu_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
 var file_menu_v1_menu_proto_goTypes = []any{
-	(*MenuItem)(nil),               // 0: menu.v1.MenuItem
-	(*GetMenuItemRequest)(nil),     // 1: menu.v1.GetMenuItemRequest
-	(*GetMenuItemResponse)(nil),    // 2: menu.v1.GetMenuItemResponse
-	(*GetMenuRequest)(nil),         // 3: menu.v1.GetMenuRequest
-	(*GetMenuResponse)(nil),        // 4: menu.v1.GetMenuResponse
-	(*CreateMenuItemRequest)(nil),  // 5: menu.v1.CreateMenuItemRequest
-	(*CreateMenuItemResponse)(nil), // 6: menu.v1.CreateMenuItemResponse
+	(*MenuItem)(nil),                    // 0: menu.v1.MenuItem
+	(*ModifierGroup)(nil),               // 1: menu.v1.ModifierGroup
+	(*Modifier)(nil),                    // 2: menu.v1.Modifier
+	(*AvailabilityWindow)(nil),          // 3: menu.v1.AvailabilityWindow
+	(*Category)(nil),                    // 4: menu.v1.Category
+	(*MenuSection)(nil),                 // 5: menu.v1.MenuSection
+	(*GetMenuItemRequest)(nil),          // 6: menu.v1.GetMenuItemRequest
+	(*GetMenuItemResponse)(nil),         // 7: menu.v1.GetMenuItemResponse
+	(*BatchGetMenuItemsRequest)(nil),    // 8: menu.v1.BatchGetMenuItemsRequest
+	(*BatchGetMenuItemsResponse)(nil),   // 9: menu.v1.BatchGetMenuItemsResponse
+	(*GetMenuRequest)(nil),              // 10: menu.v1.GetMenuRequest
+	(*GetMenuResponse)(nil),             // 11: menu.v1.GetMenuResponse
+	(*CreateMenuItemRequest)(nil),       // 12: menu.v1.CreateMenuItemRequest
+	(*CreateMenuItemResponse)(nil),      // 13: menu.v1.CreateMenuItemResponse
+	(*UpdateMenuItemRequest)(nil),       // 14: menu.v1.UpdateMenuItemRequest
+	(*UpdateMenuItemResponse)(nil),      // 15: menu.v1.UpdateMenuItemResponse
+	(*DeleteMenuItemRequest)(nil),       // 16: menu.v1.DeleteMenuItemRequest
+	(*DeleteMenuItemResponse)(nil),      // 17: menu.v1.DeleteMenuItemResponse
+	(*PriceChange)(nil),                 // 18: menu.v1.PriceChange
+	(*GetPriceHistoryRequest)(nil),      // 19: menu.v1.GetPriceHistoryRequest
+	(*GetPriceHistoryResponse)(nil),     // 20: menu.v1.GetPriceHistoryResponse
+	(*SchedulePriceChangeRequest)(nil),  // 21: menu.v1.SchedulePriceChangeRequest
+	(*SchedulePriceChangeResponse)(nil), // 22: menu.v1.SchedulePriceChangeResponse
+	(*WatchMenuRequest)(nil),            // 23: menu.v1.WatchMenuRequest
+	(*WatchMenuResponse)(nil),           // 24: menu.v1.WatchMenuResponse
+	(*MenuEvent)(nil),                   // 25: menu.v1.MenuEvent
+	(*ImportMenuItemsRequest)(nil),      // 26: menu.v1.ImportMenuItemsRequest
+	(*ImportOptions)(nil),               // 27: menu.v1.ImportOptions
+	(*ImportRowResult)(nil),             // 28: menu.v1.ImportRowResult
+	(*ImportMenuItemsResponse)(nil),     // 29: menu.v1.ImportMenuItemsResponse
+	(*StockLine)(nil),                   // 30: menu.v1.StockLine
+	(*ReserveStockRequest)(nil),         // 31: menu.v1.ReserveStockRequest
+	(*ReserveStockResponse)(nil),        // 32: menu.v1.ReserveStockResponse
+	(*ReleaseStockRequest)(nil),         // 33: menu.v1.ReleaseStockRequest
+	(*ReleaseStockResponse)(nil),        // 34: menu.v1.ReleaseStockResponse
+	(*CommitStockRequest)(nil),          // 35: menu.v1.CommitStockRequest
+	(*CommitStockResponse)(nil),         // 36: menu.v1.CommitStockResponse
+	(*CreateCategoryRequest)(nil),       // 37: menu.v1.CreateCategoryRequest
+	(*CreateCategoryResponse)(nil),      // 38: menu.v1.CreateCategoryResponse
+	(*GetCategoryRequest)(nil),          // 39: menu.v1.GetCategoryRequest
+	(*GetCategoryResponse)(nil),         // 40: menu.v1.GetCategoryResponse
+	(*ListCategoriesRequest)(nil),       // 41: menu.v1.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),      // 42: menu.v1.ListCategoriesResponse
+	(*UpdateCategoryRequest)(nil),       // 43: menu.v1.UpdateCategoryRequest
+	(*UpdateCategoryResponse)(nil),      // 44: menu.v1.UpdateCategoryResponse
+	(*DeleteCategoryRequest)(nil),       // 45: menu.v1.DeleteCategoryRequest
+	(*DeleteCategoryResponse)(nil),      // 46: menu.v1.DeleteCategoryResponse
+	(*v1.Money)(nil),                    // 47: common.v1.Money
+	(*fieldmaskpb.FieldMask)(nil),       // 48: google.protobuf.FieldMask
 }
 var file_menu_v1_menu_proto_depIdxs = []int32{
-	0, // 0: menu.v1.GetMenuItemResponse.menu_item:type_name -> menu.v1.MenuItem
-	0, // 1: menu.v1.GetMenuResponse.menu_items:type_name -> menu.v1.MenuItem
-	0, // 2: menu.v1.CreateMenuItemResponse.menu_item:type_name -> menu.v1.MenuItem
-	1, // 3: menu.v1.MenuService.GetMenuItem:input_type -> menu.v1.GetMenuItemRequest
-	3, // 4: menu.v1.MenuService.GetMenu:input_type -> menu.v1.GetMenuRequest
-	5, // 5: menu.v1.MenuService.CreateMenuItem:input_type -> menu.v1.CreateMenuItemRequest
-	2, // 6: menu.v1.MenuService.GetMenuItem:output_type -> menu.v1.GetMenuItemResponse
-	4, // 7: menu.v1.MenuService.GetMenu:output_type -> menu.v1.GetMenuResponse
-	6, // 8: menu.v1.MenuService.CreateMenuItem:output_type -> menu.v1.CreateMenuItemResponse
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	47, // 0: menu.v1.MenuItem.price:type_name -> common.v1.Money
+	3,  // 1: menu.v1.MenuItem.availability:type_name -> menu.v1.AvailabilityWindow
+	1,  // 2: menu.v1.MenuItem.modifier_groups:type_name -> menu.v1.ModifierGroup
+	2,  // 3: menu.v1.ModifierGroup.modifiers:type_name -> menu.v1.Modifier
+	47, // 4: menu.v1.Modifier.price_delta:type_name -> common.v1.Money
+	4,  // 5: menu.v1.MenuSection.category:type_name -> menu.v1.Category
+	0,  // 6: menu.v1.MenuSection.menu_items:type_name -> menu.v1.MenuItem
+	0,  // 7: menu.v1.GetMenuItemResponse.menu_item:type_name -> menu.v1.MenuItem
+	0,  // 8: menu.v1.BatchGetMenuItemsResponse.menu_items:type_name -> menu.v1.MenuItem
+	47, // 9: menu.v1.GetMenuRequest.min_price:type_name -> common.v1.Money
+	47, // 10: menu.v1.GetMenuRequest.max_price:type_name -> common.v1.Money
+	0,  // 11: menu.v1.GetMenuResponse.menu_items:type_name -> menu.v1.MenuItem
+	5,  // 12: menu.v1.GetMenuResponse.sections:type_name -> menu.v1.MenuSection
+	47, // 13: menu.v1.CreateMenuItemRequest.price:type_name -> common.v1.Money
+	3,  // 14: menu.v1.CreateMenuItemRequest.availability:type_name -> menu.v1.AvailabilityWindow
+	1,  // 15: menu.v1.CreateMenuItemRequest.modifier_groups:type_name -> menu.v1.ModifierGroup
+	0,  // 16: menu.v1.CreateMenuItemResponse.menu_item:type_name -> menu.v1.MenuItem
+	0,  // 17: menu.v1.UpdateMenuItemRequest.menu_item:type_name -> menu.v1.MenuItem
+	48, // 18: menu.v1.UpdateMenuItemRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 19: menu.v1.UpdateMenuItemResponse.menu_item:type_name -> menu.v1.MenuItem
+	47, // 20: menu.v1.PriceChange.price:type_name -> common.v1.Money
+	18, // 21: menu.v1.GetPriceHistoryResponse.price_changes:type_name -> menu.v1.PriceChange
+	47, // 22: menu.v1.SchedulePriceChangeRequest.price:type_name -> common.v1.Money
+	18, // 23: menu.v1.SchedulePriceChangeResponse.price_change:type_name -> menu.v1.PriceChange
+	25, // 24: menu.v1.WatchMenuResponse.event:type_name -> menu.v1.MenuEvent
+	0,  // 25: menu.v1.MenuEvent.menu_item:type_name -> menu.v1.MenuItem
+	27, // 26: menu.v1.ImportMenuItemsRequest.options:type_name -> menu.v1.ImportOptions
+	12, // 27: menu.v1.ImportMenuItemsRequest.menu_item:type_name -> menu.v1.CreateMenuItemRequest
+	28, // 28: menu.v1.ImportMenuItemsResponse.results:type_name -> menu.v1.ImportRowResult
+	30, // 29: menu.v1.ReserveStockRequest.lines:type_name -> menu.v1.StockLine
+	4,  // 30: menu.v1.CreateCategoryResponse.category:type_name -> menu.v1.Category
+	4,  // 31: menu.v1.GetCategoryResponse.category:type_name -> menu.v1.Category
+	4,  // 32: menu.v1.ListCategoriesResponse.categories:type_name -> menu.v1.Category
+	4,  // 33: menu.v1.UpdateCategoryRequest.category:type_name -> menu.v1.Category
+	48, // 34: menu.v1.UpdateCategoryRequest.update_mask:type_name -> google.protobuf.FieldMask
+	4,  // 35: menu.v1.UpdateCategoryResponse.category:type_name -> menu.v1.Category
+	6,  // 36: menu.v1.MenuService.GetMenuItem:input_type -> menu.v1.GetMenuItemRequest
+	8,  // 37: menu.v1.MenuService.BatchGetMenuItems:input_type -> menu.v1.BatchGetMenuItemsRequest
+	10, // 38: menu.v1.MenuService.GetMenu:input_type -> menu.v1.GetMenuRequest
+	12, // 39: menu.v1.MenuService.CreateMenuItem:input_type -> menu.v1.CreateMenuItemRequest
+	14, // 40: menu.v1.MenuService.UpdateMenuItem:input_type -> menu.v1.UpdateMenuItemRequest
+	16, // 41: menu.v1.MenuService.DeleteMenuItem:input_type -> menu.v1.DeleteMenuItemRequest
+	26, // 42: menu.v1.MenuService.ImportMenuItems:input_type -> menu.v1.ImportMenuItemsRequest
+	19, // 43: menu.v1.MenuService.GetPriceHistory:input_type -> menu.v1.GetPriceHistoryRequest
+	21, // 44: menu.v1.MenuService.SchedulePriceChange:input_type -> menu.v1.SchedulePriceChangeRequest
+	23, // 45: menu.v1.MenuService.WatchMenu:input_type -> menu.v1.WatchMenuRequest
+	37, // 46: menu.v1.MenuService.CreateCategory:input_type -> menu.v1.CreateCategoryRequest
+	39, // 47: menu.v1.MenuService.GetCategory:input_type -> menu.v1.GetCategoryRequest
+	41, // 48: menu.v1.MenuService.ListCategories:input_type -> menu.v1.ListCategoriesRequest
+	43, // 49: menu.v1.MenuService.UpdateCategory:input_type -> menu.v1.UpdateCategoryRequest
+	45, // 50: menu.v1.MenuService.DeleteCategory:input_type -> menu.v1.DeleteCategoryRequest
+	31, // 51: menu.v1.MenuService.ReserveStock:input_type -> menu.v1.ReserveStockRequest
+	33, // 52: menu.v1.MenuService.ReleaseStock:input_type -> menu.v1.ReleaseStockRequest
+	35, // 53: menu.v1.MenuService.CommitStock:input_type -> menu.v1.CommitStockRequest
+	7,  // 54: menu.v1.MenuService.GetMenuItem:output_type -> menu.v1.GetMenuItemResponse
+	9,  // 55: menu.v1.MenuService.BatchGetMenuItems:output_type -> menu.v1.BatchGetMenuItemsResponse
+	11, // 56: menu.v1.MenuService.GetMenu:output_type -> menu.v1.GetMenuResponse
+	13, // 57: menu.v1.MenuService.CreateMenuItem:output_type -> menu.v1.CreateMenuItemResponse
+	15, // 58: menu.v1.MenuService.UpdateMenuItem:output_type -> menu.v1.UpdateMenuItemResponse
+	17, // 59: menu.v1.MenuService.DeleteMenuItem:output_type -> menu.v1.DeleteMenuItemResponse
+	29, // 60: menu.v1.MenuService.ImportMenuItems:output_type -> menu.v1.ImportMenuItemsResponse
+	20, // 61: menu.v1.MenuService.GetPriceHistory:output_type -> menu.v1.GetPriceHistoryResponse
+	22, // 62: menu.v1.MenuService.SchedulePriceChange:output_type -> menu.v1.SchedulePriceChangeResponse
+	24, // 63: menu.v1.MenuService.WatchMenu:output_type -> menu.v1.WatchMenuResponse
+	38, // 64: menu.v1.MenuService.CreateCategory:output_type -> menu.v1.CreateCategoryResponse
+	40, // 65: menu.v1.MenuService.GetCategory:output_type -> menu.v1.GetCategoryResponse
+	42, // 66: menu.v1.MenuService.ListCategories:output_type -> menu.v1.ListCategoriesResponse
+	44, // 67: menu.v1.MenuService.UpdateCategory:output_type -> menu.v1.UpdateCategoryResponse
+	46, // 68: menu.v1.MenuService.DeleteCategory:output_type -> menu.v1.DeleteCategoryResponse
+	32, // 69: menu.v1.MenuService.ReserveStock:output_type -> menu.v1.ReserveStockResponse
+	34, // 70: menu.v1.MenuService.ReleaseStock:output_type -> menu.v1.ReleaseStockResponse
+	36, // 71: menu.v1.MenuService.CommitStock:output_type -> menu.v1.CommitStockResponse
+	54, // [54:72] is the sub-list for method output_type
+	36, // [36:54] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_menu_v1_menu_proto_init() }
@@ -461,13 +3231,22 @@ func file_menu_v1_menu_proto_init() {
 	if File_menu_v1_menu_proto != nil {
 		return
 	}
+	file_menu_v1_menu_proto_msgTypes[0].OneofWrappers = []any{}
+	file_menu_v1_menu_proto_msgTypes[10].OneofWrappers = []any{}
+	file_menu_v1_menu_proto_msgTypes[12].OneofWrappers = []any{}
+	file_menu_v1_menu_proto_msgTypes[23].OneofWrappers = []any{}
+	file_menu_v1_menu_proto_msgTypes[26].OneofWrappers = []any{
+		(*ImportMenuItemsRequest_Options)(nil),
+		(*ImportMenuItemsRequest_MenuItem)(nil),
+	}
+	file_menu_v1_menu_proto_msgTypes[37].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_menu_v1_menu_proto_rawDesc), len(file_menu_v1_menu_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   47,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
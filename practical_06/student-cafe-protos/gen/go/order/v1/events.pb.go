@@ -0,0 +1,311 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.32.0
+// source: order/v1/events.proto
+
+package orderv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Published on subject "orders.created" when an order has been placed
+type OrderCreated struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       uint64                 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"` // Unique per event, use it to drop redeliveries
+	OccurredAt    string                 `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Order         *Order                 `protobuf:"bytes,3,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderCreated) Reset() {
+	*x = OrderCreated{}
+	mi := &file_order_v1_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderCreated) ProtoMessage() {}
+
+func (x *OrderCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderCreated.ProtoReflect.Descriptor instead.
+func (*OrderCreated) Descriptor() ([]byte, []int) {
+	return file_order_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OrderCreated) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *OrderCreated) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+func (x *OrderCreated) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// Published on subject "orders.status_changed" when an order moves to a new status
+type OrderStatusChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       uint64                 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"` // Unique per event, use it to drop redeliveries
+	OccurredAt    string                 `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	OrderId       uint32                 `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        uint32                 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Transition    *OrderStatusTransition `protobuf:"bytes,5,opt,name=transition,proto3" json:"transition,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderStatusChanged) Reset() {
+	*x = OrderStatusChanged{}
+	mi := &file_order_v1_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusChanged) ProtoMessage() {}
+
+func (x *OrderStatusChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusChanged.ProtoReflect.Descriptor instead.
+func (*OrderStatusChanged) Descriptor() ([]byte, []int) {
+	return file_order_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OrderStatusChanged) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *OrderStatusChanged) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+func (x *OrderStatusChanged) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *OrderStatusChanged) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *OrderStatusChanged) GetTransition() *OrderStatusTransition {
+	if x != nil {
+		return x.Transition
+	}
+	return nil
+}
+
+// Published on subject "orders.user_detached" when a deleted user's orders are
+// detached from them; consumers should erase what they hold about the user
+type OrdersUserDetached struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       uint64                 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"` // Unique per event, use it to drop redeliveries
+	OccurredAt    string                 `protobuf:"bytes,2,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	UserId        uint32                 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrderIds      []uint32               `protobuf:"varint,4,rep,packed,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrdersUserDetached) Reset() {
+	*x = OrdersUserDetached{}
+	mi := &file_order_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrdersUserDetached) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrdersUserDetached) ProtoMessage() {}
+
+func (x *OrdersUserDetached) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrdersUserDetached.ProtoReflect.Descriptor instead.
+func (*OrdersUserDetached) Descriptor() ([]byte, []int) {
+	return file_order_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrdersUserDetached) GetEventId() uint64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *OrdersUserDetached) GetOccurredAt() string {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return ""
+}
+
+func (x *OrdersUserDetached) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *OrdersUserDetached) GetOrderIds() []uint32 {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+var File_order_v1_events_proto protoreflect.FileDescriptor
+
+const file_order_v1_events_proto_rawDesc = "" +
+	"\n" +
+	"\x15order/v1/events.proto\x12\border.v1\x1a\x14order/v1/order.proto\"q\n" +
+	"\fOrderCreated\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\x12\x1f\n" +
+	"\voccurred_at\x18\x02 \x01(\tR\n" +
+	"occurredAt\x12%\n" +
+	"\x05order\x18\x03 \x01(\v2\x0f.order.v1.OrderR\x05order\"\xc5\x01\n" +
+	"\x12OrderStatusChanged\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\x12\x1f\n" +
+	"\voccurred_at\x18\x02 \x01(\tR\n" +
+	"occurredAt\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\rR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\rR\x06userId\x12?\n" +
+	"\n" +
+	"transition\x18\x05 \x01(\v2\x1f.order.v1.OrderStatusTransitionR\n" +
+	"transition\"\x86\x01\n" +
+	"\x12OrdersUserDetached\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x04R\aeventId\x12\x1f\n" +
+	"\voccurred_at\x18\x02 \x01(\tR\n" +
+	"occurredAt\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\rR\x06userId\x12\x1b\n" +
+	"\torder_ids\x18\x04 \x03(\rR\borderIdsBCZAgithub.com/douglasswm/student-cafe-protos/gen/go/order/v1;orderv1b\x06proto3"
+
+var (
+	file_order_v1_events_proto_rawDescOnce sync.Once
+	file_order_v1_events_proto_rawDescData []byte
+)
+
+func file_order_v1_events_proto_rawDescGZIP() []byte {
+	file_order_v1_events_proto_rawDescOnce.Do(func() {
+		file_order_v1_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_order_v1_events_proto_rawDesc), len(file_order_v1_events_proto_rawDesc)))
+	})
+	return file_order_v1_events_proto_rawDescData
+}
+
+var file_order_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_order_v1_events_proto_goTypes = []any{
+	(*OrderCreated)(nil),          // 0: order.v1.OrderCreated
+	(*OrderStatusChanged)(nil),    // 1: order.v1.OrderStatusChanged
+	(*OrdersUserDetached)(nil),    // 2: order.v1.OrdersUserDetached
+	(*Order)(nil),                 // 3: order.v1.Order
+	(*OrderStatusTransition)(nil), // 4: order.v1.OrderStatusTransition
+}
+var file_order_v1_events_proto_depIdxs = []int32{
+	3, // 0: order.v1.OrderCreated.order:type_name -> order.v1.Order
+	4, // 1: order.v1.OrderStatusChanged.transition:type_name -> order.v1.OrderStatusTransition
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_order_v1_events_proto_init() }
+func file_order_v1_events_proto_init() {
+	if File_order_v1_events_proto != nil {
+		return
+	}
+	file_order_v1_order_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_v1_events_proto_rawDesc), len(file_order_v1_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_order_v1_events_proto_goTypes,
+		DependencyIndexes: file_order_v1_events_proto_depIdxs,
+		MessageInfos:      file_order_v1_events_proto_msgTypes,
+	}.Build()
+	File_order_v1_events_proto = out.File
+	file_order_v1_events_proto_goTypes = nil
+	file_order_v1_events_proto_depIdxs = nil
+}
\ No newline at end of file
@@ -7,6 +7,7 @@
 package orderv1
 
 import (
+	v1 "github.com/douglasswm/student-cafe-protos/gen/go/common/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -23,33 +24,1264 @@ const (
 
 // OrderItem message definition
 type OrderItem struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId    uint32                 `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	MenuItemId uint32                 `protobuf:"varint,3,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
+	Quantity   int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	CreatedAt  string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt  string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Price      *v1.Money              `protobuf:"bytes,9,opt,name=price,proto3" json:"price,omitempty"`                           // Unit price when the order was placed, without modifiers
+	LineTotal  *v1.Money              `protobuf:"bytes,10,opt,name=line_total,json=lineTotal,proto3" json:"line_total,omitempty"` // (price + modifier price deltas) * quantity
+	Modifiers  []*OrderItemModifier   `protobuf:"bytes,11,rep,name=modifiers,proto3" json:"modifiers,omitempty"`
+	// Free-text instructions for this line, e.g. "extra hot"
+	Note          string `protobuf:"bytes,12,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItem) Reset() {
+	*x = OrderItem{}
+	mi := &file_order_v1_order_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItem) ProtoMessage() {}
+
+func (x *OrderItem) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
+func (*OrderItem) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OrderItem) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OrderItem) GetOrderId() uint32 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *OrderItem) GetMenuItemId() uint32 {
+	if x != nil {
+		return x.MenuItemId
+	}
+	return 0
+}
+
+func (x *OrderItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderItem) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *OrderItem) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *OrderItem) GetPrice() *v1.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *OrderItem) GetLineTotal() *v1.Money {
+	if x != nil {
+		return x.LineTotal
+	}
+	return nil
+}
+
+func (x *OrderItem) GetModifiers() []*OrderItemModifier {
+	if x != nil {
+		return x.Modifiers
+	}
+	return nil
+}
+
+func (x *OrderItem) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// OrderItemModifier is a modifier chosen for an order line, as it was when the order was placed
+type OrderItemModifier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModifierId    uint32                 `protobuf:"varint,1,opt,name=modifier_id,json=modifierId,proto3" json:"modifier_id,omitempty"`
+	GroupName     string                 `protobuf:"bytes,2,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	PriceDelta    *v1.Money              `protobuf:"bytes,4,opt,name=price_delta,json=priceDelta,proto3" json:"price_delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItemModifier) Reset() {
+	*x = OrderItemModifier{}
+	mi := &file_order_v1_order_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItemModifier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItemModifier) ProtoMessage() {}
+
+func (x *OrderItemModifier) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItemModifier.ProtoReflect.Descriptor instead.
+func (*OrderItemModifier) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OrderItemModifier) GetModifierId() uint32 {
+	if x != nil {
+		return x.ModifierId
+	}
+	return 0
+}
+
+func (x *OrderItemModifier) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *OrderItemModifier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrderItemModifier) GetPriceDelta() *v1.Money {
+	if x != nil {
+		return x.PriceDelta
+	}
+	return nil
+}
+
+// OrderStatusTransition records a single status change of an order
+type OrderStatusTransition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromStatus    string                 `protobuf:"bytes,1,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	ToStatus      string                 `protobuf:"bytes,2,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderStatusTransition) Reset() {
+	*x = OrderStatusTransition{}
+	mi := &file_order_v1_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusTransition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusTransition) ProtoMessage() {}
+
+func (x *OrderStatusTransition) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusTransition.ProtoReflect.Descriptor instead.
+func (*OrderStatusTransition) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrderStatusTransition) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *OrderStatusTransition) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *OrderStatusTransition) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *OrderStatusTransition) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+// OrderTax is one tax charged on an order
+type OrderTax struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rate          float64                `protobuf:"fixed64,2,opt,name=rate,proto3" json:"rate,omitempty"` // e.g. 0.07 for 7%
+	Amount        *v1.Money              `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderTax) Reset() {
+	*x = OrderTax{}
+	mi := &file_order_v1_order_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderTax) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderTax) ProtoMessage() {}
+
+func (x *OrderTax) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderTax.ProtoReflect.Descriptor instead.
+func (*OrderTax) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OrderTax) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrderTax) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *OrderTax) GetAmount() *v1.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+// OrderDiscount is a discount a promotion rule gave an order, as it was when the order was placed
+type OrderDiscount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromotionId   uint32                 `protobuf:"varint,1,opt,name=promotion_id,json=promotionId,proto3" json:"promotion_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Amount        *v1.Money              `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderDiscount) Reset() {
+	*x = OrderDiscount{}
+	mi := &file_order_v1_order_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderDiscount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderDiscount) ProtoMessage() {}
+
+func (x *OrderDiscount) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderDiscount.ProtoReflect.Descriptor instead.
+func (*OrderDiscount) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *OrderDiscount) GetPromotionId() uint32 {
+	if x != nil {
+		return x.PromotionId
+	}
+	return 0
+}
+
+func (x *OrderDiscount) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *OrderDiscount) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *OrderDiscount) GetAmount() *v1.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+// Order message definition. All amounts are computed by the order service in
+// the currency of the ordered items: total = subtotal - discount + tax.
+type Order struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Id            uint32                   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        uint32                   `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string                   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	OrderItems    []*OrderItem             `protobuf:"bytes,4,rep,name=order_items,json=orderItems,proto3" json:"order_items,omitempty"`
+	CreatedAt     string                   `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                   `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	StatusHistory []*OrderStatusTransition `protobuf:"bytes,7,rep,name=status_history,json=statusHistory,proto3" json:"status_history,omitempty"`
+	Taxes         []*OrderTax              `protobuf:"bytes,12,rep,name=taxes,proto3" json:"taxes,omitempty"`
+	Subtotal      *v1.Money                `protobuf:"bytes,13,opt,name=subtotal,proto3" json:"subtotal,omitempty"` // Sum of line totals
+	Discount      *v1.Money                `protobuf:"bytes,14,opt,name=discount,proto3" json:"discount,omitempty"`
+	Tax           *v1.Money                `protobuf:"bytes,15,opt,name=tax,proto3" json:"tax,omitempty"` // Sum of taxes
+	Total         *v1.Money                `protobuf:"bytes,16,opt,name=total,proto3" json:"total,omitempty"`
+	// Discounts from promotions; their amounts add up to discount
+	Discounts []*OrderDiscount `protobuf:"bytes,17,rep,name=discounts,proto3" json:"discounts,omitempty"`
+	// Set once the user who placed the order was deleted; user_id is then 0
+	UserDetachedAt string `protobuf:"bytes,18,opt,name=user_detached_at,json=userDetachedAt,proto3" json:"user_detached_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_order_v1_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Order) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Order) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Order) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Order) GetOrderItems() []*OrderItem {
+	if x != nil {
+		return x.OrderItems
+	}
+	return nil
+}
+
+func (x *Order) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Order) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Order) GetStatusHistory() []*OrderStatusTransition {
+	if x != nil {
+		return x.StatusHistory
+	}
+	return nil
+}
+
+func (x *Order) GetTaxes() []*OrderTax {
+	if x != nil {
+		return x.Taxes
+	}
+	return nil
+}
+
+func (x *Order) GetSubtotal() *v1.Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *Order) GetDiscount() *v1.Money {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *Order) GetTax() *v1.Money {
+	if x != nil {
+		return x.Tax
+	}
+	return nil
+}
+
+func (x *Order) GetTotal() *v1.Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *Order) GetDiscounts() []*OrderDiscount {
+	if x != nil {
+		return x.Discounts
+	}
+	return nil
+}
+
+func (x *Order) GetUserDetachedAt() string {
+	if x != nil {
+		return x.UserDetachedAt
+	}
+	return ""
+}
+
+// Item in create order request
+type OrderItemRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	MenuItemId uint32                 `protobuf:"varint,1,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
+	Quantity   int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Modifiers chosen from the menu item's modifier groups
+	ModifierIds []uint32 `protobuf:"varint,3,rep,packed,name=modifier_ids,json=modifierIds,proto3" json:"modifier_ids,omitempty"`
+	// Free-text instructions for this line, at most 200 characters
+	Note          string `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItemRequest) Reset() {
+	*x = OrderItemRequest{}
+	mi := &file_order_v1_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItemRequest) ProtoMessage() {}
+
+func (x *OrderItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItemRequest.ProtoReflect.Descriptor instead.
+func (*OrderItemRequest) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OrderItemRequest) GetMenuItemId() uint32 {
+	if x != nil {
+		return x.MenuItemId
+	}
+	return 0
+}
+
+func (x *OrderItemRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderItemRequest) GetModifierIds() []uint32 {
+	if x != nil {
+		return x.ModifierIds
+	}
+	return nil
+}
+
+func (x *OrderItemRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// Create order request
+type CreateOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items         []*OrderItemRequest    `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_order_v1_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateOrderRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetItems() []*OrderItemRequest {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Create order response
+type CreateOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderResponse) Reset() {
+	*x = CreateOrderResponse{}
+	mi := &file_order_v1_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderResponse) ProtoMessage() {}
+
+func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// Get orders request. All filters are optional and combined with AND.
+type GetOrdersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only orders placed by this user (0 means any user)
+	UserId uint32 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Only orders currently in this status
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// Only orders created at or after this time (RFC 3339)
+	CreatedAfter string `protobuf:"bytes,3,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	// Only orders created before this time (RFC 3339)
+	CreatedBefore string `protobuf:"bytes,4,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	// Sort order: "created_at" (default) or "id", optionally followed by " desc"
+	OrderBy string `protobuf:"bytes,5,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// Maximum number of orders to return (default 50, capped at 100)
+	PageSize int32 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token from a previous response's next_page_token; the other fields must not change between pages
+	PageToken     string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersRequest) Reset() {
+	*x = GetOrdersRequest{}
+	mi := &file_order_v1_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersRequest) ProtoMessage() {}
+
+func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersRequest.ProtoReflect.Descriptor instead.
+func (*GetOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetOrdersRequest) GetUserId() uint32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetOrdersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetOrdersRequest) GetCreatedAfter() string {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return ""
+}
+
+func (x *GetOrdersRequest) GetCreatedBefore() string {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return ""
+}
+
+func (x *GetOrdersRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+func (x *GetOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Get orders response
+type GetOrdersResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Orders []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// Token for the next page, empty when there are no more orders
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersResponse) Reset() {
+	*x = GetOrdersResponse{}
+	mi := &file_order_v1_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersResponse) ProtoMessage() {}
+
+func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersResponse.ProtoReflect.Descriptor instead.
+func (*GetOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *GetOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Get order request
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_order_v1_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetOrderRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Get order response
+type GetOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderResponse) Reset() {
+	*x = GetOrderResponse{}
+	mi := &file_order_v1_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderResponse) ProtoMessage() {}
+
+func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// Update order status request
+type UpdateOrderStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusRequest) Reset() {
+	*x = UpdateOrderStatusRequest{}
+	mi := &file_order_v1_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusRequest) ProtoMessage() {}
+
+func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *UpdateOrderStatusRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateOrderStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// Update order status response
+type UpdateOrderStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusResponse) Reset() {
+	*x = UpdateOrderStatusResponse{}
+	mi := &file_order_v1_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusResponse) ProtoMessage() {}
+
+func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusResponse) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateOrderStatusResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// Watch order request
+type WatchOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	OrderId       uint32                 `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	MenuItemId    uint32                 `protobuf:"varint,3,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderItem) Reset() {
-	*x = OrderItem{}
-	mi := &file_order_v1_order_proto_msgTypes[0]
+func (x *WatchOrderRequest) Reset() {
+	*x = WatchOrderRequest{}
+	mi := &file_order_v1_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrderRequest) ProtoMessage() {}
+
+func (x *WatchOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrderRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WatchOrderRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Watch order response, sent once on subscribe and again after every status change
+type WatchOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrderResponse) Reset() {
+	*x = WatchOrderResponse{}
+	mi := &file_order_v1_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrderResponse) ProtoMessage() {}
+
+func (x *WatchOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrderResponse.ProtoReflect.Descriptor instead.
+func (*WatchOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *WatchOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// PromotionWindow is a weekly time range during which a promotion applies
+type PromotionWindow struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Day of the week in lower case, e.g. "monday"
+	Weekday string `protobuf:"bytes,1,opt,name=weekday,proto3" json:"weekday,omitempty"`
+	// Start of the window, inclusive, as "HH:MM" in the cafe's time zone
+	StartTime string `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// End of the window, exclusive, as "HH:MM"; "24:00" is the end of the day
+	EndTime       string `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromotionWindow) Reset() {
+	*x = PromotionWindow{}
+	mi := &file_order_v1_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromotionWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromotionWindow) ProtoMessage() {}
+
+func (x *PromotionWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromotionWindow.ProtoReflect.Descriptor instead.
+func (*PromotionWindow) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PromotionWindow) GetWeekday() string {
+	if x != nil {
+		return x.Weekday
+	}
+	return ""
+}
+
+func (x *PromotionWindow) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *PromotionWindow) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+// Promotion is a discount rule evaluated against every new order.
+//
+// A rule targets order lines by menu item or category; a rule without targets
+// applies to every line. Kinds:
+//   - "percentage": percent_off of each targeted unit
+//   - "fixed": amount_off each targeted unit
+//   - "bogo": for every buy_quantity targeted units bought, free_quantity units are
+//     free, the cheapest first. The free units are drawn from free_menu_item_ids and
+//     free_category_ids, or from the targets when neither is set.
+//
+// Rules are applied in priority order, highest first. Stackable rules combine,
+// each discounting what earlier ones left of a unit's price. An exclusive rule
+// never combines with another; it is used alone when it gives a larger discount
+// than all stackable rules together.
+type Promotion struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind  string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	// For "percentage" rules, from 1 to 100
+	PercentOff int32 `protobuf:"varint,4,opt,name=percent_off,json=percentOff,proto3" json:"percent_off,omitempty"`
+	// For "fixed" rules; applies only to orders in its currency
+	AmountOff *v1.Money `protobuf:"bytes,5,opt,name=amount_off,json=amountOff,proto3" json:"amount_off,omitempty"`
+	// For "bogo" rules
+	BuyQuantity     int32    `protobuf:"varint,6,opt,name=buy_quantity,json=buyQuantity,proto3" json:"buy_quantity,omitempty"`
+	FreeQuantity    int32    `protobuf:"varint,7,opt,name=free_quantity,json=freeQuantity,proto3" json:"free_quantity,omitempty"`
+	MenuItemIds     []uint32 `protobuf:"varint,8,rep,packed,name=menu_item_ids,json=menuItemIds,proto3" json:"menu_item_ids,omitempty"`
+	CategoryIds     []uint32 `protobuf:"varint,9,rep,packed,name=category_ids,json=categoryIds,proto3" json:"category_ids,omitempty"`
+	FreeMenuItemIds []uint32 `protobuf:"varint,10,rep,packed,name=free_menu_item_ids,json=freeMenuItemIds,proto3" json:"free_menu_item_ids,omitempty"`
+	FreeCategoryIds []uint32 `protobuf:"varint,11,rep,packed,name=free_category_ids,json=freeCategoryIds,proto3" json:"free_category_ids,omitempty"`
+	// When the rule applies, in the cafe's time zone. Empty means at any time.
+	Windows []*PromotionWindow `protobuf:"bytes,12,rep,name=windows,proto3" json:"windows,omitempty"`
+	// Optional RFC 3339 times the rule applies from and until
+	StartsAt      string `protobuf:"bytes,13,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        string `protobuf:"bytes,14,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Exclusive     bool   `protobuf:"varint,15,opt,name=exclusive,proto3" json:"exclusive,omitempty"`
+	Priority      int32  `protobuf:"varint,16,opt,name=priority,proto3" json:"priority,omitempty"`
+	CreatedAt     string `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Promotion) Reset() {
+	*x = Promotion{}
+	mi := &file_order_v1_order_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderItem) String() string {
+func (x *Promotion) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderItem) ProtoMessage() {}
+func (*Promotion) ProtoMessage() {}
 
-func (x *OrderItem) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_proto_msgTypes[0]
+func (x *Promotion) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -60,169 +1292,153 @@ func (x *OrderItem) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderItem.ProtoReflect.Descriptor instead.
-func (*OrderItem) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use Promotion.ProtoReflect.Descriptor instead.
+func (*Promotion) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *OrderItem) GetId() uint32 {
+func (x *Promotion) GetId() uint32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *OrderItem) GetOrderId() uint32 {
+func (x *Promotion) GetName() string {
 	if x != nil {
-		return x.OrderId
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *OrderItem) GetMenuItemId() uint32 {
+func (x *Promotion) GetKind() string {
 	if x != nil {
-		return x.MenuItemId
+		return x.Kind
 	}
-	return 0
+	return ""
 }
 
-func (x *OrderItem) GetQuantity() int32 {
+func (x *Promotion) GetPercentOff() int32 {
 	if x != nil {
-		return x.Quantity
+		return x.PercentOff
 	}
 	return 0
 }
 
-func (x *OrderItem) GetPrice() float64 {
+func (x *Promotion) GetAmountOff() *v1.Money {
 	if x != nil {
-		return x.Price
+		return x.AmountOff
 	}
-	return 0
+	return nil
 }
 
-func (x *OrderItem) GetCreatedAt() string {
+func (x *Promotion) GetBuyQuantity() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.BuyQuantity
 	}
-	return ""
+	return 0
 }
 
-func (x *OrderItem) GetUpdatedAt() string {
+func (x *Promotion) GetFreeQuantity() int32 {
 	if x != nil {
-		return x.UpdatedAt
+		return x.FreeQuantity
 	}
-	return ""
-}
-
-// Order message definition
-type Order struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        uint32                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	OrderItems    []*OrderItem           `protobuf:"bytes,4,rep,name=order_items,json=orderItems,proto3" json:"order_items,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return 0
 }
 
-func (x *Order) Reset() {
-	*x = Order{}
-	mi := &file_order_v1_order_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Promotion) GetMenuItemIds() []uint32 {
+	if x != nil {
+		return x.MenuItemIds
+	}
+	return nil
 }
 
-func (x *Order) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Promotion) GetCategoryIds() []uint32 {
+	if x != nil {
+		return x.CategoryIds
+	}
+	return nil
 }
 
-func (*Order) ProtoMessage() {}
-
-func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_proto_msgTypes[1]
+func (x *Promotion) GetFreeMenuItemIds() []uint32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.FreeMenuItemIds
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use Order.ProtoReflect.Descriptor instead.
-func (*Order) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_proto_rawDescGZIP(), []int{1}
+func (x *Promotion) GetFreeCategoryIds() []uint32 {
+	if x != nil {
+		return x.FreeCategoryIds
+	}
+	return nil
 }
 
-func (x *Order) GetId() uint32 {
+func (x *Promotion) GetWindows() []*PromotionWindow {
 	if x != nil {
-		return x.Id
+		return x.Windows
 	}
-	return 0
+	return nil
 }
 
-func (x *Order) GetUserId() uint32 {
+func (x *Promotion) GetStartsAt() string {
 	if x != nil {
-		return x.UserId
+		return x.StartsAt
 	}
-	return 0
+	return ""
 }
 
-func (x *Order) GetStatus() string {
+func (x *Promotion) GetEndsAt() string {
 	if x != nil {
-		return x.Status
+		return x.EndsAt
 	}
 	return ""
 }
 
-func (x *Order) GetOrderItems() []*OrderItem {
+func (x *Promotion) GetExclusive() bool {
 	if x != nil {
-		return x.OrderItems
+		return x.Exclusive
 	}
-	return nil
+	return false
 }
 
-func (x *Order) GetCreatedAt() string {
+func (x *Promotion) GetPriority() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Priority
 	}
-	return ""
+	return 0
 }
 
-func (x *Order) GetUpdatedAt() string {
+func (x *Promotion) GetCreatedAt() string {
 	if x != nil {
-		return x.UpdatedAt
+		return x.CreatedAt
 	}
 	return ""
 }
 
-// Item in create order request
-type OrderItemRequest struct {
+// Create promotion request; the promotion's id and created_at are ignored
+type CreatePromotionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MenuItemId    uint32                 `protobuf:"varint,1,opt,name=menu_item_id,json=menuItemId,proto3" json:"menu_item_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderItemRequest) Reset() {
-	*x = OrderItemRequest{}
-	mi := &file_order_v1_order_proto_msgTypes[2]
+func (x *CreatePromotionRequest) Reset() {
+	*x = CreatePromotionRequest{}
+	mi := &file_order_v1_order_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderItemRequest) String() string {
+func (x *CreatePromotionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderItemRequest) ProtoMessage() {}
+func (*CreatePromotionRequest) ProtoMessage() {}
 
-func (x *OrderItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_proto_msgTypes[2]
+func (x *CreatePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -233,49 +1449,41 @@ func (x *OrderItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderItemRequest.ProtoReflect.Descriptor instead.
-func (*OrderItemRequest) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_proto_rawDescGZIP(), []int{2}
-}
-
-func (x *OrderItemRequest) GetMenuItemId() uint32 {
-	if x != nil {
-		return x.MenuItemId
-	}
-	return 0
+// Deprecated: Use CreatePromotionRequest.ProtoReflect.Descriptor instead.
+func (*CreatePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *OrderItemRequest) GetQuantity() int32 {
+func (x *CreatePromotionRequest) GetPromotion() *Promotion {
 	if x != nil {
-		return x.Quantity
+		return x.Promotion
 	}
-	return 0
+	return nil
 }
 
-// Create order request
-type CreateOrderRequest struct {
+// Create promotion response
+type CreatePromotionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*OrderItemRequest    `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOrderRequest) Reset() {
-	*x = CreateOrderRequest{}
-	mi := &file_order_v1_order_proto_msgTypes[3]
+func (x *CreatePromotionResponse) Reset() {
+	*x = CreatePromotionResponse{}
+	mi := &file_order_v1_order_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOrderRequest) String() string {
+func (x *CreatePromotionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOrderRequest) ProtoMessage() {}
+func (*CreatePromotionResponse) ProtoMessage() {}
 
-func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_proto_msgTypes[3]
+func (x *CreatePromotionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -286,48 +1494,78 @@ func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
-func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use CreatePromotionResponse.ProtoReflect.Descriptor instead.
+func (*CreatePromotionResponse) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *CreateOrderRequest) GetUserId() uint32 {
+func (x *CreatePromotionResponse) GetPromotion() *Promotion {
 	if x != nil {
-		return x.UserId
+		return x.Promotion
 	}
-	return 0
+	return nil
 }
 
-func (x *CreateOrderRequest) GetItems() []*OrderItemRequest {
+// List promotions request
+type ListPromotionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromotionsRequest) Reset() {
+	*x = ListPromotionsRequest{}
+	mi := &file_order_v1_order_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromotionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsRequest) ProtoMessage() {}
+
+func (x *ListPromotionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[21]
 	if x != nil {
-		return x.Items
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-// Create order response
-type CreateOrderResponse struct {
+// Deprecated: Use ListPromotionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPromotionsRequest) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{21}
+}
+
+// List promotions response, highest priority first
+type ListPromotionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Promotions    []*Promotion           `protobuf:"bytes,1,rep,name=promotions,proto3" json:"promotions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOrderResponse) Reset() {
-	*x = CreateOrderResponse{}
-	mi := &file_order_v1_order_proto_msgTypes[4]
+func (x *ListPromotionsResponse) Reset() {
+	*x = ListPromotionsResponse{}
+	mi := &file_order_v1_order_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOrderResponse) String() string {
+func (x *ListPromotionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOrderResponse) ProtoMessage() {}
+func (*ListPromotionsResponse) ProtoMessage() {}
 
-func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_proto_msgTypes[4]
+func (x *ListPromotionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -338,40 +1576,41 @@ func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOrderResponse.ProtoReflect.Descriptor instead.
-func (*CreateOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ListPromotionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPromotionsResponse) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *CreateOrderResponse) GetOrder() *Order {
+func (x *ListPromotionsResponse) GetPromotions() []*Promotion {
 	if x != nil {
-		return x.Order
+		return x.Promotions
 	}
 	return nil
 }
 
-// Get orders request (empty for now)
-type GetOrdersRequest struct {
+// Delete promotion request
+type DeletePromotionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrdersRequest) Reset() {
-	*x = GetOrdersRequest{}
-	mi := &file_order_v1_order_proto_msgTypes[5]
+func (x *DeletePromotionRequest) Reset() {
+	*x = DeletePromotionRequest{}
+	mi := &file_order_v1_order_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrdersRequest) String() string {
+func (x *DeletePromotionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrdersRequest) ProtoMessage() {}
+func (*DeletePromotionRequest) ProtoMessage() {}
 
-func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_proto_msgTypes[5]
+func (x *DeletePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -382,34 +1621,40 @@ func (x *GetOrdersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrdersRequest.ProtoReflect.Descriptor instead.
-func (*GetOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use DeletePromotionRequest.ProtoReflect.Descriptor instead.
+func (*DeletePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{23}
 }
 
-// Get orders response
-type GetOrdersResponse struct {
+func (x *DeletePromotionRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Delete promotion response
+type DeletePromotionResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrdersResponse) Reset() {
-	*x = GetOrdersResponse{}
-	mi := &file_order_v1_order_proto_msgTypes[6]
+func (x *DeletePromotionResponse) Reset() {
+	*x = DeletePromotionResponse{}
+	mi := &file_order_v1_order_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrdersResponse) String() string {
+func (x *DeletePromotionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrdersResponse) ProtoMessage() {}
+func (*DeletePromotionResponse) ProtoMessage() {}
 
-func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_proto_msgTypes[6]
+func (x *DeletePromotionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -420,41 +1665,34 @@ func (x *GetOrdersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrdersResponse.ProtoReflect.Descriptor instead.
-func (*GetOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *GetOrdersResponse) GetOrders() []*Order {
-	if x != nil {
-		return x.Orders
-	}
-	return nil
+// Deprecated: Use DeletePromotionResponse.ProtoReflect.Descriptor instead.
+func (*DeletePromotionResponse) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{24}
 }
 
-// Get order request
-type GetOrderRequest struct {
+// Detach user orders request
+type DetachUserOrdersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        uint32                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderRequest) Reset() {
-	*x = GetOrderRequest{}
-	mi := &file_order_v1_order_proto_msgTypes[7]
+func (x *DetachUserOrdersRequest) Reset() {
+	*x = DetachUserOrdersRequest{}
+	mi := &file_order_v1_order_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderRequest) String() string {
+func (x *DetachUserOrdersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderRequest) ProtoMessage() {}
+func (*DetachUserOrdersRequest) ProtoMessage() {}
 
-func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_proto_msgTypes[7]
+func (x *DetachUserOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -465,41 +1703,42 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
-func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use DetachUserOrdersRequest.ProtoReflect.Descriptor instead.
+func (*DetachUserOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *GetOrderRequest) GetId() uint32 {
+func (x *DetachUserOrdersRequest) GetUserId() uint32 {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return 0
 }
 
-// Get order response
-type GetOrderResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Detach user orders response
+type DetachUserOrdersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Orders detached by this call; 0 when they were detached before
+	OrdersDetached int64 `protobuf:"varint,1,opt,name=orders_detached,json=ordersDetached,proto3" json:"orders_detached,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *GetOrderResponse) Reset() {
-	*x = GetOrderResponse{}
-	mi := &file_order_v1_order_proto_msgTypes[8]
+func (x *DetachUserOrdersResponse) Reset() {
+	*x = DetachUserOrdersResponse{}
+	mi := &file_order_v1_order_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderResponse) String() string {
+func (x *DetachUserOrdersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderResponse) ProtoMessage() {}
+func (*DetachUserOrdersResponse) ProtoMessage() {}
 
-func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_v1_order_proto_msgTypes[8]
+func (x *DetachUserOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_v1_order_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -510,34 +1749,63 @@ func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
-func (*GetOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_v1_order_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use DetachUserOrdersResponse.ProtoReflect.Descriptor instead.
+func (*DetachUserOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_v1_order_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *GetOrderResponse) GetOrder() *Order {
+func (x *DetachUserOrdersResponse) GetOrdersDetached() int64 {
 	if x != nil {
-		return x.Order
+		return x.OrdersDetached
 	}
-	return nil
+	return 0
 }
 
 var File_order_v1_order_proto protoreflect.FileDescriptor
 
 const file_order_v1_order_proto_rawDesc = "" +
 	"\n" +
-	"\x14order/v1/order.proto\x12\border.v1\"\xc8\x01\n" +
+	"\x14order/v1/order.proto\x12\border.v1\x1a\x15common/v1/money.proto\"\xe6\x02\n" +
 	"\tOrderItem\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x19\n" +
 	"\border_id\x18\x02 \x01(\rR\aorderId\x12 \n" +
 	"\fmenu_item_id\x18\x03 \x01(\rR\n" +
 	"menuItemId\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1d\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\tR\tupdatedAt\"\xbc\x01\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12&\n" +
+	"\x05price\x18\t \x01(\v2\x10.common.v1.MoneyR\x05price\x12/\n" +
+	"\n" +
+	"line_total\x18\n" +
+	" \x01(\v2\x10.common.v1.MoneyR\tlineTotal\x129\n" +
+	"\tmodifiers\x18\v \x03(\v2\x1b.order.v1.OrderItemModifierR\tmodifiers\x12\x12\n" +
+	"\x04note\x18\f \x01(\tR\x04noteJ\x04\b\x05\x10\x06J\x04\b\b\x10\t\"\x9a\x01\n" +
+	"\x11OrderItemModifier\x12\x1f\n" +
+	"\vmodifier_id\x18\x01 \x01(\rR\n" +
+	"modifierId\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x02 \x01(\tR\tgroupName\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x121\n" +
+	"\vprice_delta\x18\x04 \x01(\v2\x10.common.v1.MoneyR\n" +
+	"priceDelta\"\x8a\x01\n" +
+	"\x15OrderStatusTransition\x12\x1f\n" +
+	"\vfrom_status\x18\x01 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\x02 \x01(\tR\btoStatus\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\"b\n" +
+	"\bOrderTax\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04rate\x18\x02 \x01(\x01R\x04rate\x12(\n" +
+	"\x06amount\x18\x04 \x01(\v2\x10.common.v1.MoneyR\x06amountJ\x04\b\x03\x10\x04\"\x84\x01\n" +
+	"\rOrderDiscount\x12!\n" +
+	"\fpromotion_id\x18\x01 \x01(\rR\vpromotionId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12(\n" +
+	"\x06amount\x18\x04 \x01(\v2\x10.common.v1.MoneyR\x06amount\"\xbd\x04\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\rR\x06userId\x12\x16\n" +
@@ -547,27 +1815,105 @@ const file_order_v1_order_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\tR\tupdatedAt\"P\n" +
+	"updated_at\x18\x06 \x01(\tR\tupdatedAt\x12F\n" +
+	"\x0estatus_history\x18\a \x03(\v2\x1f.order.v1.OrderStatusTransitionR\rstatusHistory\x12(\n" +
+	"\x05taxes\x18\f \x03(\v2\x12.order.v1.OrderTaxR\x05taxes\x12,\n" +
+	"\bsubtotal\x18\r \x01(\v2\x10.common.v1.MoneyR\bsubtotal\x12,\n" +
+	"\bdiscount\x18\x0e \x01(\v2\x10.common.v1.MoneyR\bdiscount\x12\"\n" +
+	"\x03tax\x18\x0f \x01(\v2\x10.common.v1.MoneyR\x03tax\x12&\n" +
+	"\x05total\x18\x10 \x01(\v2\x10.common.v1.MoneyR\x05total\x125\n" +
+	"\tdiscounts\x18\x11 \x03(\v2\x17.order.v1.OrderDiscountR\tdiscounts\x12(\n" +
+	"\x10user_detached_at\x18\x12 \x01(\tR\x0euserDetachedAtJ\x04\b\b\x10\f\"\x87\x01\n" +
 	"\x10OrderItemRequest\x12 \n" +
 	"\fmenu_item_id\x18\x01 \x01(\rR\n" +
 	"menuItemId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"_\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12!\n" +
+	"\fmodifier_ids\x18\x03 \x03(\rR\vmodifierIds\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\"_\n" +
 	"\x12CreateOrderRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\rR\x06userId\x120\n" +
 	"\x05items\x18\x02 \x03(\v2\x1a.order.v1.OrderItemRequestR\x05items\"<\n" +
 	"\x13CreateOrderResponse\x12%\n" +
-	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\"\x12\n" +
-	"\x10GetOrdersRequest\"<\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\"\xe6\x01\n" +
+	"\x10GetOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12#\n" +
+	"\rcreated_after\x18\x03 \x01(\tR\fcreatedAfter\x12%\n" +
+	"\x0ecreated_before\x18\x04 \x01(\tR\rcreatedBefore\x12\x19\n" +
+	"\border_by\x18\x05 \x01(\tR\aorderBy\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"d\n" +
 	"\x11GetOrdersResponse\x12'\n" +
-	"\x06orders\x18\x01 \x03(\v2\x0f.order.v1.OrderR\x06orders\"!\n" +
+	"\x06orders\x18\x01 \x03(\v2\x0f.order.v1.OrderR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"!\n" +
 	"\x0fGetOrderRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\"9\n" +
 	"\x10GetOrderResponse\x12%\n" +
-	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order2\xe3\x01\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\"O\n" +
+	"\x18UpdateOrderStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06statusJ\x04\b\x03\x10\x04R\x05actor\"B\n" +
+	"\x19UpdateOrderStatusResponse\x12%\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\"#\n" +
+	"\x11WatchOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\";\n" +
+	"\x12WatchOrderResponse\x12%\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderR\x05order\"e\n" +
+	"\x0fPromotionWindow\x12\x18\n" +
+	"\aweekday\x18\x01 \x01(\tR\aweekday\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x02 \x01(\tR\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x03 \x01(\tR\aendTime\"\xc1\x04\n" +
+	"\tPromotion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x1f\n" +
+	"\vpercent_off\x18\x04 \x01(\x05R\n" +
+	"percentOff\x12/\n" +
+	"\n" +
+	"amount_off\x18\x05 \x01(\v2\x10.common.v1.MoneyR\tamountOff\x12!\n" +
+	"\fbuy_quantity\x18\x06 \x01(\x05R\vbuyQuantity\x12#\n" +
+	"\rfree_quantity\x18\a \x01(\x05R\ffreeQuantity\x12\"\n" +
+	"\rmenu_item_ids\x18\b \x03(\rR\vmenuItemIds\x12!\n" +
+	"\fcategory_ids\x18\t \x03(\rR\vcategoryIds\x12+\n" +
+	"\x12free_menu_item_ids\x18\n" +
+	" \x03(\rR\x0ffreeMenuItemIds\x12*\n" +
+	"\x11free_category_ids\x18\v \x03(\rR\x0ffreeCategoryIds\x123\n" +
+	"\awindows\x18\f \x03(\v2\x19.order.v1.PromotionWindowR\awindows\x12\x1b\n" +
+	"\tstarts_at\x18\r \x01(\tR\bstartsAt\x12\x17\n" +
+	"\aends_at\x18\x0e \x01(\tR\x06endsAt\x12\x1c\n" +
+	"\texclusive\x18\x0f \x01(\bR\texclusive\x12\x1a\n" +
+	"\bpriority\x18\x10 \x01(\x05R\bpriority\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\tR\tcreatedAt\"K\n" +
+	"\x16CreatePromotionRequest\x121\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x13.order.v1.PromotionR\tpromotion\"L\n" +
+	"\x17CreatePromotionResponse\x121\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x13.order.v1.PromotionR\tpromotion\"\x17\n" +
+	"\x15ListPromotionsRequest\"M\n" +
+	"\x16ListPromotionsResponse\x123\n" +
+	"\n" +
+	"promotions\x18\x01 \x03(\v2\x13.order.v1.PromotionR\n" +
+	"promotions\"(\n" +
+	"\x16DeletePromotionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\"\x19\n" +
+	"\x17DeletePromotionResponse\"2\n" +
+	"\x17DetachUserOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\rR\x06userId\"C\n" +
+	"\x18DetachUserOrdersResponse\x12'\n" +
+	"\x0forders_detached\x18\x01 \x01(\x03R\x0eordersDetached2\xec\x05\n" +
 	"\fOrderService\x12J\n" +
 	"\vCreateOrder\x12\x1c.order.v1.CreateOrderRequest\x1a\x1d.order.v1.CreateOrderResponse\x12D\n" +
 	"\tGetOrders\x12\x1a.order.v1.GetOrdersRequest\x1a\x1b.order.v1.GetOrdersResponse\x12A\n" +
-	"\bGetOrder\x12\x19.order.v1.GetOrderRequest\x1a\x1a.order.v1.GetOrderResponseBCZAgithub.com/douglasswm/student-cafe-protos/gen/go/order/v1;orderv1b\x06proto3"
+	"\bGetOrder\x12\x19.order.v1.GetOrderRequest\x1a\x1a.order.v1.GetOrderResponse\x12\\\n" +
+	"\x11UpdateOrderStatus\x12\".order.v1.UpdateOrderStatusRequest\x1a#.order.v1.UpdateOrderStatusResponse\x12I\n" +
+	"\n" +
+	"WatchOrder\x12\x1b.order.v1.WatchOrderRequest\x1a\x1c.order.v1.WatchOrderResponse0\x01\x12V\n" +
+	"\x0fCreatePromotion\x12 .order.v1.CreatePromotionRequest\x1a!.order.v1.CreatePromotionResponse\x12S\n" +
+	"\x0eListPromotions\x12\x1f.order.v1.ListPromotionsRequest\x1a .order.v1.ListPromotionsResponse\x12V\n" +
+	"\x0fDeletePromotion\x12 .order.v1.DeletePromotionRequest\x1a!.order.v1.DeletePromotionResponse\x12Y\n" +
+	"\x10DetachUserOrders\x12!.order.v1.DetachUserOrdersRequest\x1a\".order.v1.DetachUserOrdersResponseBCZAgithub.com/douglasswm/student-cafe-protos/gen/go/order/v1;orderv1b\x06proto3"
 
 var (
 	file_order_v1_order_proto_rawDescOnce sync.Once
@@ -581,35 +1927,86 @@ func file_order_v1_order_proto_rawDescGZIP() []byte {
 	return file_order_v1_order_proto_rawDescData
 }
 
-var file_order_v1_order_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_order_v1_order_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_order_v1_order_proto_goTypes = []any{
-	(*OrderItem)(nil),           // 0: order.v1.OrderItem
-	(*Order)(nil),               // 1: order.v1.Order
-	(*OrderItemRequest)(nil),    // 2: order.v1.OrderItemRequest
-	(*CreateOrderRequest)(nil),  // 3: order.v1.CreateOrderRequest
-	(*CreateOrderResponse)(nil), // 4: order.v1.CreateOrderResponse
-	(*GetOrdersRequest)(nil),    // 5: order.v1.GetOrdersRequest
-	(*GetOrdersResponse)(nil),   // 6: order.v1.GetOrdersResponse
-	(*GetOrderRequest)(nil),     // 7: order.v1.GetOrderRequest
-	(*GetOrderResponse)(nil),    // 8: order.v1.GetOrderResponse
+	(*OrderItem)(nil),                 // 0: order.v1.OrderItem
+	(*OrderItemModifier)(nil),         // 1: order.v1.OrderItemModifier
+	(*OrderStatusTransition)(nil),     // 2: order.v1.OrderStatusTransition
+	(*OrderTax)(nil),                  // 3: order.v1.OrderTax
+	(*OrderDiscount)(nil),             // 4: order.v1.OrderDiscount
+	(*Order)(nil),                     // 5: order.v1.Order
+	(*OrderItemRequest)(nil),          // 6: order.v1.OrderItemRequest
+	(*CreateOrderRequest)(nil),        // 7: order.v1.CreateOrderRequest
+	(*CreateOrderResponse)(nil),       // 8: order.v1.CreateOrderResponse
+	(*GetOrdersRequest)(nil),          // 9: order.v1.GetOrdersRequest
+	(*GetOrdersResponse)(nil),         // 10: order.v1.GetOrdersResponse
+	(*GetOrderRequest)(nil),           // 11: order.v1.GetOrderRequest
+	(*GetOrderResponse)(nil),          // 12: order.v1.GetOrderResponse
+	(*UpdateOrderStatusRequest)(nil),  // 13: order.v1.UpdateOrderStatusRequest
+	(*UpdateOrderStatusResponse)(nil), // 14: order.v1.UpdateOrderStatusResponse
+	(*WatchOrderRequest)(nil),         // 15: order.v1.WatchOrderRequest
+	(*WatchOrderResponse)(nil),        // 16: order.v1.WatchOrderResponse
+	(*PromotionWindow)(nil),           // 17: order.v1.PromotionWindow
+	(*Promotion)(nil),                 // 18: order.v1.Promotion
+	(*CreatePromotionRequest)(nil),    // 19: order.v1.CreatePromotionRequest
+	(*CreatePromotionResponse)(nil),   // 20: order.v1.CreatePromotionResponse
+	(*ListPromotionsRequest)(nil),     // 21: order.v1.ListPromotionsRequest
+	(*ListPromotionsResponse)(nil),    // 22: order.v1.ListPromotionsResponse
+	(*DeletePromotionRequest)(nil),    // 23: order.v1.DeletePromotionRequest
+	(*DeletePromotionResponse)(nil),   // 24: order.v1.DeletePromotionResponse
+	(*DetachUserOrdersRequest)(nil),   // 25: order.v1.DetachUserOrdersRequest
+	(*DetachUserOrdersResponse)(nil),  // 26: order.v1.DetachUserOrdersResponse
+	(*v1.Money)(nil),                  // 27: common.v1.Money
 }
 var file_order_v1_order_proto_depIdxs = []int32{
-	0, // 0: order.v1.Order.order_items:type_name -> order.v1.OrderItem
-	2, // 1: order.v1.CreateOrderRequest.items:type_name -> order.v1.OrderItemRequest
-	1, // 2: order.v1.CreateOrderResponse.order:type_name -> order.v1.Order
-	1, // 3: order.v1.GetOrdersResponse.orders:type_name -> order.v1.Order
-	1, // 4: order.v1.GetOrderResponse.order:type_name -> order.v1.Order
-	3, // 5: order.v1.OrderService.CreateOrder:input_type -> order.v1.CreateOrderRequest
-	5, // 6: order.v1.OrderService.GetOrders:input_type -> order.v1.GetOrdersRequest
-	7, // 7: order.v1.OrderService.GetOrder:input_type -> order.v1.GetOrderRequest
-	4, // 8: order.v1.OrderService.CreateOrder:output_type -> order.v1.CreateOrderResponse
-	6, // 9: order.v1.OrderService.GetOrders:output_type -> order.v1.GetOrdersResponse
-	8, // 10: order.v1.OrderService.GetOrder:output_type -> order.v1.GetOrderResponse
-	8, // [8:11] is the sub-list for method output_type
-	5, // [5:8] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	27, // 0: order.v1.OrderItem.price:type_name -> common.v1.Money
+	27, // 1: order.v1.OrderItem.line_total:type_name -> common.v1.Money
+	1,  // 2: order.v1.OrderItem.modifiers:type_name -> order.v1.OrderItemModifier
+	27, // 3: order.v1.OrderItemModifier.price_delta:type_name -> common.v1.Money
+	27, // 4: order.v1.OrderTax.amount:type_name -> common.v1.Money
+	27, // 5: order.v1.OrderDiscount.amount:type_name -> common.v1.Money
+	0,  // 6: order.v1.Order.order_items:type_name -> order.v1.OrderItem
+	2,  // 7: order.v1.Order.status_history:type_name -> order.v1.OrderStatusTransition
+	3,  // 8: order.v1.Order.taxes:type_name -> order.v1.OrderTax
+	27, // 9: order.v1.Order.subtotal:type_name -> common.v1.Money
+	27, // 10: order.v1.Order.discount:type_name -> common.v1.Money
+	27, // 11: order.v1.Order.tax:type_name -> common.v1.Money
+	27, // 12: order.v1.Order.total:type_name -> common.v1.Money
+	4,  // 13: order.v1.Order.discounts:type_name -> order.v1.OrderDiscount
+	6,  // 14: order.v1.CreateOrderRequest.items:type_name -> order.v1.OrderItemRequest
+	5,  // 15: order.v1.CreateOrderResponse.order:type_name -> order.v1.Order
+	5,  // 16: order.v1.GetOrdersResponse.orders:type_name -> order.v1.Order
+	5,  // 17: order.v1.GetOrderResponse.order:type_name -> order.v1.Order
+	5,  // 18: order.v1.UpdateOrderStatusResponse.order:type_name -> order.v1.Order
+	5,  // 19: order.v1.WatchOrderResponse.order:type_name -> order.v1.Order
+	27, // 20: order.v1.Promotion.amount_off:type_name -> common.v1.Money
+	17, // 21: order.v1.Promotion.windows:type_name -> order.v1.PromotionWindow
+	18, // 22: order.v1.CreatePromotionRequest.promotion:type_name -> order.v1.Promotion
+	18, // 23: order.v1.CreatePromotionResponse.promotion:type_name -> order.v1.Promotion
+	18, // 24: order.v1.ListPromotionsResponse.promotions:type_name -> order.v1.Promotion
+	7,  // 25: order.v1.OrderService.CreateOrder:input_type -> order.v1.CreateOrderRequest
+	9,  // 26: order.v1.OrderService.GetOrders:input_type -> order.v1.GetOrdersRequest
+	11, // 27: order.v1.OrderService.GetOrder:input_type -> order.v1.GetOrderRequest
+	13, // 28: order.v1.OrderService.UpdateOrderStatus:input_type -> order.v1.UpdateOrderStatusRequest
+	15, // 29: order.v1.OrderService.WatchOrder:input_type -> order.v1.WatchOrderRequest
+	19, // 30: order.v1.OrderService.CreatePromotion:input_type -> order.v1.CreatePromotionRequest
+	21, // 31: order.v1.OrderService.ListPromotions:input_type -> order.v1.ListPromotionsRequest
+	23, // 32: order.v1.OrderService.DeletePromotion:input_type -> order.v1.DeletePromotionRequest
+	25, // 33: order.v1.OrderService.DetachUserOrders:input_type -> order.v1.DetachUserOrdersRequest
+	8,  // 34: order.v1.OrderService.CreateOrder:output_type -> order.v1.CreateOrderResponse
+	10, // 35: order.v1.OrderService.GetOrders:output_type -> order.v1.GetOrdersResponse
+	12, // 36: order.v1.OrderService.GetOrder:output_type -> order.v1.GetOrderResponse
+	14, // 37: order.v1.OrderService.UpdateOrderStatus:output_type -> order.v1.UpdateOrderStatusResponse
+	16, // 38: order.v1.OrderService.WatchOrder:output_type -> order.v1.WatchOrderResponse
+	20, // 39: order.v1.OrderService.CreatePromotion:output_type -> order.v1.CreatePromotionResponse
+	22, // 40: order.v1.OrderService.ListPromotions:output_type -> order.v1.ListPromotionsResponse
+	24, // 41: order.v1.OrderService.DeletePromotion:output_type -> order.v1.DeletePromotionResponse
+	26, // 42: order.v1.OrderService.DetachUserOrders:output_type -> order.v1.DetachUserOrdersResponse
+	34, // [34:43] is the sub-list for method output_type
+	25, // [25:34] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_order_v1_order_proto_init() }
@@ -623,7 +2020,7 @@ func file_order_v1_order_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_v1_order_proto_rawDesc), len(file_order_v1_order_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,88 @@
+// Package mtls secures the gRPC connections between the API gateway and the
+// services with mutual TLS. Each of them holds a certificate signed by the
+// cafe's own CA and only talks to peers presenting one, so the caller metadata
+// of package principal can only come from inside the cafe.
+package mtls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// Environment variables naming the PEM files of a Config
+const (
+	CertFileEnv = "TLS_CERT_FILE"
+	KeyFileEnv  = "TLS_KEY_FILE"
+	CAFileEnv   = "TLS_CA_FILE"
+)
+
+// Config names the PEM files a service or the gateway authenticates with
+type Config struct {
+	CertFile string // Own certificate, used as server and as client
+	KeyFile  string // Private key of CertFile
+	CAFile   string // CA that peers' certificates must be signed by
+}
+
+// FromEnv reads the Config from TLS_CERT_FILE, TLS_KEY_FILE and TLS_CA_FILE,
+// all of which are required
+func FromEnv() (Config, error) {
+	c := Config{
+		CertFile: os.Getenv(CertFileEnv),
+		KeyFile:  os.Getenv(KeyFileEnv),
+		CAFile:   os.Getenv(CAFileEnv),
+	}
+	if c.CertFile == "" || c.KeyFile == "" || c.CAFile == "" {
+		return Config{}, fmt.Errorf("%s, %s and %s must all be set", CertFileEnv, KeyFileEnv, CAFileEnv)
+	}
+	return c, nil
+}
+
+// ServerCredentials returns credentials for a gRPC server that only accepts
+// clients with a certificate signed by the CA
+func (c Config) ServerCredentials() (credentials.TransportCredentials, error) {
+	cert, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS13,
+	}), nil
+}
+
+// ClientCredentials returns credentials for gRPC clients that present the
+// certificate and only trust servers signed by the CA
+func (c Config) ClientCredentials() (credentials.TransportCredentials, error) {
+	cert, pool, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS13,
+	}), nil
+}
+
+// load reads the key pair and the CA
+func (c Config) load() (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to load certificate: %w", err)
+	}
+	caPEM, err := os.ReadFile(c.CAFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to read CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return tls.Certificate{}, nil, fmt.Errorf("no certificates found in %s", c.CAFile)
+	}
+	return cert, pool, nil
+}
\ No newline at end of file
@@ -0,0 +1,191 @@
+package mtls
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// testCA signs certificates for tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newTestCA(t *testing.T, dir, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca := &testCA{cert: cert, key: key, file: filepath.Join(dir, name+".pem")}
+	writePEM(t, ca.file, "CERTIFICATE", der)
+	return ca
+}
+
+// issue writes a certificate for 127.0.0.1 signed by the CA, and returns a
+// Config using it
+func (ca *testCA) issue(t *testing.T, dir, name string) Config {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := Config{
+		CertFile: filepath.Join(dir, name+".pem"),
+		KeyFile:  filepath.Join(dir, name+"-key.pem"),
+		CAFile:   ca.file,
+	}
+	writePEM(t, c.CertFile, "CERTIFICATE", der)
+	writePEM(t, c.KeyFile, "EC PRIVATE KEY", keyDER)
+	return c
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// serve starts a gRPC server with a health service and returns its address
+func serve(t *testing.T, creds credentials.TransportCredentials) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(grpc.Creds(creds))
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	return lis.Addr().String()
+}
+
+// check calls the health service at addr with creds
+func check(t *testing.T, addr string, creds credentials.TransportCredentials) error {
+	t.Helper()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, dir, "cafe-ca")
+	serverCreds, err := ca.issue(t, dir, "order-service").ServerCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := serve(t, serverCreds)
+
+	t.Run("client signed by the CA", func(t *testing.T) {
+		creds, err := ca.issue(t, dir, "api-gateway").ClientCredentials()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := check(t, addr, creds); err != nil {
+			t.Fatalf("call failed: %v", err)
+		}
+	})
+
+	t.Run("client without a certificate", func(t *testing.T) {
+		// Trusts the server, but cannot prove who it is
+		creds, err := credentials.NewClientTLSFromFile(ca.file, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := check(t, addr, creds); err == nil {
+			t.Fatal("call without a client certificate succeeded")
+		}
+	})
+
+	t.Run("client signed by another CA", func(t *testing.T) {
+		other := newTestCA(t, dir, "other-ca")
+		intruder := other.issue(t, dir, "intruder")
+		intruder.CAFile = ca.file // Trusts the server, but is not trusted by it
+		creds, err := intruder.ClientCredentials()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := check(t, addr, creds); err == nil {
+			t.Fatal("call with a certificate from another CA succeeded")
+		}
+	})
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv(CertFileEnv, "cert.pem")
+	t.Setenv(KeyFileEnv, "key.pem")
+	t.Setenv(CAFileEnv, "")
+	if _, err := FromEnv(); err == nil {
+		t.Fatal("FromEnv accepted a missing CA")
+	}
+
+	t.Setenv(CAFileEnv, "ca.pem")
+	c, err := FromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c != (Config{CertFile: "cert.pem", KeyFile: "key.pem", CAFile: "ca.pem"}) {
+		t.Fatalf("FromEnv() = %+v", c)
+	}
+}
\ No newline at end of file
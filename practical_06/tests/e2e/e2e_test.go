@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,6 +18,16 @@ import (
 // Test configuration
 var (
 	apiGatewayURL = getEnv("API_GATEWAY_URL", "http://localhost:8080")
+
+	// The cafe owner the User Service creates on start-up, see docker-compose.yml
+	ownerEmail    = getEnv("E2E_OWNER_EMAIL", "owner@cafe.local")
+	ownerPassword = getEnv("E2E_OWNER_PASSWORD", "change-me-please")
+)
+
+// The user the tests sign in as, the cafe owner, signed in by TestMain
+var (
+	accessToken  string
+	signedInUser User
 )
 
 func getEnv(key, defaultValue string) string {
@@ -27,45 +38,163 @@ func getEnv(key, defaultValue string) string {
 }
 
 // Response structures
+
+// Money is rendered with minor_units as a string, as proto JSON does for 64-bit integers
+type Money struct {
+	CurrencyCode string `json:"currency_code"`
+	MinorUnits   int64  `json:"minor_units,string"`
+}
+
 type User struct {
 	ID          uint   `json:"id"`
 	Name        string `json:"name"`
 	Email       string `json:"email"`
 	IsCafeOwner bool   `json:"is_cafe_owner"`
+	IsStaff     bool   `json:"is_staff"`
 	CreatedAt   string `json:"created_at"`
 	UpdatedAt   string `json:"updated_at"`
 }
 
+type TokenPair struct {
+	AccessToken           string `json:"access_token"`
+	RefreshToken          string `json:"refresh_token"`
+	TokenType             string `json:"token_type"`
+	AccessTokenExpiresAt  string `json:"access_token_expires_at"`
+	RefreshTokenExpiresAt string `json:"refresh_token_expires_at"`
+}
+
+type UserErasure struct {
+	ID             uint   `json:"id"`
+	UserID         uint   `json:"user_id"`
+	Status         string `json:"status"`
+	RequestedBy    string `json:"requested_by"`
+	RequestedAt    string `json:"requested_at"`
+	CompletedAt    string `json:"completed_at"`
+	OrdersDetached int64  `json:"orders_detached,string"`
+	Attempts       int    `json:"attempts"`
+	LastError      string `json:"last_error"`
+}
+
+type AuthResponse struct {
+	User   User      `json:"user"`
+	Tokens TokenPair `json:"tokens"`
+}
+
 type MenuItem struct {
-	ID          uint    `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	CreatedAt   string  `json:"created_at"`
-	UpdatedAt   string  `json:"updated_at"`
+	ID          uint     `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Price       Money    `json:"price"`
+	Stock       *int32   `json:"stock"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+	DeletedAt   string   `json:"deleted_at"`
+	CategoryID  uint     `json:"category_id"`
+	Allergens   []string `json:"allergens"`
+	DietaryTags []string `json:"dietary_tags"`
+	Calories    *int32   `json:"calories"`
+	Unavailable bool     `json:"unavailable"`
+
+	ModifierGroups []struct {
+		ID            uint   `json:"id"`
+		Name          string `json:"name"`
+		MinSelections int    `json:"min_selections"`
+		MaxSelections int    `json:"max_selections"`
+		Modifiers     []struct {
+			ID         uint   `json:"id"`
+			Name       string `json:"name"`
+			PriceDelta Money  `json:"price_delta"`
+		} `json:"modifiers"`
+	} `json:"modifier_groups"`
+}
+
+type Category struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Position    int    `json:"position"`
+}
+
+type MenuSection struct {
+	Category  *Category  `json:"category"`
+	MenuItems []MenuItem `json:"menu_items"`
+}
+
+type ImportReport struct {
+	Results []struct {
+		Row        int    `json:"row"`
+		Name       string `json:"name"`
+		Action     string `json:"action"`
+		Error      string `json:"error"`
+		MenuItemID uint   `json:"menu_item_id"`
+	} `json:"results"`
+	Created   int  `json:"created"`
+	Updated   int  `json:"updated"`
+	Failed    int  `json:"failed"`
+	Committed bool `json:"committed"`
+}
+
+type PriceChange struct {
+	ID            uint   `json:"id"`
+	MenuItemID    uint   `json:"menu_item_id"`
+	Price         Money  `json:"price"`
+	EffectiveFrom string `json:"effective_from"`
 }
 
 type OrderItem struct {
-	ID         uint    `json:"id"`
-	OrderID    uint    `json:"order_id"`
-	MenuItemID uint    `json:"menu_item_id"`
-	Quantity   int     `json:"quantity"`
-	Price      float64 `json:"price"`
-	CreatedAt  string  `json:"created_at"`
-	UpdatedAt  string  `json:"updated_at"`
+	ID         uint   `json:"id"`
+	OrderID    uint   `json:"order_id"`
+	MenuItemID uint   `json:"menu_item_id"`
+	Quantity   int    `json:"quantity"`
+	Price      Money  `json:"price"`
+	LineTotal  Money  `json:"line_total"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+	Note       string `json:"note"`
+	Modifiers  []struct {
+		ModifierID uint   `json:"modifier_id"`
+		GroupName  string `json:"group_name"`
+		Name       string `json:"name"`
+		PriceDelta Money  `json:"price_delta"`
+	} `json:"modifiers"`
+}
+
+type OrderStatusTransition struct {
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	Actor      string `json:"actor"`
+	CreatedAt  string `json:"created_at"`
 }
 
 type Order struct {
-	ID         uint        `json:"id"`
-	UserID     uint        `json:"user_id"`
-	Status     string      `json:"status"`
-	OrderItems []OrderItem `json:"order_items"`
-	CreatedAt  string      `json:"created_at"`
-	UpdatedAt  string      `json:"updated_at"`
+	ID             uint                    `json:"id"`
+	UserID         uint                    `json:"user_id"`
+	Status         string                  `json:"status"`
+	OrderItems     []OrderItem             `json:"order_items"`
+	StatusHistory  []OrderStatusTransition `json:"status_history"`
+	Subtotal       Money                   `json:"subtotal"`
+	Discount       Money                   `json:"discount"`
+	Tax            Money                   `json:"tax"`
+	Total          Money                   `json:"total"`
+	CreatedAt      string                  `json:"created_at"`
+	UpdatedAt      string                  `json:"updated_at"`
+	UserDetachedAt string                  `json:"user_detached_at"`
 }
 
 // Helper functions
+
+// usd returns a USD amount in cents
+func usd(cents int64) Money {
+	return Money{CurrencyCode: "USD", MinorUnits: cents}
+}
+
+// makeRequest sends a request as the signed-in user
 func makeRequest(method, path string, body interface{}) (*http.Response, error) {
+	return makeRequestAs(accessToken, method, path, body)
+}
+
+// makeRequestAs sends a request with an access token, or anonymously without one
+func makeRequestAs(token, method, path string, body interface{}) (*http.Response, error) {
 	var bodyReader io.Reader
 	if body != nil {
 		jsonData, err := json.Marshal(body)
@@ -83,17 +212,59 @@ func makeRequest(method, path string, body interface{}) (*http.Response, error)
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
 
 	client := &http.Client{Timeout: 10 * time.Second}
 	return client.Do(req)
 }
 
+// register signs up a new user and returns them with their tokens
+func register(name, email string) (AuthResponse, error) {
+	resp, err := makeRequestAs("", "POST", "/api/auth/register", map[string]interface{}{
+		"name":     name,
+		"email":    email,
+		"password": "e2e password",
+	})
+	if err != nil {
+		return AuthResponse{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return AuthResponse{}, fmt.Errorf("register returned %s", resp.Status)
+	}
+
+	var registered AuthResponse
+	err = json.NewDecoder(resp.Body).Decode(&registered)
+	return registered, err
+}
+
+// login signs a user in and returns them with their tokens
+func login(email, password string) (AuthResponse, error) {
+	resp, err := makeRequestAs("", "POST", "/api/auth/login", map[string]interface{}{
+		"email":    email,
+		"password": password,
+	})
+	if err != nil {
+		return AuthResponse{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return AuthResponse{}, fmt.Errorf("login returned %s", resp.Status)
+	}
+
+	var signedIn AuthResponse
+	err = json.NewDecoder(resp.Body).Decode(&signedIn)
+	return signedIn, err
+}
+
 func TestMain(m *testing.M) {
 	// Wait for services to be ready
 	fmt.Println("Waiting for services to be ready...")
 	maxRetries := 30
 	for i := 0; i < maxRetries; i++ {
-		resp, err := http.Get(apiGatewayURL + "/api/users")
+		resp, err := http.Get(apiGatewayURL + "/api/menu")
 		if err == nil && resp.StatusCode < 500 {
 			resp.Body.Close()
 			fmt.Println("Services are ready!")
@@ -106,6 +277,15 @@ func TestMain(m *testing.M) {
 		time.Sleep(2 * time.Second)
 	}
 
+	// Sign in as the owner, who may manage the menu and users
+	signedIn, err := login(ownerEmail, ownerPassword)
+	if err != nil {
+		fmt.Printf("Failed to sign in as the cafe owner %s: %v\n", ownerEmail, err)
+		os.Exit(1)
+	}
+	accessToken = signedIn.Tokens.AccessToken
+	signedInUser = signedIn.User
+
 	// Run tests
 	code := m.Run()
 	os.Exit(code)
@@ -203,11 +383,276 @@ func TestE2E_GetUserByID(t *testing.T) {
 	assert.Equal(t, createdUser.Email, user.Email)
 }
 
+func TestE2E_Authentication(t *testing.T) {
+	email := fmt.Sprintf("auth-%d@test.com", time.Now().UnixNano())
+	registered, err := register("Auth Test User", email)
+	require.NoError(t, err)
+	assert.Equal(t, email, registered.User.Email)
+	assert.False(t, registered.User.IsCafeOwner)
+	assert.Equal(t, "Bearer", registered.Tokens.TokenType)
+
+	t.Run("duplicate email", func(t *testing.T) {
+		_, err := register("Auth Test User", email)
+		assert.Error(t, err)
+	})
+
+	t.Run("login", func(t *testing.T) {
+		resp, err := makeRequestAs("", "POST", "/api/auth/login", map[string]interface{}{
+			"email":    email,
+			"password": "e2e password",
+		})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, err = makeRequestAs("", "POST", "/api/auth/login", map[string]interface{}{
+			"email":    email,
+			"password": "wrong password",
+		})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("refresh tokens are single use", func(t *testing.T) {
+		refresh := func(token string) *http.Response {
+			resp, err := makeRequestAs("", "POST", "/api/auth/refresh", map[string]interface{}{"refresh_token": token})
+			require.NoError(t, err)
+			return resp
+		}
+
+		resp := refresh(registered.Tokens.RefreshToken)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		var tokens TokenPair
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&tokens))
+		assert.NotEqual(t, registered.Tokens.RefreshToken, tokens.RefreshToken)
+
+		reused := refresh(registered.Tokens.RefreshToken)
+		defer reused.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, reused.StatusCode)
+	})
+
+	t.Run("access token authenticates", func(t *testing.T) {
+		resp, err := makeRequestAs(registered.Tokens.AccessToken, "GET", "/api/orders", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var orders []Order
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&orders))
+		assert.Empty(t, orders, "a new user has no orders, whatever others have placed")
+	})
+
+	t.Run("protected routes reject anonymous and invalid callers", func(t *testing.T) {
+		resp, err := makeRequestAs("", "GET", "/api/orders", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+		resp, err = makeRequestAs("not.a.token", "GET", "/api/menu", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("JWKS", func(t *testing.T) {
+		resp, err := makeRequestAs("", "GET", "/.well-known/jwks.json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var jwks struct {
+			Keys []struct {
+				Kty string `json:"kty"`
+				Kid string `json:"kid"`
+				Alg string `json:"alg"`
+			} `json:"keys"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&jwks))
+		require.NotEmpty(t, jwks.Keys)
+		assert.NotEmpty(t, jwks.Keys[0].Kid)
+	})
+}
+
+func TestE2E_Authorization(t *testing.T) {
+	student, err := register("Authz Student", fmt.Sprintf("authz-student-%d@test.com", time.Now().UnixNano()))
+	require.NoError(t, err)
+
+	// The owner creates a staff member, who can then sign in
+	staffEmail := fmt.Sprintf("authz-staff-%d@test.com", time.Now().UnixNano())
+	createResp, err := makeRequest("POST", "/api/users", map[string]interface{}{
+		"name":     "Authz Staff",
+		"email":    staffEmail,
+		"is_staff": true,
+		"password": "e2e staff password",
+	})
+	require.NoError(t, err)
+	createResp.Body.Close()
+	require.Equal(t, http.StatusCreated, createResp.StatusCode)
+	staff, err := login(staffEmail, "e2e staff password")
+	require.NoError(t, err)
+	assert.True(t, staff.User.IsStaff)
+
+	menuItem := map[string]interface{}{"name": "Authz Coffee", "price": usd(250)}
+	itemResp, err := makeRequest("POST", "/api/menu", menuItem)
+	require.NoError(t, err)
+	defer itemResp.Body.Close()
+	require.Equal(t, http.StatusCreated, itemResp.StatusCode)
+	var item MenuItem
+	require.NoError(t, json.NewDecoder(itemResp.Body).Decode(&item))
+
+	t.Run("only owners manage the menu and users", func(t *testing.T) {
+		for _, token := range []string{student.Tokens.AccessToken, staff.Tokens.AccessToken} {
+			resp, err := makeRequestAs(token, "POST", "/api/menu", menuItem)
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+			resp, err = makeRequestAs(token, "POST", "/api/users", map[string]interface{}{"name": "Sneaky", "email": "sneaky@test.com", "is_cafe_owner": true})
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		}
+	})
+
+	t.Run("students only see their own orders", func(t *testing.T) {
+		orderResp, err := makeRequest("POST", "/api/orders", map[string]interface{}{
+			"items": []map[string]interface{}{{"menu_item_id": item.ID, "quantity": 1}},
+		})
+		require.NoError(t, err)
+		defer orderResp.Body.Close()
+		require.Equal(t, http.StatusCreated, orderResp.StatusCode)
+		var order Order
+		require.NoError(t, json.NewDecoder(orderResp.Body).Decode(&order))
+
+		resp, err := makeRequestAs(student.Tokens.AccessToken, "GET", fmt.Sprintf("/api/orders/%d", order.ID), nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		resp, err = makeRequestAs(student.Tokens.AccessToken, "GET", "/api/users", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+
+	t.Run("staff progress orders, students do not", func(t *testing.T) {
+		orderResp, err := makeRequestAs(student.Tokens.AccessToken, "POST", "/api/orders", map[string]interface{}{
+			"items": []map[string]interface{}{{"menu_item_id": item.ID, "quantity": 1}},
+		})
+		require.NoError(t, err)
+		defer orderResp.Body.Close()
+		require.Equal(t, http.StatusCreated, orderResp.StatusCode)
+		var order Order
+		require.NoError(t, json.NewDecoder(orderResp.Body).Decode(&order))
+		assert.Equal(t, student.User.ID, order.UserID)
+
+		statusPath := fmt.Sprintf("/api/orders/%d/status", order.ID)
+		update := map[string]interface{}{"status": "preparing"}
+		resp, err := makeRequestAs(student.Tokens.AccessToken, "PATCH", statusPath, update)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		resp, err = makeRequestAs(staff.Tokens.AccessToken, "PATCH", statusPath, update)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestE2E_UpdateAndDeleteUser(t *testing.T) {
+	email := fmt.Sprintf("erase-me-%d@test.com", time.Now().UnixNano())
+	student, err := register("Erase Me", email)
+	require.NoError(t, err)
+	userPath := fmt.Sprintf("/api/users/%d", student.User.ID)
+
+	t.Run("update own profile", func(t *testing.T) {
+		resp, err := makeRequestAs(student.Tokens.AccessToken, "PATCH", userPath, map[string]interface{}{"name": "Erase Me Later"})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var user User
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&user))
+		assert.Equal(t, "Erase Me Later", user.Name)
+		assert.Equal(t, email, user.Email)
+
+		// Students cannot promote themselves
+		resp, err = makeRequestAs(student.Tokens.AccessToken, "PATCH", userPath, map[string]interface{}{"is_cafe_owner": true})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+		resp, err = makeRequestAs(student.Tokens.AccessToken, "PATCH", userPath, map[string]interface{}{"created_at": "2020-01-01T00:00:00Z"})
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	// The student orders, then deletes their account
+	itemResp, err := makeRequest("POST", "/api/menu", map[string]interface{}{"name": "Erasure Muffin", "price": usd(300)})
+	require.NoError(t, err)
+	defer itemResp.Body.Close()
+	require.Equal(t, http.StatusCreated, itemResp.StatusCode)
+	var item MenuItem
+	require.NoError(t, json.NewDecoder(itemResp.Body).Decode(&item))
+
+	orderResp, err := makeRequestAs(student.Tokens.AccessToken, "POST", "/api/orders", map[string]interface{}{
+		"items": []map[string]interface{}{{"menu_item_id": item.ID, "quantity": 1, "note": "for Erase Me"}},
+	})
+	require.NoError(t, err)
+	defer orderResp.Body.Close()
+	require.Equal(t, http.StatusCreated, orderResp.StatusCode)
+	var order Order
+	require.NoError(t, json.NewDecoder(orderResp.Body).Decode(&order))
+
+	deleteResp, err := makeRequestAs(student.Tokens.AccessToken, "DELETE", userPath, nil)
+	require.NoError(t, err)
+	defer deleteResp.Body.Close()
+	require.Equal(t, http.StatusOK, deleteResp.StatusCode, "the erasure completes while the order service is up")
+	var erasure UserErasure
+	require.NoError(t, json.NewDecoder(deleteResp.Body).Decode(&erasure))
+	assert.Equal(t, "completed", erasure.Status)
+	assert.Equal(t, student.User.ID, erasure.UserID)
+	assert.Equal(t, int64(1), erasure.OrdersDetached)
+
+	// The account is gone and cannot sign in
+	resp, err := makeRequest("GET", userPath, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	_, err = login(email, "e2e password")
+	assert.Error(t, err)
+
+	// The order is kept, detached from the student
+	resp, err = makeRequest("GET", fmt.Sprintf("/api/orders/%d", order.ID), nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var kept Order
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&kept))
+	assert.Zero(t, kept.UserID)
+	assert.NotEmpty(t, kept.UserDetachedAt)
+	assert.Empty(t, kept.OrderItems[0].Note)
+	assert.Equal(t, order.Total, kept.Total)
+
+	// Owners audit erasures
+	resp, err = makeRequest("GET", "/api/users/erasures", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var erasures []UserErasure
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&erasures))
+	require.NotEmpty(t, erasures)
+	assert.Equal(t, erasure.ID, erasures[0].ID)
+}
+
 func TestE2E_CreateMenuItem(t *testing.T) {
 	reqBody := map[string]interface{}{
 		"name":        "E2E Coffee",
 		"description": "End-to-end test coffee",
-		"price":       4.50,
+		"price":       usd(450),
 	}
 
 	resp, err := makeRequest("POST", "/api/menu", reqBody)
@@ -223,7 +668,7 @@ func TestE2E_CreateMenuItem(t *testing.T) {
 	assert.NotZero(t, item.ID)
 	assert.Equal(t, reqBody["name"], item.Name)
 	assert.Equal(t, reqBody["description"], item.Description)
-	assert.InDelta(t, reqBody["price"], item.Price, 0.01)
+	assert.Equal(t, usd(450), item.Price)
 }
 
 func TestE2E_GetMenu(t *testing.T) {
@@ -231,7 +676,7 @@ func TestE2E_GetMenu(t *testing.T) {
 	reqBody := map[string]interface{}{
 		"name":        "Get Menu Test Item",
 		"description": "Test item for get menu",
-		"price":       3.00,
+		"price":       usd(300),
 	}
 
 	createResp, err := makeRequest("POST", "/api/menu", reqBody)
@@ -252,6 +697,334 @@ func TestE2E_GetMenu(t *testing.T) {
 	assert.NotEmpty(t, items)
 }
 
+func TestE2E_SearchMenu(t *testing.T) {
+	name := fmt.Sprintf("Searchable Cardamom Bun %d", time.Now().UnixNano())
+	createResp, err := makeRequest("POST", "/api/menu", map[string]interface{}{
+		"name":        name,
+		"description": "Sticky and sweet",
+		"price":       usd(425),
+	})
+	require.NoError(t, err)
+	createResp.Body.Close()
+	require.Equal(t, http.StatusCreated, createResp.StatusCode)
+
+	search := func(query string) []MenuItem {
+		resp, err := makeRequest("GET", "/api/menu?"+query, nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var items []MenuItem
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&items))
+		return items
+	}
+
+	found := func(items []MenuItem) bool {
+		for _, item := range items {
+			if item.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	assert.True(t, found(search("q=cardamom+buns&min_price=400&max_price=450")))
+	assert.False(t, found(search("q=cardamom&max_price=400")))
+
+	// Pages follow the X-Next-Page-Token header
+	resp, err := makeRequest("GET", "/api/menu?page_size=1", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get("X-Next-Page-Token"))
+
+	resp, err = makeRequest("GET", "/api/menu?order_by=popularity", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestE2E_MenuItemDietaryInfo(t *testing.T) {
+	name := fmt.Sprintf("Dietary Test Curry %d", time.Now().UnixNano())
+	resp, err := makeRequest("POST", "/api/menu", map[string]interface{}{
+		"name":         name,
+		"price":        usd(650),
+		"allergens":    []string{"mustard", "celery"},
+		"dietary_tags": []string{"vegan"},
+		"calories":     540,
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var item MenuItem
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&item))
+	assert.Equal(t, []string{"celery", "mustard"}, item.Allergens)
+	assert.Equal(t, []string{"vegan"}, item.DietaryTags)
+	require.NotNil(t, item.Calories)
+	assert.Equal(t, int32(540), *item.Calories)
+
+	listed := func(query string) bool {
+		resp, err := makeRequest("GET", "/api/menu?"+query, nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var items []MenuItem
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&items))
+		for _, i := range items {
+			if i.ID == item.ID {
+				return true
+			}
+		}
+		return false
+	}
+
+	assert.True(t, listed("dietary_tags=vegan&max_calories=600"))
+	assert.False(t, listed("exclude_allergens=milk,celery"))
+
+	// Unknown allergen codes are rejected
+	badResp, err := makeRequest("POST", "/api/menu", map[string]interface{}{
+		"name":      "Unknown Allergen Item",
+		"price":     usd(100),
+		"allergens": []string{"pollen"},
+	})
+	require.NoError(t, err)
+	badResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badResp.StatusCode)
+}
+
+func TestE2E_ImportMenuItems(t *testing.T) {
+	suffix := time.Now().UnixNano()
+	upload := func(query, contentType, body string) (*http.Response, ImportReport) {
+		req, err := http.NewRequest("POST", apiGatewayURL+"/api/menu/import"+query, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		client := &http.Client{Timeout: 10 * time.Second}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var report ImportReport
+		if resp.Header.Get("Content-Type") == "application/json" {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+		}
+		return resp, report
+	}
+
+	csv := fmt.Sprintf("name,description,price,stock,allergens\n"+
+		"Imported Scone %[1]d,Fruit scone,325,12,gluten;milk\n"+
+		"Imported Tea %[1]d,,250,,\n", suffix)
+
+	// A dry run reports what would happen without saving
+	resp, report := upload("?dry_run=true", "text/csv", csv)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, report.Created)
+	assert.False(t, report.Committed)
+
+	resp, report = upload("", "text/csv", csv)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.True(t, report.Committed)
+	require.Len(t, report.Results, 2)
+
+	getResp, err := makeRequest("GET", fmt.Sprintf("/api/menu/%d", report.Results[0].MenuItemID), nil)
+	require.NoError(t, err)
+	defer getResp.Body.Close()
+	var scone MenuItem
+	require.NoError(t, json.NewDecoder(getResp.Body).Decode(&scone))
+	assert.Equal(t, int64(325), scone.Price.MinorUnits)
+	assert.Equal(t, []string{"gluten", "milk"}, scone.Allergens)
+
+	// JSON uploads use the same shape as POST /api/menu; upsert updates by name
+	body := fmt.Sprintf(`[{"name": "Imported Scone %[1]d", "price": {"minor_units": 350}}, {"name": "Imported Bad %[1]d"}]`, suffix)
+	resp, report = upload("?upsert=true", "application/json", body)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, "updated", report.Results[0].Action)
+	assert.False(t, report.Committed)
+
+	// Malformed files are rejected before anything is imported
+	resp, _ = upload("", "text/csv", "name,colour\nScone,red\n")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestE2E_UpdateAndDeleteMenuItem(t *testing.T) {
+	createResp, err := makeRequest("POST", "/api/menu", map[string]interface{}{
+		"name":        "E2E Mocha",
+		"description": "Chocolate and espresso",
+		"price":       usd(420),
+		"stock":       5,
+	})
+	require.NoError(t, err)
+	defer createResp.Body.Close()
+
+	var item MenuItem
+	err = json.NewDecoder(createResp.Body).Decode(&item)
+	require.NoError(t, err)
+	path := fmt.Sprintf("/api/menu/%d", item.ID)
+
+	// Only the fields sent are changed
+	resp, err := makeRequest("PATCH", path, map[string]interface{}{
+		"price": usd(450),
+		"stock": nil,
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var updated MenuItem
+	err = json.NewDecoder(resp.Body).Decode(&updated)
+	require.NoError(t, err)
+	assert.Equal(t, "E2E Mocha", updated.Name)
+	assert.Equal(t, usd(450), updated.Price)
+	assert.Nil(t, updated.Stock)
+
+	// Unknown fields are rejected
+	badResp, err := makeRequest("PATCH", path, map[string]interface{}{"id": 1})
+	require.NoError(t, err)
+	badResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badResp.StatusCode)
+
+	deleteResp, err := makeRequest("DELETE", path, nil)
+	require.NoError(t, err)
+	deleteResp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, deleteResp.StatusCode)
+
+	getResp, err := makeRequest("GET", path, nil)
+	require.NoError(t, err)
+	getResp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, getResp.StatusCode)
+
+	// Deleted items can still be looked up, e.g. for past orders
+	historyResp, err := makeRequest("GET", path+"?include_deleted=true", nil)
+	require.NoError(t, err)
+	defer historyResp.Body.Close()
+	assert.Equal(t, http.StatusOK, historyResp.StatusCode)
+
+	var deleted MenuItem
+	err = json.NewDecoder(historyResp.Body).Decode(&deleted)
+	require.NoError(t, err)
+	assert.Equal(t, "E2E Mocha", deleted.Name)
+	assert.NotEmpty(t, deleted.DeletedAt)
+}
+
+func TestE2E_MenuItemPriceHistory(t *testing.T) {
+	createResp, err := makeRequest("POST", "/api/menu", map[string]interface{}{
+		"name":  "E2E Flat White",
+		"price": usd(380),
+	})
+	require.NoError(t, err)
+	defer createResp.Body.Close()
+
+	var item MenuItem
+	err = json.NewDecoder(createResp.Body).Decode(&item)
+	require.NoError(t, err)
+	path := fmt.Sprintf("/api/menu/%d/prices", item.ID)
+
+	effectiveFrom := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+	scheduleResp, err := makeRequest("POST", path, map[string]interface{}{
+		"price":          usd(420),
+		"effective_from": effectiveFrom,
+	})
+	require.NoError(t, err)
+	defer scheduleResp.Body.Close()
+	assert.Equal(t, http.StatusCreated, scheduleResp.StatusCode)
+
+	// The scheduled price does not apply yet
+	getResp, err := makeRequest("GET", fmt.Sprintf("/api/menu/%d", item.ID), nil)
+	require.NoError(t, err)
+	defer getResp.Body.Close()
+
+	var current MenuItem
+	err = json.NewDecoder(getResp.Body).Decode(&current)
+	require.NoError(t, err)
+	assert.Equal(t, usd(380), current.Price)
+
+	historyResp, err := makeRequest("GET", path, nil)
+	require.NoError(t, err)
+	defer historyResp.Body.Close()
+	assert.Equal(t, http.StatusOK, historyResp.StatusCode)
+
+	var history []PriceChange
+	err = json.NewDecoder(historyResp.Body).Decode(&history)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, usd(380), history[0].Price)
+	assert.Equal(t, usd(420), history[1].Price)
+	assert.Equal(t, effectiveFrom, history[1].EffectiveFrom)
+
+	// Changes cannot be scheduled in the past
+	pastResp, err := makeRequest("POST", path, map[string]interface{}{
+		"price":          usd(400),
+		"effective_from": "2020-01-01T00:00:00Z",
+	})
+	require.NoError(t, err)
+	pastResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, pastResp.StatusCode)
+}
+
+func TestE2E_MenuCategories(t *testing.T) {
+	suffix := time.Now().UnixNano()
+
+	categoryResp, err := makeRequest("POST", "/api/categories", map[string]interface{}{
+		"name":     fmt.Sprintf("E2E Pastries %d", suffix),
+		"position": -1000,
+	})
+	require.NoError(t, err)
+	defer categoryResp.Body.Close()
+	assert.Equal(t, http.StatusCreated, categoryResp.StatusCode)
+
+	var category Category
+	err = json.NewDecoder(categoryResp.Body).Decode(&category)
+	require.NoError(t, err)
+	assert.NotZero(t, category.ID)
+
+	itemResp, err := makeRequest("POST", "/api/menu", map[string]interface{}{
+		"name":        fmt.Sprintf("Croissant-%d", suffix),
+		"price":       usd(320),
+		"category_id": category.ID,
+	})
+	require.NoError(t, err)
+	defer itemResp.Body.Close()
+
+	var item MenuItem
+	err = json.NewDecoder(itemResp.Body).Decode(&item)
+	require.NoError(t, err)
+	assert.Equal(t, category.ID, item.CategoryID)
+
+	// The category comes first because of its position, with the item in it
+	menuResp, err := makeRequest("GET", "/api/menu?group_by=category", nil)
+	require.NoError(t, err)
+	defer menuResp.Body.Close()
+	assert.Equal(t, http.StatusOK, menuResp.StatusCode)
+
+	var sections []MenuSection
+	err = json.NewDecoder(menuResp.Body).Decode(&sections)
+	require.NoError(t, err)
+	require.NotEmpty(t, sections)
+	require.NotNil(t, sections[0].Category)
+	assert.Equal(t, category.ID, sections[0].Category.ID)
+	require.Len(t, sections[0].MenuItems, 1)
+	assert.Equal(t, item.ID, sections[0].MenuItems[0].ID)
+
+	// Deleting the category keeps the item on the menu
+	deleteResp, err := makeRequest("DELETE", fmt.Sprintf("/api/categories/%d", category.ID), nil)
+	require.NoError(t, err)
+	deleteResp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, deleteResp.StatusCode)
+
+	getResp, err := makeRequest("GET", fmt.Sprintf("/api/menu/%d", item.ID), nil)
+	require.NoError(t, err)
+	defer getResp.Body.Close()
+
+	var uncategorised MenuItem
+	err = json.NewDecoder(getResp.Body).Decode(&uncategorised)
+	require.NoError(t, err)
+	assert.Zero(t, uncategorised.CategoryID)
+}
+
 func TestE2E_CompleteOrderFlow(t *testing.T) {
 	// Step 1: Create a user
 	userReq := map[string]interface{}{
@@ -272,7 +1045,7 @@ func TestE2E_CompleteOrderFlow(t *testing.T) {
 	item1Req := map[string]interface{}{
 		"name":        fmt.Sprintf("Coffee-%d", time.Now().Unix()),
 		"description": "Hot coffee",
-		"price":       2.50,
+		"price":       usd(250),
 	}
 
 	item1Resp, err := makeRequest("POST", "/api/menu", item1Req)
@@ -286,7 +1059,7 @@ func TestE2E_CompleteOrderFlow(t *testing.T) {
 	item2Req := map[string]interface{}{
 		"name":        fmt.Sprintf("Sandwich-%d", time.Now().Unix()),
 		"description": "Ham sandwich",
-		"price":       5.00,
+		"price":       usd(500),
 	}
 
 	item2Resp, err := makeRequest("POST", "/api/menu", item2Req)
@@ -316,14 +1089,21 @@ func TestE2E_CompleteOrderFlow(t *testing.T) {
 	err = json.NewDecoder(orderResp.Body).Decode(&order)
 	require.NoError(t, err)
 
+	// The order is placed for the signed-in user, not the user_id in the body
 	assert.NotZero(t, order.ID)
-	assert.Equal(t, user.ID, order.UserID)
+	assert.Equal(t, signedInUser.ID, order.UserID)
 	assert.Equal(t, "pending", order.Status)
 	assert.Len(t, order.OrderItems, 2)
 
 	// Verify prices were snapshotted
-	assert.InDelta(t, 2.50, order.OrderItems[0].Price, 0.01)
-	assert.InDelta(t, 5.00, order.OrderItems[1].Price, 0.01)
+	assert.Equal(t, int64(250), order.OrderItems[0].Price.MinorUnits)
+	assert.Equal(t, int64(500), order.OrderItems[1].Price.MinorUnits)
+
+	// Verify totals are computed by the server
+	assert.Equal(t, int64(500), order.OrderItems[0].LineTotal.MinorUnits)
+	assert.Equal(t, int64(1000), order.Subtotal.MinorUnits)
+	assert.Equal(t, order.Subtotal.MinorUnits-order.Discount.MinorUnits+order.Tax.MinorUnits, order.Total.MinorUnits)
+	assert.Equal(t, order.Subtotal.CurrencyCode, order.Total.CurrencyCode)
 
 	// Step 4: Retrieve the order
 	getOrderResp, err := makeRequest("GET", fmt.Sprintf("/api/orders/%d", order.ID), nil)
@@ -340,21 +1120,235 @@ func TestE2E_CompleteOrderFlow(t *testing.T) {
 	assert.Len(t, retrievedOrder.OrderItems, 2)
 }
 
+func TestE2E_OrderStatusLifecycle(t *testing.T) {
+	// Create a user, a menu item and an order to work with
+	userReq := map[string]interface{}{
+		"name":          "Status Lifecycle User",
+		"email":         fmt.Sprintf("status-%d@test.com", time.Now().Unix()),
+		"is_cafe_owner": false,
+	}
+
+	userResp, err := makeRequest("POST", "/api/users", userReq)
+	require.NoError(t, err)
+	defer userResp.Body.Close()
+
+	var user User
+	err = json.NewDecoder(userResp.Body).Decode(&user)
+	require.NoError(t, err)
+
+	itemReq := map[string]interface{}{
+		"name":        fmt.Sprintf("Latte-%d", time.Now().Unix()),
+		"description": "Milky coffee",
+		"price":       usd(350),
+	}
+
+	itemResp, err := makeRequest("POST", "/api/menu", itemReq)
+	require.NoError(t, err)
+	defer itemResp.Body.Close()
+
+	var item MenuItem
+	err = json.NewDecoder(itemResp.Body).Decode(&item)
+	require.NoError(t, err)
+
+	orderReq := map[string]interface{}{
+		"user_id": user.ID,
+		"items": []map[string]interface{}{
+			{"menu_item_id": item.ID, "quantity": 1},
+		},
+	}
+
+	orderResp, err := makeRequest("POST", "/api/orders", orderReq)
+	require.NoError(t, err)
+	defer orderResp.Body.Close()
+
+	var order Order
+	err = json.NewDecoder(orderResp.Body).Decode(&order)
+	require.NoError(t, err)
+
+	statusPath := fmt.Sprintf("/api/orders/%d/status", order.ID)
+
+	// Illegal transition is rejected
+	resp, err := makeRequest("PATCH", statusPath, map[string]interface{}{"status": "collected"})
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+
+	// Walk the order through its lifecycle
+	for _, next := range []string{"preparing", "ready", "collected"} {
+		resp, err := makeRequest("PATCH", statusPath, map[string]interface{}{"status": next})
+		require.NoError(t, err)
+
+		var updated Order
+		err = json.NewDecoder(resp.Body).Decode(&updated)
+		resp.Body.Close()
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, next, updated.Status)
+	}
+
+	// Every transition is recorded
+	getResp, err := makeRequest("GET", fmt.Sprintf("/api/orders/%d", order.ID), nil)
+	require.NoError(t, err)
+	defer getResp.Body.Close()
+
+	var retrieved Order
+	err = json.NewDecoder(getResp.Body).Decode(&retrieved)
+	require.NoError(t, err)
+
+	require.Len(t, retrieved.StatusHistory, 3)
+	assert.Equal(t, "pending", retrieved.StatusHistory[0].FromStatus)
+	assert.Equal(t, "collected", retrieved.StatusHistory[2].ToStatus)
+	assert.Equal(t, fmt.Sprintf("owner %d", signedInUser.ID), retrieved.StatusHistory[2].Actor, "recorded as the signed in caller")
+}
+
+func TestE2E_IdempotentCreateOrder(t *testing.T) {
+	// Create a user and a menu item to order
+	userReq := map[string]interface{}{
+		"name":          "Idempotency User",
+		"email":         fmt.Sprintf("idempotency-%d@test.com", time.Now().UnixNano()),
+		"is_cafe_owner": false,
+	}
+
+	userResp, err := makeRequest("POST", "/api/users", userReq)
+	require.NoError(t, err)
+	defer userResp.Body.Close()
+
+	var user User
+	err = json.NewDecoder(userResp.Body).Decode(&user)
+	require.NoError(t, err)
+
+	itemReq := map[string]interface{}{
+		"name":        fmt.Sprintf("Flat White-%d", time.Now().UnixNano()),
+		"description": "Velvety coffee",
+		"price":       usd(380),
+	}
+
+	itemResp, err := makeRequest("POST", "/api/menu", itemReq)
+	require.NoError(t, err)
+	defer itemResp.Body.Close()
+
+	var item MenuItem
+	err = json.NewDecoder(itemResp.Body).Decode(&item)
+	require.NoError(t, err)
+
+	key := fmt.Sprintf("e2e-%d", time.Now().UnixNano())
+	createOrder := func(quantity int) *http.Response {
+		body, err := json.Marshal(map[string]interface{}{
+			"user_id": user.ID,
+			"items": []map[string]interface{}{
+				{"menu_item_id": item.ID, "quantity": quantity},
+			},
+		})
+		require.NoError(t, err)
+
+		req, err := http.NewRequest("POST", apiGatewayURL+"/api/orders", bytes.NewBuffer(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", key)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		client := &http.Client{Timeout: 10 * time.Second}
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	// The retry returns the order placed by the first request
+	var orders [2]Order
+	for i := range orders {
+		resp := createOrder(2)
+		err := json.NewDecoder(resp.Body).Decode(&orders[i])
+		resp.Body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	}
+	assert.NotZero(t, orders[0].ID)
+	assert.Equal(t, orders[0].ID, orders[1].ID)
+
+	// A different order under the same key is rejected
+	resp := createOrder(3)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestE2E_OrderWithModifiers(t *testing.T) {
+	userResp, err := makeRequest("POST", "/api/users", map[string]interface{}{
+		"name":  "Modifier Test User",
+		"email": fmt.Sprintf("modifiers-%d@test.com", time.Now().UnixNano()),
+	})
+	require.NoError(t, err)
+	defer userResp.Body.Close()
+
+	var user User
+	require.NoError(t, json.NewDecoder(userResp.Body).Decode(&user))
+
+	itemResp, err := makeRequest("POST", "/api/menu", map[string]interface{}{
+		"name":  "Modifier Test Burger",
+		"price": usd(800),
+		"modifier_groups": []map[string]interface{}{
+			{"name": "Extras", "max_selections": 2, "modifiers": []map[string]interface{}{
+				{"name": "Cheese", "price_delta": usd(100)},
+				{"name": "No onions"},
+			}},
+		},
+	})
+	require.NoError(t, err)
+	defer itemResp.Body.Close()
+	require.Equal(t, http.StatusCreated, itemResp.StatusCode)
+
+	var item MenuItem
+	require.NoError(t, json.NewDecoder(itemResp.Body).Decode(&item))
+	require.Len(t, item.ModifierGroups, 1)
+	require.Len(t, item.ModifierGroups[0].Modifiers, 2)
+	cheese, noOnions := item.ModifierGroups[0].Modifiers[0], item.ModifierGroups[0].Modifiers[1]
+
+	orderResp, err := makeRequest("POST", "/api/orders", map[string]interface{}{
+		"user_id": user.ID,
+		"items": []map[string]interface{}{
+			{"menu_item_id": item.ID, "quantity": 2, "modifier_ids": []uint{cheese.ID, noOnions.ID}, "note": "well done"},
+		},
+	})
+	require.NoError(t, err)
+	defer orderResp.Body.Close()
+	require.Equal(t, http.StatusCreated, orderResp.StatusCode)
+
+	var order Order
+	require.NoError(t, json.NewDecoder(orderResp.Body).Decode(&order))
+	require.Len(t, order.OrderItems, 1)
+	line := order.OrderItems[0]
+	assert.Equal(t, int64(1800), line.LineTotal.MinorUnits)
+	assert.Equal(t, "well done", line.Note)
+	require.Len(t, line.Modifiers, 2)
+	assert.Equal(t, "Cheese", line.Modifiers[0].Name)
+	assert.Equal(t, "Extras", line.Modifiers[0].GroupName)
+
+	// A modifier the item does not offer is rejected
+	badResp, err := makeRequest("POST", "/api/orders", map[string]interface{}{
+		"user_id": user.ID,
+		"items": []map[string]interface{}{
+			{"menu_item_id": item.ID, "quantity": 1, "modifier_ids": []uint{999999}},
+		},
+	})
+	require.NoError(t, err)
+	badResp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, badResp.StatusCode)
+}
+
 func TestE2E_OrderValidation(t *testing.T) {
-	// Try to create order with invalid user
-	t.Run("invalid user", func(t *testing.T) {
+	// Try to create order without signing in
+	t.Run("anonymous caller", func(t *testing.T) {
 		orderReq := map[string]interface{}{
-			"user_id": 999999,
 			"items": []map[string]interface{}{
 				{"menu_item_id": 1, "quantity": 1},
 			},
 		}
 
-		resp, err := makeRequest("POST", "/api/orders", orderReq)
+		resp, err := makeRequestAs("", "POST", "/api/orders", orderReq)
 		require.NoError(t, err)
 		defer resp.Body.Close()
 
-		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
 	})
 
 	// Create a valid user for next test
@@ -387,6 +1381,37 @@ func TestE2E_OrderValidation(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
+
+	// Try to order an item outside its availability. Three days from now is
+	// neither today nor tomorrow in any cafe time zone.
+	t.Run("unavailable menu item", func(t *testing.T) {
+		weekday := strings.ToLower(time.Now().UTC().AddDate(0, 0, 3).Weekday().String())
+		itemResp, err := makeRequest("POST", "/api/menu", map[string]interface{}{
+			"name":  "Not Today Special",
+			"price": usd(500),
+			"availability": []map[string]string{
+				{"weekday": weekday, "start_time": "00:00", "end_time": "24:00"},
+			},
+		})
+		require.NoError(t, err)
+		defer itemResp.Body.Close()
+		require.Equal(t, http.StatusCreated, itemResp.StatusCode)
+
+		var item MenuItem
+		require.NoError(t, json.NewDecoder(itemResp.Body).Decode(&item))
+		assert.True(t, item.Unavailable)
+
+		resp, err := makeRequest("POST", "/api/orders", map[string]interface{}{
+			"user_id": user.ID,
+			"items": []map[string]interface{}{
+				{"menu_item_id": item.ID, "quantity": 1},
+			},
+		})
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	})
 }
 
 func TestE2E_GetNonExistentUser(t *testing.T) {
@@ -432,7 +1457,7 @@ func TestE2E_ConcurrentOrders(t *testing.T) {
 	itemReq := map[string]interface{}{
 		"name":        fmt.Sprintf("Concurrent Item-%d", time.Now().Unix()),
 		"description": "For concurrent testing",
-		"price":       1.00,
+		"price":       usd(100),
 	}
 
 	itemResp, err := makeRequest("POST", "/api/menu", itemReq)
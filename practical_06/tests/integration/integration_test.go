@@ -2,18 +2,27 @@ package integration
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net"
 	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/douglasswm/student-cafe-protos/authz"
+	commonv1 "github.com/douglasswm/student-cafe-protos/gen/go/common/v1"
 	menuv1 "github.com/douglasswm/student-cafe-protos/gen/go/menu/v1"
 	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
 	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/douglasswm/student-cafe-protos/principal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
 	// Import actual service implementations
@@ -25,6 +34,7 @@ import (
 	ordergrpc "order-service/grpc"
 	ordermodels "order-service/models"
 
+	userauth "user-service/auth"
 	userdatabase "user-service/database"
 	usergrpc "user-service/grpc"
 	usermodels "user-service/models"
@@ -41,43 +51,52 @@ var (
 	orderListener *bufconn.Listener
 )
 
-// setupUserService creates and starts the user service
-func setupUserService(t *testing.T) {
+// setupUserService creates and starts the user service with server options,
+// and returns its server so tests can connect it to the order service
+func setupUserService(t *testing.T, opts ...grpc.ServerOption) *usergrpc.UserServer {
 	// Setup in-memory database
 	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&usermodels.User{})
+	err = db.AutoMigrate(&usermodels.User{}, &usermodels.RefreshToken{}, &usermodels.UserErasure{})
 	require.NoError(t, err)
 
 	userdatabase.DB = db
 
+	// Sign tokens with a throwaway key
+	key, err := userauth.GenerateKey()
+	require.NoError(t, err)
+	userServer := usergrpc.NewUserServer()
+	userServer.Tokens, err = userauth.NewIssuer(key)
+	require.NoError(t, err)
+
 	// Create gRPC server with bufconn
 	userListener = bufconn.Listen(bufSize)
-	s := grpc.NewServer()
-	userv1.RegisterUserServiceServer(s, usergrpc.NewUserServer())
+	s := grpc.NewServer(opts...)
+	userv1.RegisterUserServiceServer(s, userServer)
 
 	go func() {
 		if err := s.Serve(userListener); err != nil {
 			log.Fatalf("Server exited with error: %v", err)
 		}
 	}()
+	return userServer
 }
 
-// setupMenuService creates and starts the menu service
-func setupMenuService(t *testing.T) {
+// setupMenuService creates and starts the menu service with server options
+func setupMenuService(t *testing.T, opts ...grpc.ServerOption) {
 	// Setup in-memory database
 	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&menumodels.MenuItem{})
+	err = db.AutoMigrate(&menumodels.Menu{}, &menumodels.MenuItem{}, &menumodels.AvailabilityWindow{}, &menumodels.ModifierGroup{}, &menumodels.Modifier{}, &menumodels.StockReservation{}, &menumodels.MenuEvent{}, &menumodels.PriceChange{})
 	require.NoError(t, err)
 
 	menudatabase.DB = db
 
 	// Create gRPC server with bufconn
 	menuListener = bufconn.Listen(bufSize)
-	s := grpc.NewServer()
+	s := grpc.NewServer(opts...)
 	menuv1.RegisterMenuServiceServer(s, menugrpc.NewMenuServer())
 
 	go func() {
@@ -87,13 +106,13 @@ func setupMenuService(t *testing.T) {
 	}()
 }
 
-// setupOrderService creates and starts the order service with clients to user and menu services
-func setupOrderService(t *testing.T, userConn, menuConn *grpc.ClientConn) {
+// setupOrderService creates and starts the order service with clients to user and menu services, and server options
+func setupOrderService(t *testing.T, userConn, menuConn *grpc.ClientConn, opts ...grpc.ServerOption) {
 	// Setup in-memory database
 	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&ordermodels.Order{}, &ordermodels.OrderItem{})
+	err = db.AutoMigrate(&ordermodels.Order{}, &ordermodels.OrderItem{}, &ordermodels.OrderItemModifier{}, &ordermodels.OrderStatusTransition{}, &ordermodels.OrderSaga{}, &ordermodels.OutboxEvent{}, &ordermodels.IdempotencyKey{}, &ordermodels.OrderTax{}, &ordermodels.OrderDiscount{}, &ordermodels.Promotion{}, &ordermodels.PromotionTarget{}, &ordermodels.PromotionWindow{})
 	require.NoError(t, err)
 
 	orderdatabase.DB = db
@@ -106,7 +125,7 @@ func setupOrderService(t *testing.T, userConn, menuConn *grpc.ClientConn) {
 
 	// Create gRPC server with bufconn
 	orderListener = bufconn.Listen(bufSize)
-	s := grpc.NewServer()
+	s := grpc.NewServer(opts...)
 	orderv1.RegisterOrderServiceServer(s, orderServer)
 
 	go func() {
@@ -170,7 +189,7 @@ func TestIntegration_CreateAndGetMenuItem(t *testing.T) {
 	createResp, err := client.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
 		Name:        "Integration Coffee",
 		Description: "Test coffee",
-		Price:       3.50,
+		Price:       usd(350),
 	})
 
 	require.NoError(t, err)
@@ -184,7 +203,108 @@ func TestIntegration_CreateAndGetMenuItem(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, createResp.MenuItem.Id, getResp.MenuItem.Id)
 	assert.Equal(t, "Integration Coffee", getResp.MenuItem.Name)
-	assert.InDelta(t, 3.50, getResp.MenuItem.Price, 0.001)
+	assert.Equal(t, int64(350), getResp.MenuItem.Price.MinorUnits)
+}
+
+func TestIntegration_ImportMenuItems(t *testing.T) {
+	setupMenuService(t)
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := menuv1.NewMenuServiceClient(conn)
+
+	// importItems streams the rows over a real client stream and returns the report
+	importItems := func(options *menuv1.ImportOptions, rows ...*menuv1.CreateMenuItemRequest) *menuv1.ImportMenuItemsResponse {
+		stream, err := client.ImportMenuItems(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&menuv1.ImportMenuItemsRequest{
+			Payload: &menuv1.ImportMenuItemsRequest_Options{Options: options},
+		}))
+		for _, row := range rows {
+			require.NoError(t, stream.Send(&menuv1.ImportMenuItemsRequest{
+				Payload: &menuv1.ImportMenuItemsRequest_MenuItem{MenuItem: row},
+			}))
+		}
+		resp, err := stream.CloseAndRecv()
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("InvalidRowSavesNothing", func(t *testing.T) {
+		resp := importItems(&menuv1.ImportOptions{},
+			&menuv1.CreateMenuItemRequest{Name: "Import Espresso", Price: usd(300)},
+			&menuv1.CreateMenuItemRequest{Name: "Import Cake", Price: usd(400), Allergens: []string{"glitter"}},
+		)
+		assert.False(t, resp.Committed)
+		assert.Equal(t, int32(1), resp.Failed)
+
+		menu, err := client.GetMenu(ctx, &menuv1.GetMenuRequest{Query: "Import"})
+		require.NoError(t, err)
+		assert.Empty(t, menu.MenuItems)
+	})
+
+	t.Run("ImportThenUpsert", func(t *testing.T) {
+		resp := importItems(&menuv1.ImportOptions{},
+			&menuv1.CreateMenuItemRequest{Name: "Import Espresso", Price: usd(300)},
+			&menuv1.CreateMenuItemRequest{Name: "Import Cake", Price: usd(400)},
+		)
+		require.True(t, resp.Committed)
+		assert.Equal(t, int32(2), resp.Created)
+
+		resp = importItems(&menuv1.ImportOptions{UpsertByName: true},
+			&menuv1.CreateMenuItemRequest{Name: "Import Cake", Price: usd(450)},
+		)
+		require.True(t, resp.Committed)
+		assert.Equal(t, int32(1), resp.Updated)
+
+		getResp, err := client.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: resp.Results[0].MenuItemId})
+		require.NoError(t, err)
+		assert.Equal(t, int64(450), getResp.MenuItem.Price.MinorUnits)
+	})
+}
+
+func TestIntegration_WatchMenu(t *testing.T) {
+	setupMenuService(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := menuv1.NewMenuServiceClient(conn)
+
+	menu, err := client.GetMenu(ctx, &menuv1.GetMenuRequest{})
+	require.NoError(t, err)
+
+	// Changes made while the client is away are replayed when it resumes
+	created, err := client.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Watched Mocha", Price: usd(450)})
+	require.NoError(t, err)
+
+	stream, err := client.WatchMenu(ctx, &menuv1.WatchMenuRequest{SinceRevision: &menu.Revision})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "created", resp.Event.Type)
+	assert.Equal(t, created.MenuItem.Id, resp.Event.MenuItemId)
+	assert.Equal(t, menu.Revision+1, resp.Event.Revision)
+
+	// Later changes arrive live
+	_, err = client.DeleteMenuItem(ctx, &menuv1.DeleteMenuItemRequest{Id: created.MenuItem.Id})
+	require.NoError(t, err)
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "deleted", resp.Event.Type)
+	assert.Equal(t, "Watched Mocha", resp.Event.MenuItem.Name)
 }
 
 func TestIntegration_CompleteOrderFlow(t *testing.T) {
@@ -237,14 +357,14 @@ func TestIntegration_CompleteOrderFlow(t *testing.T) {
 	item1, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
 		Name:        "Coffee",
 		Description: "Hot coffee",
-		Price:       2.50,
+		Price:       usd(250),
 	})
 	require.NoError(t, err)
 
 	item2, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
 		Name:        "Sandwich",
 		Description: "Ham sandwich",
-		Price:       5.00,
+		Price:       usd(500),
 	})
 	require.NoError(t, err)
 
@@ -264,8 +384,8 @@ func TestIntegration_CompleteOrderFlow(t *testing.T) {
 	assert.Len(t, orderResp.Order.OrderItems, 2)
 
 	// Verify prices were snapshotted
-	assert.InDelta(t, 2.50, orderResp.Order.OrderItems[0].Price, 0.001)
-	assert.InDelta(t, 5.00, orderResp.Order.OrderItems[1].Price, 0.001)
+	assert.Equal(t, int64(250), orderResp.Order.OrderItems[0].Price.MinorUnits)
+	assert.Equal(t, int64(500), orderResp.Order.OrderItems[1].Price.MinorUnits)
 
 	// Step 4: Retrieve the order
 	getOrderResp, err := orderClient.GetOrder(ctx, &orderv1.GetOrderRequest{
@@ -343,6 +463,664 @@ func TestIntegration_OrderValidation(t *testing.T) {
 	})
 }
 
+func TestIntegration_StockReservation(t *testing.T) {
+	// Setup all three services
+	setupUserService(t)
+	setupMenuService(t)
+
+	ctx := context.Background()
+
+	userConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer userConn.Close()
+
+	menuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer menuConn.Close()
+
+	setupOrderService(t, userConn, menuConn)
+
+	orderConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(orderListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer orderConn.Close()
+
+	userClient := userv1.NewUserServiceClient(userConn)
+	menuClient := menuv1.NewMenuServiceClient(menuConn)
+	orderClient := orderv1.NewOrderServiceClient(orderConn)
+
+	userResp, err := userClient.CreateUser(ctx, &userv1.CreateUserRequest{
+		Name:  "Stock User",
+		Email: "stock@test.com",
+	})
+	require.NoError(t, err)
+
+	stock := int32(3)
+	itemResp, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+		Name:  "Limited Muffin",
+		Price: usd(220),
+		Stock: &stock,
+	})
+	require.NoError(t, err)
+	itemID := itemResp.MenuItem.Id
+
+	remaining := func() int32 {
+		resp, err := menuClient.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: itemID})
+		require.NoError(t, err)
+		require.NotNil(t, resp.MenuItem.Stock)
+		return *resp.MenuItem.Stock
+	}
+
+	t.Run("OrderTakesStock", func(t *testing.T) {
+		_, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId: userResp.User.Id,
+			Items:  []*orderv1.OrderItemRequest{{MenuItemId: itemID, Quantity: 2}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), remaining())
+	})
+
+	t.Run("OrderExceedingStockIsRejected", func(t *testing.T) {
+		_, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId: userResp.User.Id,
+			Items:  []*orderv1.OrderItemRequest{{MenuItemId: itemID, Quantity: 2}},
+		})
+		require.Error(t, err)
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.ResourceExhausted, st.Code())
+
+		// The failed order leaves the stock untouched
+		assert.Equal(t, int32(1), remaining())
+	})
+
+	t.Run("LastUnitCanBeOrdered", func(t *testing.T) {
+		_, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId: userResp.User.Id,
+			Items:  []*orderv1.OrderItemRequest{{MenuItemId: itemID, Quantity: 1}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), remaining())
+	})
+}
+
+func TestIntegration_DeletedMenuItem(t *testing.T) {
+	// Setup all three services
+	setupUserService(t)
+	setupMenuService(t)
+
+	ctx := context.Background()
+
+	userConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer userConn.Close()
+
+	menuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer menuConn.Close()
+
+	setupOrderService(t, userConn, menuConn)
+
+	orderConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(orderListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer orderConn.Close()
+
+	userClient := userv1.NewUserServiceClient(userConn)
+	menuClient := menuv1.NewMenuServiceClient(menuConn)
+	orderClient := orderv1.NewOrderServiceClient(orderConn)
+
+	userResp, err := userClient.CreateUser(ctx, &userv1.CreateUserRequest{
+		Name:  "History User",
+		Email: "history@test.com",
+	})
+	require.NoError(t, err)
+
+	itemResp, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+		Name:  "Seasonal Latte",
+		Price: usd(480),
+	})
+	require.NoError(t, err)
+	itemID := itemResp.MenuItem.Id
+
+	orderResp, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		UserId: userResp.User.Id,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: itemID, Quantity: 1}},
+	})
+	require.NoError(t, err)
+
+	_, err = menuClient.DeleteMenuItem(ctx, &menuv1.DeleteMenuItemRequest{Id: itemID})
+	require.NoError(t, err)
+
+	t.Run("NewOrdersAreRejected", func(t *testing.T) {
+		_, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId: userResp.User.Id,
+			Items:  []*orderv1.OrderItemRequest{{MenuItemId: itemID, Quantity: 1}},
+		})
+		require.Error(t, err)
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+
+	t.Run("PastOrdersStillResolveTheItem", func(t *testing.T) {
+		getResp, err := orderClient.GetOrder(ctx, &orderv1.GetOrderRequest{Id: orderResp.Order.Id})
+		require.NoError(t, err)
+		require.Len(t, getResp.Order.OrderItems, 1)
+
+		menuItemID := getResp.Order.OrderItems[0].MenuItemId
+		itemResp, err := menuClient.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: menuItemID, IncludeDeleted: true})
+		require.NoError(t, err)
+		assert.Equal(t, "Seasonal Latte", itemResp.MenuItem.Name)
+		assert.NotEmpty(t, itemResp.MenuItem.DeletedAt)
+	})
+}
+
+func TestIntegration_UnavailableMenuItem(t *testing.T) {
+	// Setup all three services
+	setupUserService(t)
+	setupMenuService(t)
+
+	ctx := context.Background()
+
+	userConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer userConn.Close()
+
+	menuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer menuConn.Close()
+
+	setupOrderService(t, userConn, menuConn)
+
+	orderConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(orderListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer orderConn.Close()
+
+	userClient := userv1.NewUserServiceClient(userConn)
+	menuClient := menuv1.NewMenuServiceClient(menuConn)
+	orderClient := orderv1.NewOrderServiceClient(orderConn)
+
+	userResp, err := userClient.CreateUser(ctx, &userv1.CreateUserRequest{
+		Name:  "Early Bird",
+		Email: "early@test.com",
+	})
+	require.NoError(t, err)
+
+	// Only served all day tomorrow, in the menu service's time zone (UTC)
+	tomorrow := strings.ToLower(time.Now().UTC().AddDate(0, 0, 1).Weekday().String())
+	itemResp, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+		Name:         "Tomorrow's Special",
+		Price:        usd(650),
+		Availability: []*menuv1.AvailabilityWindow{{Weekday: tomorrow, StartTime: "00:00", EndTime: "24:00"}},
+	})
+	require.NoError(t, err)
+	assert.True(t, itemResp.MenuItem.Unavailable)
+
+	_, err = orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		UserId: userResp.User.Id,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: itemResp.MenuItem.Id, Quantity: 1}},
+	})
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+
+	menuResp, err := menuClient.GetMenu(ctx, &menuv1.GetMenuRequest{AvailableNow: true})
+	require.NoError(t, err)
+	for _, item := range menuResp.MenuItems {
+		assert.NotEqual(t, itemResp.MenuItem.Id, item.Id)
+	}
+}
+
+func TestIntegration_OrderWithModifiers(t *testing.T) {
+	// Setup all three services
+	setupUserService(t)
+	setupMenuService(t)
+
+	ctx := context.Background()
+
+	userConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer userConn.Close()
+
+	menuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer menuConn.Close()
+
+	setupOrderService(t, userConn, menuConn)
+
+	orderConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(orderListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer orderConn.Close()
+
+	userClient := userv1.NewUserServiceClient(userConn)
+	menuClient := menuv1.NewMenuServiceClient(menuConn)
+	orderClient := orderv1.NewOrderServiceClient(orderConn)
+
+	userResp, err := userClient.CreateUser(ctx, &userv1.CreateUserRequest{
+		Name:  "Oat Milk Fan",
+		Email: "oat@test.com",
+	})
+	require.NoError(t, err)
+
+	itemResp, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+		Name:  "Flat White",
+		Price: usd(420),
+		ModifierGroups: []*menuv1.ModifierGroup{
+			{Name: "Milk", MinSelections: 1, MaxSelections: 1, Modifiers: []*menuv1.Modifier{
+				{Name: "Dairy"},
+				{Name: "Oat", PriceDelta: usd(50)},
+			}},
+		},
+	})
+	require.NoError(t, err)
+	oat := itemResp.MenuItem.ModifierGroups[0].Modifiers[1]
+
+	t.Run("RequiredGroupMustBeChosen", func(t *testing.T) {
+		_, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId: userResp.User.Id,
+			Items:  []*orderv1.OrderItemRequest{{MenuItemId: itemResp.MenuItem.Id, Quantity: 1}},
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("ModifiersArePricedIn", func(t *testing.T) {
+		orderResp, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+			UserId: userResp.User.Id,
+			Items: []*orderv1.OrderItemRequest{{
+				MenuItemId:  itemResp.MenuItem.Id,
+				Quantity:    2,
+				ModifierIds: []uint32{oat.Id},
+				Note:        "Extra hot",
+			}},
+		})
+		require.NoError(t, err)
+
+		item := orderResp.Order.OrderItems[0]
+		assert.Equal(t, int64(940), item.LineTotal.MinorUnits)
+		assert.Equal(t, "Extra hot", item.Note)
+		require.Len(t, item.Modifiers, 1)
+		assert.Equal(t, "Oat", item.Modifiers[0].Name)
+		assert.Equal(t, int64(50), item.Modifiers[0].PriceDelta.MinorUnits)
+	})
+}
+
+func TestIntegration_OrderWithPromotions(t *testing.T) {
+	// Setup all three services
+	setupUserService(t)
+	setupMenuService(t)
+
+	ctx := context.Background()
+
+	userConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer userConn.Close()
+
+	menuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer menuConn.Close()
+
+	setupOrderService(t, userConn, menuConn)
+
+	orderConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(orderListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer orderConn.Close()
+
+	userClient := userv1.NewUserServiceClient(userConn)
+	menuClient := menuv1.NewMenuServiceClient(menuConn)
+	orderClient := orderv1.NewOrderServiceClient(orderConn)
+
+	userResp, err := userClient.CreateUser(ctx, &userv1.CreateUserRequest{
+		Name:  "Bargain Hunter",
+		Email: "bargain@test.com",
+	})
+	require.NoError(t, err)
+
+	coffees, err := menuClient.CreateCategory(ctx, &menuv1.CreateCategoryRequest{Name: "Coffees"})
+	require.NoError(t, err)
+	latte, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
+		Name: "Latte", Price: usd(400), CategoryId: coffees.Category.Id,
+	})
+	require.NoError(t, err)
+	cookie, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Cookie", Price: usd(180)})
+	require.NoError(t, err)
+
+	// The category of each ordered item comes from the menu service
+	promotion, err := orderClient.CreatePromotion(ctx, &orderv1.CreatePromotionRequest{Promotion: &orderv1.Promotion{
+		Name:            "Coffee and a cookie",
+		Kind:            "bogo",
+		BuyQuantity:     2,
+		FreeQuantity:    1,
+		CategoryIds:     []uint32{coffees.Category.Id},
+		FreeMenuItemIds: []uint32{cookie.MenuItem.Id},
+	}})
+	require.NoError(t, err)
+
+	orderResp, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		UserId: userResp.User.Id,
+		Items: []*orderv1.OrderItemRequest{
+			{MenuItemId: latte.MenuItem.Id, Quantity: 2},
+			{MenuItemId: cookie.MenuItem.Id, Quantity: 1},
+		},
+	})
+	require.NoError(t, err)
+
+	order := orderResp.Order
+	assert.Equal(t, int64(980), order.Subtotal.MinorUnits)
+	assert.Equal(t, int64(180), order.Discount.MinorUnits)
+	assert.Equal(t, int64(800), order.Total.MinorUnits)
+	require.Len(t, order.Discounts, 1)
+	assert.Equal(t, promotion.Promotion.Id, order.Discounts[0].PromotionId)
+	assert.Equal(t, "Coffee and a cookie", order.Discounts[0].Name)
+}
+
+func TestIntegration_OrderForAuthenticatedCaller(t *testing.T) {
+	// Setup all three services
+	setupUserService(t)
+	setupMenuService(t)
+
+	ctx := context.Background()
+
+	userConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer userConn.Close()
+
+	menuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer menuConn.Close()
+
+	setupOrderService(t, userConn, menuConn)
+
+	orderConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(orderListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer orderConn.Close()
+
+	userClient := userv1.NewUserServiceClient(userConn)
+	menuClient := menuv1.NewMenuServiceClient(menuConn)
+	orderClient := orderv1.NewOrderServiceClient(orderConn)
+
+	// A student registers and another user exists
+	registered, err := userClient.Register(ctx, &userv1.RegisterRequest{
+		Name: "Signed In", Email: "signed-in@test.com", Password: "correct horse",
+	})
+	require.NoError(t, err)
+	other, err := userClient.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Someone Else", Email: "else@test.com"})
+	require.NoError(t, err)
+
+	item, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{Name: "Coffee", Price: usd(250)})
+	require.NoError(t, err)
+
+	// The gateway forwards the caller from the access token as metadata
+	callerCtx := principal.NewOutgoingContext(ctx, principal.Principal{
+		UserID: registered.User.Id,
+		Role:   principal.RoleStudent,
+	})
+
+	// Naming another user in the request does not place the order for them
+	orderResp, err := orderClient.CreateOrder(callerCtx, &orderv1.CreateOrderRequest{
+		UserId: other.User.Id,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: item.MenuItem.Id, Quantity: 1}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, registered.User.Id, orderResp.Order.UserId)
+
+	_, err = orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		UserId: other.User.Id,
+		Items:  []*orderv1.OrderItemRequest{{MenuItemId: item.MenuItem.Id, Quantity: 1}},
+	})
+	require.NoError(t, err)
+
+	// The caller only lists their own orders
+	listResp, err := orderClient.GetOrders(callerCtx, &orderv1.GetOrdersRequest{})
+	require.NoError(t, err)
+	require.Len(t, listResp.Orders, 1)
+	assert.Equal(t, orderResp.Order.Id, listResp.Orders[0].Id)
+}
+
+func TestIntegration_Authorization(t *testing.T) {
+	// Setup all three services, authorizing calls as in production
+	setupUserService(t, authz.ServerOptions(usergrpc.Policy)...)
+	setupMenuService(t, authz.ServerOptions(menugrpc.Policy)...)
+
+	ctx := context.Background()
+
+	// The order service calls the others as itself
+	serviceUserConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		authz.AsService())
+	require.NoError(t, err)
+	defer serviceUserConn.Close()
+
+	serviceMenuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		authz.AsService())
+	require.NoError(t, err)
+	defer serviceMenuConn.Close()
+
+	setupOrderService(t, serviceUserConn, serviceMenuConn, authz.ServerOptions(ordergrpc.Policy)...)
+
+	userConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer userConn.Close()
+
+	menuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer menuConn.Close()
+
+	orderConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(orderListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer orderConn.Close()
+
+	userClient := userv1.NewUserServiceClient(userConn)
+	menuClient := menuv1.NewMenuServiceClient(menuConn)
+	orderClient := orderv1.NewOrderServiceClient(orderConn)
+
+	// Anyone may register; the gateway forwards callers from their access tokens
+	registered, err := userClient.Register(ctx, &userv1.RegisterRequest{
+		Name: "Authorized Student", Email: "authorized-student@test.com", Password: "correct horse",
+	})
+	require.NoError(t, err)
+	studentCtx := principal.NewOutgoingContext(ctx, principal.Principal{UserID: registered.User.Id, Role: principal.RoleStudent})
+	otherStudentCtx := principal.NewOutgoingContext(ctx, principal.Principal{UserID: registered.User.Id + 1000, Role: principal.RoleStudent})
+	staffCtx := principal.NewOutgoingContext(ctx, principal.Principal{UserID: registered.User.Id + 1001, Role: principal.RoleStaff})
+	ownerCtx := principal.NewOutgoingContext(ctx, principal.Principal{UserID: registered.User.Id + 1002, Role: principal.RoleOwner})
+
+	// Only owners change the menu
+	newItem := &menuv1.CreateMenuItemRequest{Name: "Authorized Coffee", Price: usd(250)}
+	_, err = menuClient.CreateMenuItem(ctx, newItem)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = menuClient.CreateMenuItem(studentCtx, newItem)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = menuClient.CreateMenuItem(staffCtx, newItem)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	item, err := menuClient.CreateMenuItem(ownerCtx, newItem)
+	require.NoError(t, err)
+
+	// Browsing the menu needs no sign in
+	_, err = menuClient.GetMenu(ctx, &menuv1.GetMenuRequest{})
+	assert.NoError(t, err)
+
+	// Stock is only moved by the order service, even for owners
+	_, err = menuClient.ReserveStock(ownerCtx, &menuv1.ReserveStockRequest{
+		ReservationId: "manual",
+		Lines:         []*menuv1.StockLine{{MenuItemId: item.MenuItem.Id, Quantity: 1}},
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Only staff list users
+	_, err = userClient.GetUsers(studentCtx, &userv1.GetUsersRequest{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = userClient.GetUsers(staffCtx, &userv1.GetUsersRequest{})
+	assert.NoError(t, err)
+
+	// A student orders; the order service checks the user and reserves stock as itself
+	orderResp, err := orderClient.CreateOrder(studentCtx, &orderv1.CreateOrderRequest{
+		Items: []*orderv1.OrderItemRequest{{MenuItemId: item.MenuItem.Id, Quantity: 1}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, registered.User.Id, orderResp.Order.UserId)
+
+	// Other students cannot see the order, staff can
+	_, err = orderClient.GetOrder(otherStudentCtx, &orderv1.GetOrderRequest{Id: orderResp.Order.Id})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = orderClient.GetOrder(staffCtx, &orderv1.GetOrderRequest{Id: orderResp.Order.Id})
+	assert.NoError(t, err)
+
+	// Only staff progress orders, and the change is recorded as theirs
+	statusReq := &orderv1.UpdateOrderStatusRequest{Id: orderResp.Order.Id, Status: "preparing"}
+	_, err = orderClient.UpdateOrderStatus(studentCtx, statusReq)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	updated, err := orderClient.UpdateOrderStatus(staffCtx, statusReq)
+	require.NoError(t, err)
+	require.Len(t, updated.Order.StatusHistory, 1)
+	assert.Equal(t, fmt.Sprintf("staff %d", registered.User.Id+1001), updated.Order.StatusHistory[0].Actor)
+}
+
+func TestIntegration_UserErasure(t *testing.T) {
+	// Setup all three services, authorizing calls as in production
+	userServer := setupUserService(t, authz.ServerOptions(usergrpc.Policy)...)
+	setupMenuService(t, authz.ServerOptions(menugrpc.Policy)...)
+
+	ctx := context.Background()
+
+	serviceUserConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		authz.AsService())
+	require.NoError(t, err)
+	defer serviceUserConn.Close()
+
+	serviceMenuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		authz.AsService())
+	require.NoError(t, err)
+	defer serviceMenuConn.Close()
+
+	setupOrderService(t, serviceUserConn, serviceMenuConn, authz.ServerOptions(ordergrpc.Policy)...)
+
+	userConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(userListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer userConn.Close()
+
+	menuConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(menuListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer menuConn.Close()
+
+	orderConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(orderListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer orderConn.Close()
+
+	userClient := userv1.NewUserServiceClient(userConn)
+	menuClient := menuv1.NewMenuServiceClient(menuConn)
+	orderClient := orderv1.NewOrderServiceClient(orderConn)
+
+	// A student places an order
+	registered, err := userClient.Register(ctx, &userv1.RegisterRequest{
+		Name: "Erased Student", Email: "erased-student@test.com", Password: "correct horse",
+	})
+	require.NoError(t, err)
+	studentCtx := principal.NewOutgoingContext(ctx, principal.Principal{UserID: registered.User.Id, Role: principal.RoleStudent})
+	ownerCtx := principal.NewOutgoingContext(ctx, principal.Principal{UserID: registered.User.Id + 1000, Role: principal.RoleOwner})
+
+	item, err := menuClient.CreateMenuItem(ownerCtx, &menuv1.CreateMenuItemRequest{Name: "Erasure Tea", Price: usd(200)})
+	require.NoError(t, err)
+	orderResp, err := orderClient.CreateOrder(studentCtx, &orderv1.CreateOrderRequest{
+		Items: []*orderv1.OrderItemRequest{{MenuItemId: item.MenuItem.Id, Quantity: 1, Note: "for the erased student"}},
+	})
+	require.NoError(t, err)
+
+	// Only the user service may detach orders
+	_, err = orderClient.DetachUserOrders(ownerCtx, &orderv1.DetachUserOrdersRequest{UserId: registered.User.Id})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// The student deletes themselves while the order service is unreachable
+	deleted, err := userClient.DeleteUser(studentCtx, &userv1.DeleteUserRequest{Id: registered.User.Id})
+	require.NoError(t, err)
+	assert.Equal(t, "pending", deleted.Erasure.Status)
+	assert.NotEmpty(t, deleted.Erasure.LastError)
+
+	_, err = userClient.GetUser(ownerCtx, &userv1.GetUserRequest{Id: registered.User.Id})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = userClient.Login(ctx, &userv1.LoginRequest{Email: "erased-student@test.com", Password: "correct horse"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Once it is reachable, recovery completes the erasure
+	serviceOrderConn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(bufDialer(orderListener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		authz.AsService())
+	require.NoError(t, err)
+	defer serviceOrderConn.Close()
+	userServer.Orders = orderv1.NewOrderServiceClient(serviceOrderConn)
+	require.NoError(t, userServer.RecoverErasures(ctx, 0))
+
+	erasures, err := userClient.ListErasures(ownerCtx, &userv1.ListErasuresRequest{})
+	require.NoError(t, err)
+	require.Len(t, erasures.Erasures, 1)
+	assert.Equal(t, "completed", erasures.Erasures[0].Status)
+	assert.Equal(t, int64(1), erasures.Erasures[0].OrdersDetached)
+	assert.Equal(t, fmt.Sprintf("student %d", registered.User.Id), erasures.Erasures[0].RequestedBy)
+
+	// The order is kept for accounting, detached from the student
+	order, err := orderClient.GetOrder(ownerCtx, &orderv1.GetOrderRequest{Id: orderResp.Order.Id})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), order.Order.UserId)
+	assert.NotEmpty(t, order.Order.UserDetachedAt)
+	assert.Empty(t, order.Order.OrderItems[0].Note)
+	assert.Equal(t, orderResp.Order.Total.MinorUnits, order.Order.Total.MinorUnits)
+}
+
 func TestIntegration_ConcurrentOrders(t *testing.T) {
 	// Setup all services
 	setupUserService(t)
@@ -385,46 +1163,84 @@ func TestIntegration_ConcurrentOrders(t *testing.T) {
 	})
 	require.NoError(t, err)
 
+	// Fewer units than orders, so the orders race for the last ones
+	const stock, numOrders = 5, 20
+	initialStock := int32(stock)
 	itemResp, err := menuClient.CreateMenuItem(ctx, &menuv1.CreateMenuItemRequest{
 		Name:        "Test Item",
 		Description: "For concurrent testing",
-		Price:       1.00,
+		Price:       usd(100),
+		Stock:       &initialStock,
 	})
 	require.NoError(t, err)
+	itemID := itemResp.MenuItem.Id
 
-	// Create multiple orders concurrently
-	numOrders := 10
-	errChan := make(chan error, numOrders)
-	respChan := make(chan *orderv1.CreateOrderResponse, numOrders)
+	remaining := func() int32 {
+		resp, err := menuClient.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: itemID})
+		require.NoError(t, err)
+		require.NotNil(t, resp.MenuItem.Stock)
+		return *resp.MenuItem.Stock
+	}
+
+	// Watch the stock while the orders run, so a dip below zero is caught
+	done := make(chan struct{})
+	lowest := make(chan int32)
+	go func() {
+		low := int32(stock)
+		for {
+			select {
+			case <-done:
+				lowest <- low
+				return
+			default:
+			}
+			if resp, err := menuClient.GetMenuItem(ctx, &menuv1.GetMenuItemRequest{Id: itemID}); err == nil && resp.MenuItem.Stock != nil {
+				low = min(low, *resp.MenuItem.Stock)
+			}
+		}
+	}()
 
+	// Place the orders at the same time
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make([]error, numOrders)
 	for i := 0; i < numOrders; i++ {
-		go func() {
-			resp, err := orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = orderClient.CreateOrder(ctx, &orderv1.CreateOrderRequest{
 				UserId: userResp.User.Id,
 				Items: []*orderv1.OrderItemRequest{
-					{MenuItemId: itemResp.MenuItem.Id, Quantity: 1},
+					{MenuItemId: itemID, Quantity: 1},
 				},
 			})
-			errChan <- err
-			respChan <- resp
-		}()
+		}(i)
 	}
-
-	// Collect results
-	// Note: SQLite may have locking issues with concurrent writes,
-	// so we expect some failures in this test
-	successCount := 0
-	for i := 0; i < numOrders; i++ {
-		err := <-errChan
-		resp := <-respChan
-		if err == nil && resp != nil {
-			successCount++
-			assert.NotZero(t, resp.Order.Id)
+	close(start)
+	wg.Wait()
+	close(done)
+
+	// Exactly as many orders as there were units succeed, the rest are told it is out of stock
+	var succeeded int
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
 		}
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err), "unexpected error: %v", err)
 	}
+	assert.Equal(t, stock, succeeded)
+
+	assert.GreaterOrEqual(t, <-lowest, int32(0))
+	assert.Equal(t, int32(0), remaining())
+
+	orders, err := orderClient.GetOrders(ctx, &orderv1.GetOrdersRequest{UserId: userResp.User.Id})
+	require.NoError(t, err)
+	assert.Len(t, orders.Orders, stock)
+}
 
-	// With SQLite, we expect at least some orders to succeed
-	// In production with PostgreSQL, all should succeed
-	assert.GreaterOrEqual(t, successCount, numOrders/2,
-		"At least half of concurrent orders should succeed (SQLite has known locking limitations)")
+// usd returns a USD amount in cents
+func usd(cents int64) *commonv1.Money {
+	return &commonv1.Money{CurrencyCode: "USD", MinorUnits: cents}
 }
\ No newline at end of file
@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePEM writes a PEM block to a file in a temporary directory
+func writePEM(t *testing.T, name, blockType string, der []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestLoadKeys(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaDER, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	edDER, err := x509.MarshalPKCS8PrivateKey(edKey)
+	require.NoError(t, err)
+	edPublicDER, err := x509.MarshalPKIXPublicKey(edKey.Public())
+	require.NoError(t, err)
+
+	t.Run("RSA PKCS#8", func(t *testing.T) {
+		key, err := LoadSigningKey(writePEM(t, "rsa.pem", "PRIVATE KEY", rsaDER))
+		require.NoError(t, err)
+		assert.Equal(t, "RS256", key.Algorithm)
+		assert.True(t, key.CanSign())
+
+		jwk := key.JWK()
+		assert.Equal(t, "RSA", jwk.KeyType)
+		assert.Equal(t, "AQAB", jwk.E)
+		assert.Equal(t, key.ID, jwk.KeyID)
+	})
+
+	t.Run("RSA PKCS#1", func(t *testing.T) {
+		key, err := LoadSigningKey(writePEM(t, "rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)))
+		require.NoError(t, err)
+		assert.Equal(t, "RS256", key.Algorithm)
+	})
+
+	t.Run("Ed25519 private and public halves share an ID", func(t *testing.T) {
+		private, err := LoadSigningKey(writePEM(t, "ed.pem", "PRIVATE KEY", edDER))
+		require.NoError(t, err)
+		assert.Equal(t, "EdDSA", private.Algorithm)
+
+		public, err := LoadVerificationKey(writePEM(t, "ed.pub", "PUBLIC KEY", edPublicDER))
+		require.NoError(t, err)
+		assert.False(t, public.CanSign())
+		assert.Equal(t, private.ID, public.ID)
+
+		jwk := public.JWK()
+		assert.Equal(t, "OKP", jwk.KeyType)
+		assert.Equal(t, "Ed25519", jwk.Curve)
+		assert.Empty(t, jwk.N)
+	})
+
+	t.Run("small RSA keys are refused", func(t *testing.T) {
+		small, err := rsa.GenerateKey(rand.Reader, 1024)
+		require.NoError(t, err)
+		_, err = LoadSigningKey(writePEM(t, "small.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(small)))
+		assert.Error(t, err)
+	})
+
+	t.Run("public key cannot sign", func(t *testing.T) {
+		public, err := LoadVerificationKey(writePEM(t, "ed.pub", "PUBLIC KEY", edPublicDER))
+		require.NoError(t, err)
+		_, err = NewIssuer(public)
+		assert.Error(t, err)
+	})
+}
+
+// TestThumbprint checks the key ID against the RFC 7638 example
+func TestThumbprint(t *testing.T) {
+	const n = "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+	modulus, err := base64.RawURLEncoding.DecodeString(n)
+	require.NoError(t, err)
+	public := &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: 65537}
+
+	key, err := newKey(public)
+	require.NoError(t, err)
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", key.ID)
+}
+
+func TestIssueAndVerify(t *testing.T) {
+	signing, err := GenerateKey()
+	require.NoError(t, err)
+	issuer, err := NewIssuer(signing)
+	require.NoError(t, err)
+	now := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+
+	token, expiresAt, err := issuer.IssueAccessToken(42, RoleOwner, now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(DefaultAccessTokenTTL), expiresAt)
+
+	t.Run("valid", func(t *testing.T) {
+		claims, err := issuer.Verify(token, now.Add(time.Minute))
+		require.NoError(t, err)
+		userID, err := claims.UserID()
+		require.NoError(t, err)
+		assert.Equal(t, uint(42), userID)
+		assert.Equal(t, RoleOwner, claims.Role)
+		assert.NotEmpty(t, claims.ID)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		_, err := issuer.Verify(token, expiresAt.Add(time.Second))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		parts := strings.Split(token, ".")
+		parts[2] = strings.Repeat("A", len(parts[2]))
+		_, err := issuer.Verify(strings.Join(parts, "."), now)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		other, err := NewIssuer(signing)
+		require.NoError(t, err)
+		other.Audience = "someone-else"
+		_, err = other.Verify(token, now)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("previous key still verifies while rotating", func(t *testing.T) {
+		next, err := GenerateKey()
+		require.NoError(t, err)
+		rotated, err := NewIssuer(next, signing)
+		require.NoError(t, err)
+		require.Len(t, rotated.Keys(), 2)
+		assert.Equal(t, next.ID, rotated.Keys()[0].ID)
+
+		_, err = rotated.Verify(token, now)
+		assert.NoError(t, err)
+
+		unknown, err := NewIssuer(next)
+		require.NoError(t, err)
+		_, err = unknown.Verify(token, now)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestPasswords(t *testing.T) {
+	PasswordCost = 4 // bcrypt.MinCost, to keep the test fast
+
+	hash, err := HashPassword("correct horse")
+	require.NoError(t, err)
+	assert.True(t, CheckPassword(hash, "correct horse"))
+	assert.False(t, CheckPassword(hash, "wrong horse"))
+	assert.False(t, CheckPassword("", "correct horse"))
+
+	_, err = HashPassword("short")
+	assert.ErrorIs(t, err, ErrWeakPassword)
+	_, err = HashPassword(strings.Repeat("x", MaxPasswordBytes+1))
+	assert.ErrorIs(t, err, ErrWeakPassword)
+}
+
+func TestRefreshTokens(t *testing.T) {
+	token, hash, err := NewRefreshToken()
+	require.NoError(t, err)
+	other, _, err := NewRefreshToken()
+	require.NoError(t, err)
+
+	assert.NotEqual(t, token, other)
+	assert.Equal(t, hash, HashRefreshToken(token))
+	assert.NotEqual(t, token, hash)
+}
\ No newline at end of file
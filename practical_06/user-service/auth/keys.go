@@ -0,0 +1,217 @@
+// Package auth hashes passwords and issues the signed JWTs that other
+// services verify offline against the user service's JSON Web Key Set.
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSABits is the smallest RSA key accepted for signing or verifying
+const minRSABits = 2048
+
+// Key is a key that signs or verifies access tokens. Keys loaded from a
+// public key file can only verify.
+type Key struct {
+	ID        string // RFC 7638 thumbprint, used as the JWT "kid"
+	Algorithm string // "RS256" or "EdDSA"
+	Public    crypto.PublicKey
+	private   crypto.Signer
+}
+
+// JWK is a public key in JSON Web Key form (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"` // OKP keys only
+	X         string `json:"x,omitempty"`   // OKP keys only
+	N         string `json:"n,omitempty"`   // RSA keys only
+	E         string `json:"e,omitempty"`   // RSA keys only
+}
+
+// LoadSigningKey reads a PEM encoded RSA or Ed25519 private key, in PKCS#8 or,
+// for RSA, PKCS#1 form
+func LoadSigningKey(path string) (*Key, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var private any
+	switch block.Type {
+	case "PRIVATE KEY":
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: unsupported PEM block %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: unsupported private key type %T", path, private)
+	}
+	key, err := newKey(signer.Public())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	key.private = signer
+	return key, nil
+}
+
+// LoadVerificationKey reads a PEM encoded RSA or Ed25519 public key in PKIX
+// form, or the public half of a private key
+func LoadVerificationKey(path string) (*Key, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type != "PUBLIC KEY" {
+		key, err := LoadSigningKey(path)
+		if err != nil {
+			return nil, err
+		}
+		key.private = nil
+		return key, nil
+	}
+
+	public, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	key, err := newKey(public)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return key, nil
+}
+
+// GenerateKey creates an Ed25519 signing key. Tokens signed by it stop
+// verifying when the process exits, so it is only for development and tests.
+func GenerateKey() (*Key, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	key, err := newKey(private.Public())
+	if err != nil {
+		return nil, err
+	}
+	key.private = private
+	return key, nil
+}
+
+// readPEM reads the first PEM block of a file
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", path)
+	}
+	return block, nil
+}
+
+// newKey works out the algorithm and ID of a public key
+func newKey(public crypto.PublicKey) (*Key, error) {
+	key := &Key{Public: public}
+	switch public := public.(type) {
+	case *rsa.PublicKey:
+		if public.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("RSA key must be at least %d bits", minRSABits)
+		}
+		key.Algorithm = jwt.SigningMethodRS256.Alg()
+	case ed25519.PublicKey:
+		key.Algorithm = jwt.SigningMethodEdDSA.Alg()
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T, want RSA or Ed25519", public)
+	}
+
+	thumbprint, err := key.thumbprint()
+	if err != nil {
+		return nil, err
+	}
+	key.ID = thumbprint
+	return key, nil
+}
+
+// CanSign reports whether the key has a private half
+func (k *Key) CanSign() bool {
+	return k.private != nil
+}
+
+// JWK returns the public half of the key in JSON Web Key form
+func (k *Key) JWK() JWK {
+	jwk := k.members()
+	jwk.KeyID = k.ID
+	jwk.Use = "sig"
+	jwk.Algorithm = k.Algorithm
+	return jwk
+}
+
+// members returns the key type and key material of the JWK
+func (k *Key) members() JWK {
+	encode := base64.RawURLEncoding.EncodeToString
+	switch public := k.Public.(type) {
+	case *rsa.PublicKey:
+		return JWK{KeyType: "RSA", N: encode(public.N.Bytes()), E: encode(big.NewInt(int64(public.E)).Bytes())}
+	case ed25519.PublicKey:
+		return JWK{KeyType: "OKP", Curve: "Ed25519", X: encode(public)}
+	}
+	return JWK{}
+}
+
+// thumbprint computes the RFC 7638 thumbprint of the key: the SHA-256 of its
+// required members, serialised in lexicographic order
+func (k *Key) thumbprint() (string, error) {
+	jwk := k.members()
+	var required any
+	switch jwk.KeyType {
+	case "RSA":
+		required = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.KeyType, jwk.N}
+	case "OKP":
+		required = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Curve, jwk.KeyType, jwk.X}
+	default:
+		return "", errors.New("unsupported key type")
+	}
+
+	data, err := json.Marshal(required)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// signingMethod returns the JWT signing method of the key
+func (k *Key) signingMethod() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
\ No newline at end of file
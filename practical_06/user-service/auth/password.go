@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password length limits. bcrypt only uses the first 72 bytes, so longer
+// passwords are refused rather than silently truncated.
+const (
+	MinPasswordLength = 8
+	MaxPasswordBytes  = 72
+)
+
+// PasswordCost is the bcrypt cost passwords are hashed with
+var PasswordCost = bcrypt.DefaultCost
+
+// ErrWeakPassword is returned for passwords outside the length limits
+var ErrWeakPassword = fmt.Errorf("password must be at least %d characters and at most %d bytes", MinPasswordLength, MaxPasswordBytes)
+
+// dummyHash is compared against when there is no user, so a failed login takes
+// as long whether or not the email is registered
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not a real password"), bcrypt.DefaultCost)
+
+// HashPassword hashes a password with bcrypt
+func HashPassword(password string) (string, error) {
+	if len([]rune(password)) < MinPasswordLength || len(password) > MaxPasswordBytes {
+		return "", ErrWeakPassword
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), PasswordCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword reports whether password matches hash. An empty hash, for a
+// user without a password, never matches but takes as long to check.
+func CheckPassword(hash, password string) bool {
+	if hash == "" {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
\ No newline at end of file
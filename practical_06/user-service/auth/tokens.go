@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/douglasswm/student-cafe-protos/principal"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Roles carried in access tokens
+const (
+	RoleOwner   = principal.RoleOwner
+	RoleStaff   = principal.RoleStaff
+	RoleStudent = principal.RoleStudent
+)
+
+// Default token lifetimes
+const (
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// DefaultIssuer is the default "iss" and "aud" of access tokens
+const DefaultIssuer = "student-cafe"
+
+// refreshTokenBytes is the amount of randomness in a refresh token
+const refreshTokenBytes = 32
+
+// ErrInvalidToken is returned for tokens that are malformed, expired, or not
+// signed by a known key
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims are the claims of an access token. The subject is the user ID.
+type Claims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role"`
+}
+
+// UserID returns the user the token was issued to
+func (c *Claims) UserID() (uint, error) {
+	id, err := strconv.ParseUint(c.Subject, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: bad subject %q", ErrInvalidToken, c.Subject)
+	}
+	return uint(id), nil
+}
+
+// Issuer signs and verifies access tokens
+type Issuer struct {
+	Issuer          string // "iss" of issued tokens, also required when verifying
+	Audience        string // "aud" of issued tokens, also required when verifying
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+
+	signing *Key
+	keys    []*Key
+}
+
+// NewIssuer creates an issuer that signs with signing. Tokens signed by it, or
+// by any of the verification keys, verify; keep a previous signing key there
+// until the tokens it signed have expired.
+func NewIssuer(signing *Key, verification ...*Key) (*Issuer, error) {
+	if signing == nil || !signing.CanSign() {
+		return nil, errors.New("signing key has no private key")
+	}
+	keys := []*Key{signing}
+	seen := map[string]bool{signing.ID: true}
+	for _, key := range verification {
+		if !seen[key.ID] {
+			seen[key.ID] = true
+			keys = append(keys, key)
+		}
+	}
+	return &Issuer{
+		Issuer:          DefaultIssuer,
+		Audience:        DefaultIssuer,
+		AccessTokenTTL:  DefaultAccessTokenTTL,
+		RefreshTokenTTL: DefaultRefreshTokenTTL,
+		signing:         signing,
+		keys:            keys,
+	}, nil
+}
+
+// Keys returns the keys access tokens are verified with, signing key first
+func (i *Issuer) Keys() []*Key {
+	return i.keys
+}
+
+// IssueAccessToken signs an access token for a user, valid from now, and
+// returns it with its expiry
+func (i *Issuer) IssueAccessToken(userID uint, role string, now time.Time) (string, time.Time, error) {
+	jti, err := randomToken(16)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	expiresAt := now.Add(i.AccessTokenTTL)
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    i.Issuer,
+			Subject:   strconv.FormatUint(uint64(userID), 10),
+			Audience:  jwt.ClaimStrings{i.Audience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        jti,
+		},
+		Role: role,
+	}
+
+	token := jwt.NewWithClaims(i.signing.signingMethod(), claims)
+	token.Header["kid"] = i.signing.ID
+	signed, err := token.SignedString(i.signing.private)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// Verify checks an access token's signature, issuer, audience and expiry at
+// now, and returns its claims
+func (i *Issuer) Verify(tokenString string, now time.Time) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		for _, key := range i.keys {
+			if key.ID == kid && key.Algorithm == token.Method.Alg() {
+				return key.Public, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key %q", kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(i.Issuer),
+		jwt.WithAudience(i.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(func() time.Time { return now }),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if _, err := claims.UserID(); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// NewRefreshToken creates an opaque refresh token. Only its hash is stored.
+func NewRefreshToken() (token, hash string, err error) {
+	token, err = randomToken(refreshTokenBytes)
+	if err != nil {
+		return "", "", err
+	}
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken returns the hash a refresh token is stored and looked up by.
+// Refresh tokens are random, so a fast hash is enough.
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomToken returns n random bytes, base64url encoded
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
\ No newline at end of file
@@ -18,7 +18,7 @@ func Connect(dsn string) error {
     }
 
     // Only migrate user-related tables
-    err = DB.AutoMigrate(&models.User{})
+    err = DB.AutoMigrate(&models.User{}, &models.RefreshToken{}, &models.UserErasure{})
     if err != nil {
         return err
     }
@@ -0,0 +1,304 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"user-service/auth"
+	"user-service/database"
+	"user-service/models"
+)
+
+// errBadCredentials is returned for every failed login, so callers cannot tell
+// which emails are registered
+var errBadCredentials = status.Errorf(codes.Unauthenticated, "invalid email or password")
+
+// errBadRefreshToken is returned for refresh tokens that are unknown, expired
+// or revoked
+var errBadRefreshToken = status.Errorf(codes.Unauthenticated, "invalid refresh token")
+
+// now returns the current time
+func (s *UserServer) now() time.Time {
+	if s.clock != nil {
+		return s.clock().UTC()
+	}
+	return time.Now().UTC()
+}
+
+// issuer returns the token issuer, or an error if authentication is not set up
+func (s *UserServer) issuer() (*auth.Issuer, error) {
+	if s.Tokens == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "authentication is not configured")
+	}
+	return s.Tokens, nil
+}
+
+// Register creates a user with a password and signs them in. Registered users
+// are never cafe owners.
+func (s *UserServer) Register(ctx context.Context, req *userv1.RegisterRequest) (*userv1.RegisterResponse, error) {
+	issuer, err := s.issuer()
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	email := normalizeEmail(req.Email)
+	if name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+	if !strings.Contains(email, "@") {
+		return nil, status.Errorf(codes.InvalidArgument, "a valid email is required")
+	}
+	hash, err := auth.HashPassword(req.Password)
+	if err == auth.ErrWeakPassword {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
+	}
+
+	user := models.User{Name: name, Email: email, PasswordHash: hash}
+	var tokens *userv1.TokenPair
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.User{}).Where("LOWER(email) = ?", email).Count(&count).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to look up user: %v", err)
+		}
+		if count > 0 {
+			return status.Errorf(codes.AlreadyExists, "a user with this email already exists")
+		}
+		if err := tx.Create(&user).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to create user: %v", err)
+		}
+
+		tokens, err = s.issueTokens(tx, issuer, &user, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userv1.RegisterResponse{
+		User:   modelToProto(&user),
+		Tokens: tokens,
+	}, nil
+}
+
+// Login signs a user in with their email and password
+func (s *UserServer) Login(ctx context.Context, req *userv1.LoginRequest) (*userv1.LoginResponse, error) {
+	issuer, err := s.issuer()
+	if err != nil {
+		return nil, err
+	}
+
+	var user models.User
+	err = database.DB.Where("LOWER(email) = ?", normalizeEmail(req.Email)).First(&user).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	// Check the password even without a user, so both failures take as long
+	if !auth.CheckPassword(user.PasswordHash, req.Password) {
+		return nil, errBadCredentials
+	}
+
+	tokens, err := s.issueTokens(database.DB, issuer, &user, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userv1.LoginResponse{
+		User:   modelToProto(&user),
+		Tokens: tokens,
+	}, nil
+}
+
+// RefreshToken exchanges a refresh token for a new token pair, revoking it.
+// Reusing a revoked token means it has leaked, so every token in its family is
+// revoked and the user has to sign in again.
+func (s *UserServer) RefreshToken(ctx context.Context, req *userv1.RefreshTokenRequest) (*userv1.RefreshTokenResponse, error) {
+	issuer, err := s.issuer()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	var stored models.RefreshToken
+	err = database.DB.Where(&models.RefreshToken{TokenHash: auth.HashRefreshToken(req.RefreshToken)}).First(&stored).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, errBadRefreshToken
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get refresh token: %v", err)
+	}
+	if stored.RevokedAt != nil {
+		if err := revokeFamily(stored.FamilyID, now); err != nil {
+			return nil, err
+		}
+		return nil, errBadRefreshToken
+	}
+	if !now.Before(stored.ExpiresAt) {
+		return nil, errBadRefreshToken
+	}
+
+	var tokens *userv1.TokenPair
+	reused := false
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Revoke conditionally, so of two concurrent refreshes only one wins
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", stored.ID).
+			Update("revoked_at", now)
+		if result.Error != nil {
+			return status.Errorf(codes.Internal, "failed to revoke refresh token: %v", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			reused = true
+			return errBadRefreshToken
+		}
+
+		var user models.User
+		if err := tx.First(&user, stored.UserID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return errBadRefreshToken
+			}
+			return status.Errorf(codes.Internal, "failed to get user: %v", err)
+		}
+
+		tokens, err = s.issueTokens(tx, issuer, &user, &stored)
+		return err
+	})
+	if reused {
+		if err := revokeFamily(stored.FamilyID, now); err != nil {
+			return nil, err
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &userv1.RefreshTokenResponse{
+		Tokens: tokens,
+	}, nil
+}
+
+// GetJWKS returns the public keys access tokens are verified with
+func (s *UserServer) GetJWKS(ctx context.Context, req *userv1.GetJWKSRequest) (*userv1.GetJWKSResponse, error) {
+	issuer, err := s.issuer()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &userv1.GetJWKSResponse{}
+	for _, key := range issuer.Keys() {
+		jwk := key.JWK()
+		resp.Keys = append(resp.Keys, &userv1.JSONWebKey{
+			Kty: jwk.KeyType,
+			Kid: jwk.KeyID,
+			Use: jwk.Use,
+			Alg: jwk.Algorithm,
+			Crv: jwk.Curve,
+			X:   jwk.X,
+			N:   jwk.N,
+			E:   jwk.E,
+		})
+	}
+	return resp, nil
+}
+
+// BootstrapOwner creates a cafe owner who signs in with email and password,
+// unless a user with that email already exists, so a new deployment has
+// someone to manage it. It reports whether the owner was created.
+func (s *UserServer) BootstrapOwner(name, email, password string) (bool, error) {
+	email = normalizeEmail(email)
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return false, err
+	}
+
+	created := false
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.User{}).Where("LOWER(email) = ?", email).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		created = true
+		return tx.Create(&models.User{Name: name, Email: email, IsCafeOwner: true, PasswordHash: hash}).Error
+	})
+	return created, err
+}
+
+// issueTokens signs an access token for user and stores a new refresh token.
+// The refresh token replaces, and joins the family of, the token it was
+// refreshed from; without one it starts a new family.
+func (s *UserServer) issueTokens(tx *gorm.DB, issuer *auth.Issuer, user *models.User, replaces *models.RefreshToken) (*userv1.TokenPair, error) {
+	now := s.now()
+	accessToken, accessExpiresAt, err := issuer.IssueAccessToken(user.ID, roleOf(user), now)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to sign access token: %v", err)
+	}
+
+	refreshToken, hash, err := auth.NewRefreshToken()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create refresh token: %v", err)
+	}
+	stored := models.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hash,
+		FamilyID:  hash, // The first token's hash is unique, and names the family
+		ExpiresAt: now.Add(issuer.RefreshTokenTTL),
+	}
+	if replaces != nil {
+		stored.FamilyID = replaces.FamilyID
+	}
+	if err := tx.Create(&stored).Error; err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to store refresh token: %v", err)
+	}
+	if replaces != nil {
+		err := tx.Model(replaces).Update("replaced_by_id", stored.ID).Error
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to rotate refresh token: %v", err)
+		}
+	}
+
+	return &userv1.TokenPair{
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		TokenType:             "Bearer",
+		AccessTokenExpiresAt:  accessExpiresAt.Format(time.RFC3339),
+		RefreshTokenExpiresAt: stored.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// revokeFamily revokes every refresh token in a family that is not revoked yet
+func revokeFamily(familyID string, now time.Time) error {
+	err := database.DB.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", now).Error
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to revoke refresh tokens: %v", err)
+	}
+	return nil
+}
+
+// roleOf returns the role access tokens for user carry
+func roleOf(user *models.User) string {
+	if user.IsCafeOwner {
+		return auth.RoleOwner
+	}
+	if user.IsStaff {
+		return auth.RoleStaff
+	}
+	return auth.RoleStudent
+}
+
+// normalizeEmail trims and lower-cases an email, so sign in is case insensitive
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
\ No newline at end of file
@@ -0,0 +1,216 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/douglasswm/student-cafe-protos/principal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"user-service/database"
+	"user-service/models"
+)
+
+// DeleteUser deletes a user and erases their personal data. The user is
+// anonymised, signed out and soft deleted at once, and an erasure is recorded;
+// the erasure completes when the Order Service has detached their orders,
+// which is retried by RunErasureRecovery if it fails now. Users may delete
+// themselves; only owners may delete other users. Deleting an erased user
+// again returns their erasure, retrying it if it is still pending.
+func (s *UserServer) DeleteUser(ctx context.Context, req *userv1.DeleteUserRequest) (*userv1.DeleteUserResponse, error) {
+	caller, authenticated := principal.FromIncomingContext(ctx)
+	if authenticated && !caller.IsOwner() && caller.UserID != req.Id {
+		return nil, status.Errorf(codes.PermissionDenied, "only owners may delete other users")
+	}
+	requestedBy := "internal"
+	if authenticated {
+		requestedBy = fmt.Sprintf("%s %d", caller.Role, caller.UserID)
+	}
+
+	var erasure models.UserErasure
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ?", req.Id).First(&erasure).Error
+		if err == nil {
+			return nil
+		}
+		if err != gorm.ErrRecordNotFound {
+			return status.Errorf(codes.Internal, "failed to get erasure: %v", err)
+		}
+
+		var user models.User
+		if err := tx.First(&user, req.Id).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return status.Errorf(codes.NotFound, "user not found")
+			}
+			return status.Errorf(codes.Internal, "failed to get user: %v", err)
+		}
+		if user.IsCafeOwner {
+			if err := checkOtherOwners(tx, user.ID); err != nil {
+				return err
+			}
+		}
+
+		// The soft deleted row is kept, so nothing left in it may identify the person
+		err = tx.Model(&user).Updates(map[string]interface{}{
+			"name":          "Deleted user",
+			"email":         fmt.Sprintf("deleted-%d@erased.invalid", user.ID),
+			"password_hash": "",
+			"is_cafe_owner": false,
+			"is_staff":      false,
+		}).Error
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to anonymise user: %v", err)
+		}
+		if err := tx.Where("user_id = ?", user.ID).Delete(&models.RefreshToken{}).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to delete refresh tokens: %v", err)
+		}
+		if err := tx.Delete(&user).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to delete user: %v", err)
+		}
+
+		erasure = models.UserErasure{
+			UserID:      user.ID,
+			RequestedBy: requestedBy,
+			Status:      models.ErasurePending,
+		}
+		if err := tx.Create(&erasure).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to record erasure: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if erasure.Status == models.ErasurePending {
+		// The user is already erased here, so a failure is only retried later
+		if err := s.detachOrders(ctx, &erasure); err != nil {
+			log.Printf("user erasure %d: detaching orders failed, will retry: %v", erasure.ID, err)
+		}
+	}
+
+	return &userv1.DeleteUserResponse{
+		Erasure: erasureToProto(&erasure),
+	}, nil
+}
+
+// ListErasures lists user erasures, newest first
+func (s *UserServer) ListErasures(ctx context.Context, req *userv1.ListErasuresRequest) (*userv1.ListErasuresResponse, error) {
+	query := database.DB.Order("id DESC")
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+
+	var erasures []models.UserErasure
+	if err := query.Find(&erasures).Error; err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list erasures: %v", err)
+	}
+
+	resp := &userv1.ListErasuresResponse{}
+	for i := range erasures {
+		resp.Erasures = append(resp.Erasures, erasureToProto(&erasures[i]))
+	}
+	return resp, nil
+}
+
+// detachOrders asks the Order Service to detach the orders of an erased user,
+// and records the attempt on the erasure: it completes on success, and keeps
+// the error otherwise
+func (s *UserServer) detachOrders(ctx context.Context, erasure *models.UserErasure) error {
+	var resp *orderv1.DetachUserOrdersResponse
+	err := errors.New("order service is not configured")
+	if s.Orders != nil {
+		resp, err = s.Orders.DetachUserOrders(ctx, &orderv1.DetachUserOrdersRequest{UserId: uint32(erasure.UserID)})
+	}
+
+	updates := map[string]interface{}{"attempts": gorm.Expr("attempts + 1")}
+	if err != nil {
+		updates["last_error"] = err.Error()
+	} else {
+		updates["status"] = models.ErasureCompleted
+		updates["completed_at"] = s.now()
+		updates["orders_detached"] = gorm.Expr("orders_detached + ?", resp.OrdersDetached)
+		updates["last_error"] = ""
+	}
+
+	// Only a pending erasure is updated, in case recovery finished it meanwhile
+	result := database.DB.Model(&models.UserErasure{}).
+		Where("id = ? AND status = ?", erasure.ID, models.ErasurePending).
+		Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("failed to record erasure attempt: %w", result.Error)
+	}
+	if reloadErr := database.DB.First(erasure, erasure.ID).Error; reloadErr != nil {
+		return fmt.Errorf("failed to reload erasure: %w", reloadErr)
+	}
+	return err
+}
+
+// RecoverErasures retries the pending erasures not attempted for staleAfter
+func (s *UserServer) RecoverErasures(ctx context.Context, staleAfter time.Duration) error {
+	var erasures []models.UserErasure
+	err := database.DB.
+		Where("status = ? AND updated_at < ?", models.ErasurePending, time.Now().Add(-staleAfter)).
+		Order("id").
+		Find(&erasures).Error
+	if err != nil {
+		return fmt.Errorf("failed to list pending erasures: %w", err)
+	}
+
+	var failed int
+	for i := range erasures {
+		if err := s.detachOrders(ctx, &erasures[i]); err != nil {
+			log.Printf("user erasure %d: retry failed: %v", erasures[i].ID, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d pending erasures could not be completed", failed, len(erasures))
+	}
+	return nil
+}
+
+// RunErasureRecovery calls RecoverErasures straight away and then every
+// interval until ctx is done, so erasures complete once the Order Service is
+// reachable again. Each erasure is retried at most once per interval.
+func (s *UserServer) RunErasureRecovery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RecoverErasures(ctx, interval); err != nil {
+			log.Printf("erasure recovery: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// erasureToProto converts a UserErasure model to its proto message
+func erasureToProto(erasure *models.UserErasure) *userv1.UserErasure {
+	var completedAt string
+	if erasure.CompletedAt != nil {
+		completedAt = erasure.CompletedAt.Format(time.RFC3339)
+	}
+	return &userv1.UserErasure{
+		Id:             uint32(erasure.ID),
+		UserId:         uint32(erasure.UserID),
+		Status:         erasure.Status,
+		RequestedBy:    erasure.RequestedBy,
+		RequestedAt:    erasure.CreatedAt.Format(time.RFC3339),
+		CompletedAt:    completedAt,
+		OrdersDetached: erasure.OrdersDetached,
+		Attempts:       int32(erasure.Attempts),
+		LastError:      erasure.LastError,
+	}
+}
\ No newline at end of file
@@ -0,0 +1,27 @@
+package grpc
+
+import (
+	"github.com/douglasswm/student-cafe-protos/authz"
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/douglasswm/student-cafe-protos/principal"
+)
+
+// Policy says who may call each UserService method. Anyone may sign up and
+// sign in, only owners create users and audit erasures, and staff see every
+// user. GetUser, UpdateUser and DeleteUser let other users only act on
+// themselves.
+var Policy = authz.Policy{
+	userv1.UserService_Register_FullMethodName:     authz.Public(),
+	userv1.UserService_Login_FullMethodName:        authz.Public(),
+	userv1.UserService_RefreshToken_FullMethodName: authz.Public(),
+	userv1.UserService_GetJWKS_FullMethodName:      authz.Public(),
+
+	userv1.UserService_GetUser_FullMethodName:  authz.Allow(principal.RoleOwner, principal.RoleStaff, principal.RoleStudent, principal.RoleService),
+	userv1.UserService_GetUsers_FullMethodName: authz.Allow(principal.RoleOwner, principal.RoleStaff),
+
+	userv1.UserService_UpdateUser_FullMethodName: authz.Allow(authz.Users...),
+	userv1.UserService_DeleteUser_FullMethodName: authz.Allow(authz.Users...),
+
+	userv1.UserService_CreateUser_FullMethodName:   authz.Allow(principal.RoleOwner),
+	userv1.UserService_ListErasures_FullMethodName: authz.Allow(principal.RoleOwner),
+}
\ No newline at end of file
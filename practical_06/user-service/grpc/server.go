@@ -2,12 +2,16 @@ package grpc
 
 import (
 	"context"
+	"strings"
 	"time"
 
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
 	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/douglasswm/student-cafe-protos/principal"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
+	"user-service/auth"
 	"user-service/database"
 	"user-service/models"
 )
@@ -15,6 +19,10 @@ import (
 // UserServer implements the gRPC UserService
 type UserServer struct {
 	userv1.UnimplementedUserServiceServer
+	Tokens *auth.Issuer               // Signs access tokens; nil disables Register, Login and RefreshToken
+	Orders orderv1.OrderServiceClient // Detaches the orders of deleted users; nil leaves their erasures pending
+
+	clock func() time.Time // Overridden in tests
 }
 
 // NewUserServer creates a new gRPC user server
@@ -22,16 +30,41 @@ func NewUserServer() *UserServer {
 	return &UserServer{}
 }
 
-// CreateUser creates a new user
+// CreateUser creates a new user. Users created with a password can sign in.
+// Emails are stored in lower case and must not belong to another user.
 func (s *UserServer) CreateUser(ctx context.Context, req *userv1.CreateUserRequest) (*userv1.CreateUserResponse, error) {
 	user := models.User{
 		Name:        req.Name,
-		Email:       req.Email,
+		Email:       normalizeEmail(req.Email),
 		IsCafeOwner: req.IsCafeOwner,
+		IsStaff:     req.IsStaff,
+	}
+	if req.Password != "" {
+		hash, err := auth.HashPassword(req.Password)
+		if err == auth.ErrWeakPassword {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
+		}
+		user.PasswordHash = hash
 	}
 
-	if err := database.DB.Create(&user).Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to create user: %v", err)
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.User{}).Where("LOWER(email) = ?", user.Email).Count(&count).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to look up user: %v", err)
+		}
+		if count > 0 {
+			return status.Errorf(codes.AlreadyExists, "a user with this email already exists")
+		}
+		if err := tx.Create(&user).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to create user: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &userv1.CreateUserResponse{
@@ -39,8 +72,12 @@ func (s *UserServer) CreateUser(ctx context.Context, req *userv1.CreateUserReque
 	}, nil
 }
 
-// GetUser retrieves a user by ID
+// GetUser retrieves a user by ID. Authenticated students may only get themselves.
 func (s *UserServer) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*userv1.GetUserResponse, error) {
+	if caller, ok := principal.FromIncomingContext(ctx); ok && caller.Role == principal.RoleStudent && caller.UserID != req.Id {
+		return nil, status.Errorf(codes.PermissionDenied, "students may only get their own user")
+	}
+
 	var user models.User
 	if err := database.DB.First(&user, req.Id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -71,6 +108,120 @@ func (s *UserServer) GetUsers(ctx context.Context, req *userv1.GetUsersRequest)
 	}, nil
 }
 
+// UpdateUser updates the fields of a user listed in the update mask. Users may
+// update their own name, email and password; only owners may update other
+// users or change roles. Changing the password signs the user out everywhere.
+func (s *UserServer) UpdateUser(ctx context.Context, req *userv1.UpdateUserRequest) (*userv1.UpdateUserResponse, error) {
+	if req.User == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "user is required")
+	}
+	if len(req.UpdateMask.GetPaths()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "update_mask must list the fields to update")
+	}
+	caller, authenticated := principal.FromIncomingContext(ctx)
+	if authenticated && !caller.IsOwner() && caller.UserID != req.User.Id {
+		return nil, status.Errorf(codes.PermissionDenied, "only owners may update other users")
+	}
+
+	updates := make(map[string]interface{})
+	passwordChanged := false
+	for _, path := range req.UpdateMask.Paths {
+		switch path {
+		case "name":
+			name := strings.TrimSpace(req.User.Name)
+			if name == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "name is required")
+			}
+			updates["name"] = name
+		case "email":
+			email := normalizeEmail(req.User.Email)
+			if !strings.Contains(email, "@") {
+				return nil, status.Errorf(codes.InvalidArgument, "a valid email is required")
+			}
+			updates["email"] = email
+		case "is_cafe_owner", "is_staff":
+			if authenticated && !caller.IsOwner() {
+				return nil, status.Errorf(codes.PermissionDenied, "only owners may change roles")
+			}
+			if path == "is_cafe_owner" {
+				updates["is_cafe_owner"] = req.User.IsCafeOwner
+			} else {
+				updates["is_staff"] = req.User.IsStaff
+			}
+		case "password":
+			hash, err := auth.HashPassword(req.Password)
+			if err == auth.ErrWeakPassword {
+				return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+			}
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
+			}
+			updates["password_hash"] = hash
+			passwordChanged = true
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", path)
+		}
+	}
+
+	var user models.User
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&user, req.User.Id).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return status.Errorf(codes.NotFound, "user not found")
+			}
+			return status.Errorf(codes.Internal, "failed to get user: %v", err)
+		}
+
+		if email, ok := updates["email"].(string); ok {
+			var count int64
+			if err := tx.Model(&models.User{}).Where("LOWER(email) = ? AND id <> ?", email, user.ID).Count(&count).Error; err != nil {
+				return status.Errorf(codes.Internal, "failed to look up user: %v", err)
+			}
+			if count > 0 {
+				return status.Errorf(codes.AlreadyExists, "a user with this email already exists")
+			}
+		}
+		if owner, ok := updates["is_cafe_owner"].(bool); ok && !owner && user.IsCafeOwner {
+			if err := checkOtherOwners(tx, user.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&user).Updates(updates).Error; err != nil {
+			return status.Errorf(codes.Internal, "failed to update user: %v", err)
+		}
+		if passwordChanged {
+			err := tx.Model(&models.RefreshToken{}).
+				Where("user_id = ? AND revoked_at IS NULL", user.ID).
+				Update("revoked_at", s.now()).Error
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to revoke refresh tokens: %v", err)
+			}
+		}
+		return tx.First(&user, user.ID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userv1.UpdateUserResponse{
+		User: modelToProto(&user),
+	}, nil
+}
+
+// checkOtherOwners fails with FailedPrecondition unless a cafe owner other than
+// the user exists, so the cafe is never left without one
+func checkOtherOwners(tx *gorm.DB, userID uint) error {
+	var count int64
+	if err := tx.Model(&models.User{}).Where("is_cafe_owner = ? AND id <> ?", true, userID).Count(&count).Error; err != nil {
+		return status.Errorf(codes.Internal, "failed to count owners: %v", err)
+	}
+	if count == 0 {
+		return status.Errorf(codes.FailedPrecondition, "the cafe must keep at least one owner")
+	}
+	return nil
+}
+
 // modelToProto converts a GORM User model to proto User message
 func modelToProto(user *models.User) *userv1.User {
 	return &userv1.User{
@@ -78,6 +229,7 @@ func modelToProto(user *models.User) *userv1.User {
 		Name:        user.Name,
 		Email:       user.Email,
 		IsCafeOwner: user.IsCafeOwner,
+		IsStaff:     user.IsStaff,
 		CreatedAt:   user.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   user.UpdatedAt.Format(time.RFC3339),
 	}
@@ -2,16 +2,24 @@ package grpc
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
+	"user-service/auth"
 	"user-service/database"
 	"user-service/models"
 
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
 	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/douglasswm/student-cafe-protos/principal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -21,8 +29,8 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	db, err := gorm.Open(sqlite.Open("file::memory:?cache=shared"), &gorm.Config{})
 	require.NoError(t, err, "Failed to open test database")
 
-	// Auto-migrate the User model
-	err = db.AutoMigrate(&models.User{})
+	// Auto-migrate the user models
+	err = db.AutoMigrate(&models.User{}, &models.RefreshToken{}, &models.UserErasure{})
 	require.NoError(t, err, "Failed to migrate test database")
 
 	return db
@@ -44,10 +52,11 @@ func TestCreateUser(t *testing.T) {
 	server := NewUserServer()
 
 	tests := []struct {
-		name        string
-		request     *userv1.CreateUserRequest
-		wantErr     bool
-		expectedMsg string
+		name          string
+		request       *userv1.CreateUserRequest
+		wantErr       bool
+		expectedMsg   string
+		expectedEmail string // Defaults to the requested email
 	}{
 		{
 			name: "successful user creation",
@@ -76,6 +85,24 @@ func TestCreateUser(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "email is stored in lower case",
+			request: &userv1.CreateUserRequest{
+				Name:  "Mixed Case",
+				Email: " Mixed.Case@Example.com ",
+			},
+			wantErr:       false,
+			expectedEmail: "mixed.case@example.com",
+		},
+		{
+			name: "email of another user in another case",
+			request: &userv1.CreateUserRequest{
+				Name:  "John Again",
+				Email: "JOHN@example.com",
+			},
+			wantErr:     true,
+			expectedMsg: "already exists",
+		},
 	}
 
 	for _, tt := range tests {
@@ -93,13 +120,28 @@ func TestCreateUser(t *testing.T) {
 				require.NotNil(t, resp)
 				assert.NotZero(t, resp.User.Id)
 				assert.Equal(t, tt.request.Name, resp.User.Name)
-				assert.Equal(t, tt.request.Email, resp.User.Email)
+				expectedEmail := tt.expectedEmail
+				if expectedEmail == "" {
+					expectedEmail = tt.request.Email
+				}
+				assert.Equal(t, expectedEmail, resp.User.Email)
 				assert.Equal(t, tt.request.IsCafeOwner, resp.User.IsCafeOwner)
 				assert.NotEmpty(t, resp.User.CreatedAt)
 				assert.NotEmpty(t, resp.User.UpdatedAt)
 			}
 		})
 	}
+
+	// A taken email is a conflict like on Register, not an internal error
+	_, err := server.CreateUser(context.Background(), &userv1.CreateUserRequest{Name: "Jane Again", Email: "Jane@CafeShop.com"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+}
+
+// asCaller returns a context carrying caller as incoming gRPC metadata, as the
+// API gateway sends it
+func asCaller(caller principal.Principal) context.Context {
+	md, _ := metadata.FromOutgoingContext(principal.NewOutgoingContext(context.Background(), caller))
+	return metadata.NewIncomingContext(context.Background(), md)
 }
 
 func TestGetUser(t *testing.T) {
@@ -121,33 +163,61 @@ func TestGetUser(t *testing.T) {
 
 	tests := []struct {
 		name        string
+		ctx         context.Context
 		userID      uint32
 		wantErr     bool
 		expectedErr codes.Code
 	}{
 		{
 			name:    "get existing user",
+			ctx:     context.Background(),
 			userID:  uint32(testUser.ID),
 			wantErr: false,
 		},
 		{
 			name:        "get non-existent user",
+			ctx:         context.Background(),
 			userID:      9999,
 			wantErr:     true,
 			expectedErr: codes.NotFound,
 		},
 		{
 			name:        "get user with ID 0",
+			ctx:         context.Background(),
 			userID:      0,
 			wantErr:     true,
 			expectedErr: codes.NotFound,
 		},
+		{
+			name:    "student gets themselves",
+			ctx:     asCaller(principal.Principal{UserID: uint32(testUser.ID), Role: principal.RoleStudent}),
+			userID:  uint32(testUser.ID),
+			wantErr: false,
+		},
+		{
+			name:        "student gets another user",
+			ctx:         asCaller(principal.Principal{UserID: uint32(testUser.ID) + 1, Role: principal.RoleStudent}),
+			userID:      uint32(testUser.ID),
+			wantErr:     true,
+			expectedErr: codes.PermissionDenied,
+		},
+		{
+			name:    "staff get any user",
+			ctx:     asCaller(principal.Principal{UserID: uint32(testUser.ID) + 1, Role: principal.RoleStaff}),
+			userID:  uint32(testUser.ID),
+			wantErr: false,
+		},
+		{
+			name:    "services get any user",
+			ctx:     asCaller(principal.Service),
+			userID:  uint32(testUser.ID),
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ctx := context.Background()
-			resp, err := server.GetUser(ctx, &userv1.GetUserRequest{
+			resp, err := server.GetUser(tt.ctx, &userv1.GetUserRequest{
 				Id: tt.userID,
 			})
 
@@ -236,4 +306,430 @@ func TestModelToProto(t *testing.T) {
 	assert.Equal(t, true, protoUser.IsCafeOwner)
 	assert.Equal(t, now.Format(time.RFC3339), protoUser.CreatedAt)
 	assert.Equal(t, now.Format(time.RFC3339), protoUser.UpdatedAt)
+}
+
+// newAuthServer creates a server that signs tokens with a fresh key
+func newAuthServer(t *testing.T) *UserServer {
+	auth.PasswordCost = bcrypt.MinCost
+	key, err := auth.GenerateKey()
+	require.NoError(t, err)
+	issuer, err := auth.NewIssuer(key)
+	require.NoError(t, err)
+
+	server := NewUserServer()
+	server.Tokens = issuer
+	return server
+}
+
+func TestRegisterAndLogin(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	server := newAuthServer(t)
+	ctx := context.Background()
+
+	registered, err := server.Register(ctx, &userv1.RegisterRequest{
+		Name:     "Alice",
+		Email:    " Alice@Example.com ",
+		Password: "correct horse",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", registered.User.Email)
+	assert.False(t, registered.User.IsCafeOwner)
+	assert.Equal(t, "Bearer", registered.Tokens.TokenType)
+	assert.NotEmpty(t, registered.Tokens.RefreshToken)
+
+	claims, err := server.Tokens.Verify(registered.Tokens.AccessToken, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprint(registered.User.Id), claims.Subject)
+	assert.Equal(t, auth.RoleStudent, claims.Role)
+
+	// The password is only stored hashed
+	var stored models.User
+	require.NoError(t, db.First(&stored, registered.User.Id).Error)
+	assert.NotContains(t, stored.PasswordHash, "correct horse")
+
+	t.Run("duplicate email", func(t *testing.T) {
+		_, err := server.Register(ctx, &userv1.RegisterRequest{Name: "Alice", Email: "alice@EXAMPLE.com", Password: "another password"})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("weak password", func(t *testing.T) {
+		_, err := server.Register(ctx, &userv1.RegisterRequest{Name: "Bob", Email: "bob@example.com", Password: "short"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("login", func(t *testing.T) {
+		resp, err := server.Login(ctx, &userv1.LoginRequest{Email: "ALICE@example.com", Password: "correct horse"})
+		require.NoError(t, err)
+		assert.Equal(t, registered.User.Id, resp.User.Id)
+		assert.NotEqual(t, registered.Tokens.RefreshToken, resp.Tokens.RefreshToken)
+	})
+
+	t.Run("wrong password and unknown email look the same", func(t *testing.T) {
+		_, wrongPassword := server.Login(ctx, &userv1.LoginRequest{Email: "alice@example.com", Password: "wrong horse"})
+		_, unknownEmail := server.Login(ctx, &userv1.LoginRequest{Email: "nobody@example.com", Password: "correct horse"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(wrongPassword))
+		assert.Equal(t, wrongPassword.Error(), unknownEmail.Error())
+	})
+
+	t.Run("users without a password cannot log in", func(t *testing.T) {
+		_, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Owner", Email: "owner@example.com", IsCafeOwner: true})
+		require.NoError(t, err)
+		_, err = server.Login(ctx, &userv1.LoginRequest{Email: "owner@example.com", Password: ""})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("owners get the owner role", func(t *testing.T) {
+		hash, err := auth.HashPassword("owner password")
+		require.NoError(t, err)
+		require.NoError(t, db.Model(&models.User{}).Where("email = ?", "owner@example.com").Update("password_hash", hash).Error)
+
+		resp, err := server.Login(ctx, &userv1.LoginRequest{Email: "owner@example.com", Password: "owner password"})
+		require.NoError(t, err)
+		claims, err := server.Tokens.Verify(resp.Tokens.AccessToken, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, auth.RoleOwner, claims.Role)
+	})
+
+	t.Run("staff created with a password get the staff role", func(t *testing.T) {
+		created, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Barista", Email: "barista@example.com", IsStaff: true, Password: "staff password"})
+		require.NoError(t, err)
+		assert.True(t, created.User.IsStaff)
+
+		resp, err := server.Login(ctx, &userv1.LoginRequest{Email: "barista@example.com", Password: "staff password"})
+		require.NoError(t, err)
+		claims, err := server.Tokens.Verify(resp.Tokens.AccessToken, time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, auth.RoleStaff, claims.Role)
+
+		_, err = server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Weak", Email: "weak@example.com", Password: "short"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		_, err := NewUserServer().Login(ctx, &userv1.LoginRequest{Email: "alice@example.com", Password: "correct horse"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestBootstrapOwner(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	server := newAuthServer(t)
+
+	created, err := server.BootstrapOwner("Cafe Owner", "Owner@Example.com", "owner password")
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	resp, err := server.Login(context.Background(), &userv1.LoginRequest{Email: "owner@example.com", Password: "owner password"})
+	require.NoError(t, err)
+	assert.True(t, resp.User.IsCafeOwner)
+
+	// Restarting leaves the existing owner alone
+	created, err = server.BootstrapOwner("Cafe Owner", "owner@example.com", "another password")
+	require.NoError(t, err)
+	assert.False(t, created)
+	_, err = server.Login(context.Background(), &userv1.LoginRequest{Email: "owner@example.com", Password: "owner password"})
+	assert.NoError(t, err)
+
+	_, err = server.BootstrapOwner("Cafe Owner", "new-owner@example.com", "")
+	assert.ErrorIs(t, err, auth.ErrWeakPassword)
+}
+
+func TestRefreshToken(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	server := newAuthServer(t)
+	ctx := context.Background()
+	now := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	server.clock = func() time.Time { return now }
+
+	registered, err := server.Register(ctx, &userv1.RegisterRequest{Name: "Alice", Email: "alice@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	t.Run("rotates", func(t *testing.T) {
+		first := registered.Tokens.RefreshToken
+		resp, err := server.RefreshToken(ctx, &userv1.RefreshTokenRequest{RefreshToken: first})
+		require.NoError(t, err)
+		second := resp.Tokens.RefreshToken
+		assert.NotEqual(t, first, second)
+
+		var old models.RefreshToken
+		require.NoError(t, db.Where("token_hash = ?", auth.HashRefreshToken(first)).First(&old).Error)
+		assert.NotNil(t, old.RevokedAt)
+		require.NotNil(t, old.ReplacedByID)
+
+		resp, err = server.RefreshToken(ctx, &userv1.RefreshTokenRequest{RefreshToken: second})
+		require.NoError(t, err)
+		third := resp.Tokens.RefreshToken
+
+		// Reusing a rotated token revokes the whole family, including the newest token
+		_, err = server.RefreshToken(ctx, &userv1.RefreshTokenRequest{RefreshToken: first})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = server.RefreshToken(ctx, &userv1.RefreshTokenRequest{RefreshToken: third})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("other logins are unaffected by a revoked family", func(t *testing.T) {
+		login, err := server.Login(ctx, &userv1.LoginRequest{Email: "alice@example.com", Password: "correct horse"})
+		require.NoError(t, err)
+		_, err = server.RefreshToken(ctx, &userv1.RefreshTokenRequest{RefreshToken: login.Tokens.RefreshToken})
+		assert.NoError(t, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		login, err := server.Login(ctx, &userv1.LoginRequest{Email: "alice@example.com", Password: "correct horse"})
+		require.NoError(t, err)
+
+		server.clock = func() time.Time { return now.Add(auth.DefaultRefreshTokenTTL) }
+		defer func() { server.clock = func() time.Time { return now } }()
+		_, err = server.RefreshToken(ctx, &userv1.RefreshTokenRequest{RefreshToken: login.Tokens.RefreshToken})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := server.RefreshToken(ctx, &userv1.RefreshTokenRequest{RefreshToken: "not-a-token"})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func TestGetJWKS(t *testing.T) {
+	server := newAuthServer(t)
+
+	resp, err := server.GetJWKS(context.Background(), &userv1.GetJWKSRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Keys, 1)
+	key := resp.Keys[0]
+	assert.Equal(t, "OKP", key.Kty)
+	assert.Equal(t, "EdDSA", key.Alg)
+	assert.Equal(t, "sig", key.Use)
+	assert.Equal(t, server.Tokens.Keys()[0].ID, key.Kid)
+	assert.NotEmpty(t, key.X)
+}
+
+func TestUpdateUser(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	server := newAuthServer(t)
+	ctx := context.Background()
+
+	owner, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Owner", Email: "owner@example.com", IsCafeOwner: true})
+	require.NoError(t, err)
+	alice, err := server.Register(ctx, &userv1.RegisterRequest{Name: "Alice", Email: "alice@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	bob, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Bob", Email: "bob@example.com"})
+	require.NoError(t, err)
+
+	asOwner := asCaller(principal.Principal{UserID: owner.User.Id, Role: principal.RoleOwner})
+	asAlice := asCaller(principal.Principal{UserID: alice.User.Id, Role: principal.RoleStudent})
+	mask := func(paths ...string) *fieldmaskpb.FieldMask {
+		return &fieldmaskpb.FieldMask{Paths: paths}
+	}
+
+	t.Run("own name and email", func(t *testing.T) {
+		resp, err := server.UpdateUser(asAlice, &userv1.UpdateUserRequest{
+			User:       &userv1.User{Id: alice.User.Id, Name: " Alice B ", Email: "Alice.B@Example.com", IsCafeOwner: true},
+			UpdateMask: mask("name", "email"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Alice B", resp.User.Name)
+		assert.Equal(t, "alice.b@example.com", resp.User.Email)
+		assert.False(t, resp.User.IsCafeOwner, "fields outside the mask are left alone")
+	})
+
+	t.Run("password signs out everywhere", func(t *testing.T) {
+		_, err := server.UpdateUser(asAlice, &userv1.UpdateUserRequest{
+			User:       &userv1.User{Id: alice.User.Id},
+			UpdateMask: mask("password"),
+			Password:   "battery staple",
+		})
+		require.NoError(t, err)
+
+		_, err = server.RefreshToken(ctx, &userv1.RefreshTokenRequest{RefreshToken: alice.Tokens.RefreshToken})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		_, err = server.Login(ctx, &userv1.LoginRequest{Email: "alice.b@example.com", Password: "battery staple"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("owner changes role", func(t *testing.T) {
+		resp, err := server.UpdateUser(asOwner, &userv1.UpdateUserRequest{
+			User:       &userv1.User{Id: bob.User.Id, IsStaff: true},
+			UpdateMask: mask("is_staff"),
+		})
+		require.NoError(t, err)
+		assert.True(t, resp.User.IsStaff)
+	})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		request  *userv1.UpdateUserRequest
+		wantCode codes.Code
+	}{
+		{"empty mask", asOwner, &userv1.UpdateUserRequest{User: &userv1.User{Id: bob.User.Id}}, codes.InvalidArgument},
+		{"unknown field", asOwner, &userv1.UpdateUserRequest{User: &userv1.User{Id: bob.User.Id}, UpdateMask: mask("created_at")}, codes.InvalidArgument},
+		{"blank name", asOwner, &userv1.UpdateUserRequest{User: &userv1.User{Id: bob.User.Id, Name: " "}, UpdateMask: mask("name")}, codes.InvalidArgument},
+		{"weak password", asAlice, &userv1.UpdateUserRequest{User: &userv1.User{Id: alice.User.Id}, UpdateMask: mask("password"), Password: "short"}, codes.InvalidArgument},
+		{"email taken", asOwner, &userv1.UpdateUserRequest{User: &userv1.User{Id: bob.User.Id, Email: "OWNER@example.com"}, UpdateMask: mask("email")}, codes.AlreadyExists},
+		{"another user", asAlice, &userv1.UpdateUserRequest{User: &userv1.User{Id: bob.User.Id, Name: "Robert"}, UpdateMask: mask("name")}, codes.PermissionDenied},
+		{"own role", asAlice, &userv1.UpdateUserRequest{User: &userv1.User{Id: alice.User.Id, IsCafeOwner: true}, UpdateMask: mask("is_cafe_owner")}, codes.PermissionDenied},
+		{"last owner", asOwner, &userv1.UpdateUserRequest{User: &userv1.User{Id: owner.User.Id}, UpdateMask: mask("is_cafe_owner")}, codes.FailedPrecondition},
+		{"unknown user", asOwner, &userv1.UpdateUserRequest{User: &userv1.User{Id: 999, Name: "Nobody"}, UpdateMask: mask("name")}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server.UpdateUser(tt.ctx, tt.request)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+// fakeOrderService stands in for the Order Service when erasing users
+type fakeOrderService struct {
+	orderv1.OrderServiceClient
+	err      error    // Returned by every call when set
+	detached []uint32 // Users whose orders were detached
+}
+
+func (f *fakeOrderService) DetachUserOrders(ctx context.Context, req *orderv1.DetachUserOrdersRequest, opts ...grpc.CallOption) (*orderv1.DetachUserOrdersResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.detached = append(f.detached, req.UserId)
+	return &orderv1.DetachUserOrdersResponse{OrdersDetached: 2}, nil
+}
+
+func TestDeleteUser(t *testing.T) {
+	// Setup
+	db := setupTestDB(t)
+	defer teardownTestDB(t, db)
+	database.DB = db
+
+	server := newAuthServer(t)
+	orders := &fakeOrderService{}
+	server.Orders = orders
+	ctx := context.Background()
+
+	owner, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Owner", Email: "owner@example.com", IsCafeOwner: true})
+	require.NoError(t, err)
+	alice, err := server.Register(ctx, &userv1.RegisterRequest{Name: "Alice", Email: "alice@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	asOwner := asCaller(principal.Principal{UserID: owner.User.Id, Role: principal.RoleOwner})
+	asAlice := asCaller(principal.Principal{UserID: alice.User.Id, Role: principal.RoleStudent})
+
+	// Test
+	resp, err := server.DeleteUser(asAlice, &userv1.DeleteUserRequest{Id: alice.User.Id})
+	require.NoError(t, err)
+
+	// Assert: the erasure completed and is auditable
+	erasure := resp.Erasure
+	assert.Equal(t, models.ErasureCompleted, erasure.Status)
+	assert.Equal(t, alice.User.Id, erasure.UserId)
+	assert.Equal(t, fmt.Sprintf("student %d", alice.User.Id), erasure.RequestedBy)
+	assert.Equal(t, int64(2), erasure.OrdersDetached)
+	assert.Equal(t, int32(1), erasure.Attempts)
+	assert.NotEmpty(t, erasure.CompletedAt)
+	assert.Equal(t, []uint32{alice.User.Id}, orders.detached)
+
+	// The user is gone, anonymised and signed out
+	_, err = server.GetUser(ctx, &userv1.GetUserRequest{Id: alice.User.Id})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	var stored models.User
+	require.NoError(t, db.Unscoped().First(&stored, alice.User.Id).Error)
+	assert.Equal(t, "Deleted user", stored.Name)
+	assert.NotContains(t, stored.Email, "alice")
+	assert.Empty(t, stored.PasswordHash)
+	_, err = server.Login(ctx, &userv1.LoginRequest{Email: "alice@example.com", Password: "correct horse"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = server.RefreshToken(ctx, &userv1.RefreshTokenRequest{RefreshToken: alice.Tokens.RefreshToken})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// The email is free for a new account
+	_, err = server.Register(ctx, &userv1.RegisterRequest{Name: "Alice", Email: "alice@example.com", Password: "correct horse"})
+	assert.NoError(t, err)
+
+	t.Run("again", func(t *testing.T) {
+		again, err := server.DeleteUser(asOwner, &userv1.DeleteUserRequest{Id: alice.User.Id})
+		require.NoError(t, err)
+		assert.Equal(t, erasure.Id, again.Erasure.Id)
+		assert.Len(t, orders.detached, 1, "completed erasures are not repeated")
+	})
+
+	t.Run("order service down", func(t *testing.T) {
+		bob, err := server.CreateUser(ctx, &userv1.CreateUserRequest{Name: "Bob", Email: "bob@example.com"})
+		require.NoError(t, err)
+
+		orders.err = status.Errorf(codes.Unavailable, "connection refused")
+		resp, err := server.DeleteUser(asOwner, &userv1.DeleteUserRequest{Id: bob.User.Id})
+		require.NoError(t, err, "the user is erased even if their orders cannot be detached yet")
+		assert.Equal(t, models.ErasurePending, resp.Erasure.Status)
+		assert.Contains(t, resp.Erasure.LastError, "connection refused")
+		assert.Equal(t, fmt.Sprintf("owner %d", owner.User.Id), resp.Erasure.RequestedBy)
+		_, err = server.GetUser(ctx, &userv1.GetUserRequest{Id: bob.User.Id})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		// Recovery finishes it once the Order Service is back
+		assert.Error(t, server.RecoverErasures(ctx, 0))
+		orders.err = nil
+		require.NoError(t, server.RecoverErasures(ctx, 0))
+
+		list, err := server.ListErasures(ctx, &userv1.ListErasuresRequest{})
+		require.NoError(t, err)
+		require.Len(t, list.Erasures, 2)
+		assert.Equal(t, bob.User.Id, list.Erasures[0].UserId, "newest first")
+		assert.Equal(t, models.ErasureCompleted, list.Erasures[0].Status)
+		assert.Equal(t, int32(3), list.Erasures[0].Attempts)
+		assert.Empty(t, list.Erasures[0].LastError)
+
+		pending, err := server.ListErasures(ctx, &userv1.ListErasuresRequest{Status: models.ErasurePending})
+		require.NoError(t, err)
+		assert.Empty(t, pending.Erasures)
+	})
+
+	t.Run("another user", func(t *testing.T) {
+		asStaff := asCaller(principal.Principal{UserID: alice.User.Id + 100, Role: principal.RoleStaff})
+		_, err := server.DeleteUser(asStaff, &userv1.DeleteUserRequest{Id: owner.User.Id})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("last owner", func(t *testing.T) {
+		_, err := server.DeleteUser(asOwner, &userv1.DeleteUserRequest{Id: owner.User.Id})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := server.DeleteUser(asOwner, &userv1.DeleteUserRequest{Id: 999})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestPolicy(t *testing.T) {
+	// Every method has a rule, so none is left uncallable
+	for _, method := range userv1.UserService_ServiceDesc.Methods {
+		assert.Contains(t, Policy, "/"+userv1.UserService_ServiceDesc.ServiceName+"/"+method.MethodName)
+	}
+	for _, stream := range userv1.UserService_ServiceDesc.Streams {
+		assert.Contains(t, Policy, "/"+userv1.UserService_ServiceDesc.ServiceName+"/"+stream.StreamName)
+	}
+
+	student := asCaller(principal.Principal{UserID: 1, Role: principal.RoleStudent})
+	assert.NoError(t, Policy.Authorize(context.Background(), userv1.UserService_Login_FullMethodName))
+	assert.Equal(t, codes.PermissionDenied, status.Code(Policy.Authorize(student, userv1.UserService_CreateUser_FullMethodName)))
+	assert.Equal(t, codes.PermissionDenied, status.Code(Policy.Authorize(student, userv1.UserService_GetUsers_FullMethodName)))
+	assert.NoError(t, Policy.Authorize(asCaller(principal.Service), userv1.UserService_GetUser_FullMethodName))
+	assert.NoError(t, Policy.Authorize(student, userv1.UserService_DeleteUser_FullMethodName))
+	assert.Equal(t, codes.PermissionDenied, status.Code(Policy.Authorize(student, userv1.UserService_ListErasures_FullMethodName)))
 }
\ No newline at end of file
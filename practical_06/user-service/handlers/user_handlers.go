@@ -1,40 +0,0 @@
-package handlers
-
-import (
-    "encoding/json"
-    "net/http"
-    "user-service/database"
-    "user-service/models"
-
-    "github.com/go-chi/chi/v5"
-)
-
-func CreateUser(w http.ResponseWriter, r *http.Request) {
-    var user models.User
-    if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
-        http.Error(w, err.Error(), http.StatusBadRequest)
-        return
-    }
-
-    if err := database.DB.Create(&user).Error; err != nil {
-        http.Error(w, err.Error(), http.StatusInternalServerError)
-        return
-    }
-
-    w.Header().Set("Content-Type", "application/json")
-    w.WriteHeader(http.StatusCreated)
-    json.NewEncoder(w).Encode(user)
-}
-
-func GetUser(w http.ResponseWriter, r *http.Request) {
-    id := chi.URLParam(r, "id")
-
-    var user models.User
-    if err := database.DB.First(&user, id).Error; err != nil {
-        http.Error(w, "User not found", http.StatusNotFound)
-        return
-    }
-
-    w.Header().Set("Content-Type", "application/json")
-    json.NewEncoder(w).Encode(user)
-}
\ No newline at end of file
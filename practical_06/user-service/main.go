@@ -1,77 +1,165 @@
 package main
 
 import (
-    "log"
-    "net/http"
-    "os"
-    "user-service/database"
-    "user-service/handlers"
-
-    "github.com/go-chi/chi/v5"
-    "github.com/go-chi/chi/v5/middleware"
-    
-    "fmt"
-    consulapi "github.com/hashicorp/consul/api"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"time"
+	"user-service/auth"
+	"user-service/database"
+	grpcserver "user-service/grpc"
+
+	"github.com/douglasswm/student-cafe-protos/authz"
+	orderv1 "github.com/douglasswm/student-cafe-protos/gen/go/order/v1"
+	userv1 "github.com/douglasswm/student-cafe-protos/gen/go/user/v1"
+	"github.com/douglasswm/student-cafe-protos/mtls"
+	"google.golang.org/grpc"
 )
 
-func registerWithConsul(serviceName string, port int) error {
-    config := consulapi.DefaultConfig()
-    config.Address = "consul:8500"
-
-    consul, err := consulapi.NewClient(config)
-    if err != nil {
-        return err
-    }
-
-    hostname, _ := os.Hostname()
-
-    registration := &consulapi.AgentServiceRegistration{
-        ID:      fmt.Sprintf("%s-%s", serviceName, hostname),
-        Name:    serviceName,
-        Port:    port,
-        Address: hostname,
-        Check: &consulapi.AgentServiceCheck{
-            HTTP:     fmt.Sprintf("http://%s:%d/health", hostname, port),
-            Interval: "10s",
-            Timeout:  "3s",
-        },
-    }
-
-    return consul.Agent().ServiceRegister(registration)
-}
+// erasureRecoveryInterval is how often pending user erasures are retried
+const erasureRecoveryInterval = time.Minute
 
 func main() {
-    // Connect to dedicated user database
-    dsn := os.Getenv("DATABASE_URL")
-    if dsn == "" {
-        dsn = "host=localhost user=postgres password=postgres dbname=user_db port=5432 sslmode=disable"
-    }
-
-    if err := database.Connect(dsn); err != nil {
-        log.Fatalf("Failed to connect to database: %v", err)
-    }
-
-    r := chi.NewRouter()
-    r.Use(middleware.Logger)
-
-    // Add health endpoint
-    r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-        w.WriteHeader(http.StatusOK)
-    })
-
-    r.Post("/users", handlers.CreateUser)
-    r.Get("/users/{id}", handlers.GetUser)
-
-    // Register with Consul
-    if err := registerWithConsul("user-service", 8081); err != nil {
-        log.Printf("Failed to register with Consul: %v", err)
-    }
-
-    port := os.Getenv("PORT")
-    if port == "" {
-        port = "8081"
-    }
-
-    log.Printf("User service starting on :%s", port)
-    http.ListenAndServe(":"+port, r)
+	// Connect to dedicated user database
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		dsn = "host=localhost user=postgres password=postgres dbname=user_db port=5432 sslmode=disable"
+	}
+
+	if err := database.Connect(dsn); err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	// Get gRPC port from environment
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9091"
+	}
+
+	// Start listening on TCP port
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
+	if err != nil {
+		log.Fatalf("Failed to listen on gRPC port %s: %v", grpcPort, err)
+	}
+
+	// Load the keys access tokens are signed and verified with
+	issuer, err := loadIssuer()
+	if err != nil {
+		log.Fatalf("Failed to set up token signing: %v", err)
+	}
+	userServer := grpcserver.NewUserServer()
+	userServer.Tokens = issuer
+
+	// Create the first cafe owner, who can then create staff and other owners
+	if email := os.Getenv("OWNER_EMAIL"); email != "" {
+		name := os.Getenv("OWNER_NAME")
+		if name == "" {
+			name = "Cafe Owner"
+		}
+		created, err := userServer.BootstrapOwner(name, email, os.Getenv("OWNER_PASSWORD"))
+		if err != nil {
+			log.Fatalf("Failed to create the cafe owner: %v", err)
+		}
+		if created {
+			log.Printf("Created cafe owner %s", email)
+		}
+	}
+
+	// The gateway and the other services are only talked to over mutual TLS
+	tlsConfig, err := mtls.FromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure TLS: %v", err)
+	}
+	serverCreds, err := tlsConfig.ServerCredentials()
+	if err != nil {
+		log.Fatalf("Failed to load TLS credentials: %v", err)
+	}
+	clientCreds, err := tlsConfig.ClientCredentials()
+	if err != nil {
+		log.Fatalf("Failed to load TLS credentials: %v", err)
+	}
+
+	// Deleted users' orders are detached in the Order Service, calling it as a
+	// service; erasures it cannot complete straight away are retried
+	orderServiceAddr := os.Getenv("ORDER_SERVICE_GRPC_ADDR")
+	if orderServiceAddr == "" {
+		orderServiceAddr = "order-service:9093"
+	}
+	orderConn, err := grpc.NewClient(
+		orderServiceAddr,
+		grpc.WithTransportCredentials(clientCreds),
+		authz.AsService(),
+	)
+	if err != nil {
+		log.Fatalf("Failed to create order service client: %v", err)
+	}
+	defer orderConn.Close()
+	userServer.Orders = orderv1.NewOrderServiceClient(orderConn)
+	go userServer.RunErasureRecovery(context.Background(), erasureRecoveryInterval)
+
+	// Create and register gRPC server, authorizing calls by the caller's role
+	s := grpc.NewServer(append(authz.ServerOptions(grpcserver.Policy), grpc.Creds(serverCreds))...)
+	userv1.RegisterUserServiceServer(s, userServer)
+
+	log.Printf("User service (gRPC only) starting on :%s", grpcPort)
+	if err := s.Serve(lis); err != nil {
+		log.Fatalf("gRPC server failed: %v", err)
+	}
+}
+
+// loadIssuer creates the access token issuer from the environment.
+// JWT_SIGNING_KEY_FILE is a PEM RSA or Ed25519 private key; without one an
+// ephemeral key is generated. JWT_VERIFICATION_KEY_FILES is a comma separated
+// list of extra keys, such as the previous signing key while rotating, whose
+// tokens are still accepted.
+func loadIssuer() (*auth.Issuer, error) {
+	var signing *auth.Key
+	var err error
+	if path := os.Getenv("JWT_SIGNING_KEY_FILE"); path != "" {
+		signing, err = auth.LoadSigningKey(path)
+	} else {
+		log.Println("JWT_SIGNING_KEY_FILE not set, signing tokens with an ephemeral key that is lost on restart")
+		signing, err = auth.GenerateKey()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var verification []*auth.Key
+	for _, path := range strings.Split(os.Getenv("JWT_VERIFICATION_KEY_FILES"), ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		key, err := auth.LoadVerificationKey(path)
+		if err != nil {
+			return nil, err
+		}
+		verification = append(verification, key)
+	}
+
+	issuer, err := auth.NewIssuer(signing, verification...)
+	if err != nil {
+		return nil, err
+	}
+	if value := os.Getenv("JWT_ISSUER"); value != "" {
+		issuer.Issuer = value
+	}
+	if value := os.Getenv("JWT_AUDIENCE"); value != "" {
+		issuer.Audience = value
+	}
+	if value := os.Getenv("ACCESS_TOKEN_TTL"); value != "" {
+		if issuer.AccessTokenTTL, err = time.ParseDuration(value); err != nil {
+			return nil, fmt.Errorf("invalid ACCESS_TOKEN_TTL %q: %v", value, err)
+		}
+	}
+	if value := os.Getenv("REFRESH_TOKEN_TTL"); value != "" {
+		if issuer.RefreshTokenTTL, err = time.ParseDuration(value); err != nil {
+			return nil, fmt.Errorf("invalid REFRESH_TOKEN_TTL %q: %v", value, err)
+		}
+	}
+	log.Printf("Signing access tokens with %s key %s", signing.Algorithm, signing.ID)
+	return issuer, nil
 }
\ No newline at end of file
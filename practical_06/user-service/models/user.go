@@ -1,9 +1,51 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type User struct {
-    gorm.Model
-    Name  string `json:"name"`
-    Email string `json:"email" gorm:"unique"`
+	gorm.Model
+	Name         string `json:"name"`
+	Email        string `json:"email" gorm:"unique"`
+	IsCafeOwner  bool   `json:"is_cafe_owner" gorm:"not null;default:false"` // Owners manage the menu
+	IsStaff      bool   `json:"is_staff" gorm:"not null;default:false"`      // Staff progress every order
+	PasswordHash string `json:"-"`                                           // bcrypt; empty for users who cannot sign in
+}
+
+// RefreshToken is a single use refresh token. Refreshing revokes the token and
+// issues a replacement in the same family; a family is every token descended
+// from one login, and is revoked as a whole if a revoked token is reused.
+type RefreshToken struct {
+	ID           uint       `json:"id" gorm:"primarykey"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UserID       uint       `json:"user_id" gorm:"index"`
+	TokenHash    string     `json:"-" gorm:"uniqueIndex"` // see auth.HashRefreshToken
+	FamilyID     string     `json:"family_id" gorm:"index"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	ReplacedByID *uint      `json:"replaced_by_id"`
+}
+
+// Erasure statuses. An erasure is pending until the Order Service has detached
+// the user's orders, then completed.
+const (
+	ErasurePending   = "pending"
+	ErasureCompleted = "completed"
+)
+
+// UserErasure is the audit record of deleting a user. Their personal data is
+// anonymised as soon as they are deleted; detaching their orders in the Order
+// Service is retried until it succeeds, so the record also tracks that step.
+type UserErasure struct {
+	gorm.Model
+	UserID         uint       `json:"user_id" gorm:"uniqueIndex"`
+	RequestedBy    string     `json:"requested_by"`        // Caller who deleted the user, e.g. "owner 1"
+	Status         string     `json:"status" gorm:"index"` // see Erasure* constants
+	OrdersDetached int64      `json:"orders_detached"`
+	Attempts       int        `json:"attempts"`   // Calls made to the Order Service
+	LastError      string     `json:"last_error"` // Why the last attempt failed
+	CompletedAt    *time.Time `json:"completed_at"`
 }
\ No newline at end of file